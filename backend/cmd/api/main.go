@@ -32,22 +32,53 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/metrics"
 	"github.com/ketches/ketches/internal/middlewares"
 	"github.com/ketches/ketches/internal/routes"
+	"github.com/ketches/ketches/internal/services"
+	"github.com/ketches/ketches/internal/tracing"
 	_ "github.com/ketches/ketches/openapi"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // @title Ketches API Server
 // @description Ketches api server
 // @version v1
 func main() {
+	logging.Setup()
+
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+
 	server := http.Server{
 		Addr:    fmt.Sprintf("%s:%s", app.GetEnv("APP_HOST", "0.0.0.0"), app.GetEnv("APP_PORT", "8080")), // default to 8080 if not set
 		Handler: newHttpHandler(),
 	}
 
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go services.RunVolumeSnapshotScheduler(schedulerCtx)
+	go services.RunAppReleaseScheduler(schedulerCtx)
+
+	var metricsServer *http.Server
+	if addr := app.MetricsAddr(); addr != "" {
+		metricsServer = &http.Server{
+			Addr:    addr,
+			Handler: newMetricsHandler(),
+		}
+		go func() {
+			logging.Infof(context.Background(), "Ketches api metrics are served on http://%s/metrics", metricsServer.Addr)
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("metrics listen and serve err: %v", err)
+			}
+		}()
+	}
+
 	go func() {
-		log.Printf("Ketches api server is listening on http://%s\n", server.Addr)
+		logging.Infof(context.Background(), "Ketches api server is listening on http://%s", server.Addr)
 		if err := server.ListenAndServe(); err != nil {
 			log.Fatalf("listen and serve err: %v", err)
 		}
@@ -56,7 +87,8 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("shutting down server ...")
+	logging.Infof(context.Background(), "shutting down server ...")
+	stopScheduler()
 
 	shutdownTimeoutErr := fmt.Errorf("shutting down server timeout")
 	ctx, cancel := context.WithTimeoutCause(context.Background(), 5*time.Second, shutdownTimeoutErr)
@@ -69,19 +101,42 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("server forced to shutdown: %v", err)
 	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logging.Errorf(ctx, "failed to shut down metrics server: %v", err)
+		}
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logging.Errorf(ctx, "failed to flush traces: %v", err)
+	}
 
-	log.Println("server exited")
+	logging.Infof(context.Background(), "server exited")
 }
 
 func newHttpHandler() http.Handler {
 	gin.SetMode(gin.ReleaseMode)
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.Recovery())
+	// Services take the gin context as their context, it must carry the
+	// values of the request context such as the trace span.
+	r.ContextWithFallback = true
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("slug", api.ValidateSlug)
 	}
+	r.Use(middlewares.RequestIDMiddleware())
+	r.Use(middlewares.Logger())
 	r.Use(middlewares.Cors())
+	r.Use(middlewares.Metrics())
+	r.Use(otelgin.Middleware(tracing.ServiceName))
 
 	routes.NewRoute(r).Register()
 
 	return r
 }
+
+// newMetricsHandler serves the Prometheus metrics on their own listener.
+func newMetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	return mux
+}
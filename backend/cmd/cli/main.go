@@ -1 +1,42 @@
-package cli
+// Command ketches is the command line client of the Ketches API.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: ketches <command> [flags] [args]
+
+Commands:
+  port-forward   Forward local ports to an app instance
+
+Environment:
+  KETCHES_SERVER   Address of the Ketches API, e.g. https://ketches.example.com
+  KETCHES_TOKEN    Access token of the user
+
+Run "ketches <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "port-forward":
+		err = runPortForward(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/gorilla/websocket"
+	kwebsocket "github.com/ketches/ketches/pkg/websocket"
+)
+
+type portMapping struct {
+	local  int
+	remote int
+}
+
+func runPortForward(args []string) error {
+	fs := flag.NewFlagSet("port-forward", flag.ExitOnError)
+	server := fs.String("server", os.Getenv("KETCHES_SERVER"), "Address of the Ketches API")
+	token := fs.String("token", os.Getenv("KETCHES_TOKEN"), "Access token of the user")
+	address := fs.String("address", "127.0.0.1", "Local address to listen on")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: ketches port-forward [flags] APP_ID INSTANCE_NAME [LOCAL_PORT:]REMOTE_PORT [...]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 3 {
+		fs.Usage()
+		return errors.New("app ID, instance name and at least one port are required")
+	}
+	if *server == "" {
+		return errors.New("server address is required, set --server or KETCHES_SERVER")
+	}
+	if *token == "" {
+		return errors.New("access token is required, set --token or KETCHES_TOKEN")
+	}
+
+	appID, instanceName := fs.Arg(0), fs.Arg(1)
+	mappings := make([]portMapping, 0, fs.NArg()-2)
+	for _, arg := range fs.Args()[2:] {
+		m, err := parsePortMapping(arg)
+		if err != nil {
+			return err
+		}
+		mappings = append(mappings, m)
+	}
+
+	base, err := portForwardURL(*server, appID, instanceName)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+*token)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+	for _, m := range mappings {
+		listener, err := net.Listen("tcp", net.JoinHostPort(*address, strconv.Itoa(m.local)))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Forwarding from %s -> %d\n", listener.Addr(), m.remote)
+
+		go func() {
+			<-ctx.Done()
+			listener.Close()
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			forwardListener(ctx, listener, base, header, m.remote)
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// forwardListener tunnels each accepted connection over its own websocket
+// until the listener is closed, connections still open are closed with it.
+func forwardListener(ctx context.Context, listener net.Listener, base *url.URL, header http.Header, remotePort int) {
+	u := *base
+	u.RawQuery = url.Values{"port": {strconv.Itoa(remotePort)}}.Encode()
+
+	var conns sync.WaitGroup
+	defer conns.Wait()
+	for {
+		local, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				log.Printf("failed to accept connection: %v", err)
+			}
+			return
+		}
+
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			stop := context.AfterFunc(ctx, func() { local.Close() })
+			defer stop()
+
+			ws, resp, err := websocket.DefaultDialer.DialContext(ctx, u.String(), header)
+			if err != nil {
+				if resp != nil {
+					err = fmt.Errorf("%w (%s)", err, resp.Status)
+				}
+				log.Printf("failed to forward connection from %s: %v", local.RemoteAddr(), err)
+				local.Close()
+				return
+			}
+			if err := kwebsocket.Pipe(ws, local); err != nil {
+				log.Printf("connection from %s closed: %v", local.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// parsePortMapping parses "8080" or "18080:8080", the local port defaults to
+// the remote port.
+func parsePortMapping(s string) (portMapping, error) {
+	local, remote, found := strings.Cut(s, ":")
+	if !found {
+		remote = local
+	}
+	localPort, err := strconv.Atoi(local)
+	if err != nil || localPort < 0 || localPort > 65535 {
+		return portMapping{}, fmt.Errorf("invalid local port in %q", s)
+	}
+	remotePort, err := strconv.Atoi(remote)
+	if err != nil || remotePort < 1 || remotePort > 65535 {
+		return portMapping{}, fmt.Errorf("invalid remote port in %q", s)
+	}
+	return portMapping{local: localPort, remote: remotePort}, nil
+}
+
+// portForwardURL returns the websocket URL of the port forward endpoint.
+func portForwardURL(server, appID, instanceName string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSuffix(server, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid server address: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	u.Path += "/api/v1/apps/" + url.PathEscape(appID) + "/instances/" + url.PathEscape(instanceName) + "/port-forward"
+	return u, nil
+}
@@ -11,6 +11,9 @@ const contextKeyUserID = "user_id"
 const contextKeyUserRole = "user_role"
 const contextKeyProjectRole = "project_role"
 const contextKeyRequestID = "request_id"
+const contextKeyProjectID = "project_id"
+const contextKeyEnvID = "env_id"
+const contextKeyAppID = "app_id"
 
 func SetUserID(ctx *gin.Context, userID string) {
 	ctx.Set(contextKeyUserID, userID)
@@ -75,3 +78,32 @@ func RequestID(ctx context.Context) string {
 	}
 	return requestID.(string)
 }
+
+// SetResourceIDs sets the project, env and app IDs of the route, which scope
+// the logs of the request.
+func SetResourceIDs(ctx *gin.Context) {
+	if projectID := ctx.Param("projectID"); projectID != "" {
+		ctx.Set(contextKeyProjectID, projectID)
+	}
+	if envID := ctx.Param("envID"); envID != "" {
+		ctx.Set(contextKeyEnvID, envID)
+	}
+	if appID := ctx.Param("appID"); appID != "" {
+		ctx.Set(contextKeyAppID, appID)
+	}
+}
+
+func ProjectID(ctx context.Context) string {
+	projectID, _ := ctx.Value(contextKeyProjectID).(string)
+	return projectID
+}
+
+func EnvID(ctx context.Context) string {
+	envID, _ := ctx.Value(contextKeyEnvID).(string)
+	return envID
+}
+
+func AppID(ctx context.Context) string {
+	appID, _ := ctx.Value(contextKeyAppID).(string)
+	return appID
+}
@@ -24,8 +24,9 @@ import (
 )
 
 type Response struct {
-	Data  any    `json:"data,omitempty"`
-	Error string `json:"error,omitempty"`
+	Data    any    `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
 }
 
 func Created(c *gin.Context, data any) {
@@ -45,7 +46,11 @@ func NoContent(c *gin.Context) {
 }
 
 func Error(c *gin.Context, err app.Error) {
-	c.AbortWithStatusJSON(err.Code(), Response{
+	resp := Response{
 		Error: err.Message(),
-	})
+	}
+	if detailed, ok := err.(app.DetailedError); ok {
+		resp.Details = detailed.Details()
+	}
+	c.AbortWithStatusJSON(err.Code(), resp)
 }
@@ -20,6 +20,9 @@ const (
 	AppStatusCompleted  AppStatus = "completed"
 	AppStatusDebugging  AppStatus = "debugging"
 	AppStatusUnknown    AppStatus = "unknown"
+
+	AppStatusRolloutFailed AppStatus = "rolloutFailed"
+	AppStatusReleasing     AppStatus = "releasing" // Stable and canary editions running side by side
 )
 
 type AppAction = string
@@ -31,11 +34,18 @@ const (
 	AppActionRollback AppAction = "rollback"
 	AppActionUpdate   AppAction = "update"
 	AppActionRedeploy AppAction = "redeploy"
-	AppActionDebug    AppAction = "debug"
+	AppActionDebug    AppAction = "debug" // Redeploys the main container sleeping, running instances use ephemeral debug containers instead
 	AppActionDebugOff AppAction = "debugOff"
 	AppActionDelete   AppAction = "delete"
 )
 
+type ClusterRoutingBackend = string
+
+const (
+	ClusterRoutingBackendGatewayAPI ClusterRoutingBackend = "gatewayAPI" // HTTPRoutes attached to the Gateway of each env
+	ClusterRoutingBackendIngress    ClusterRoutingBackend = "ingress"    // networking.k8s.io/v1 Ingresses, http and https gateways only
+)
+
 type AppGatewayProtocol = string
 
 const (
@@ -78,3 +88,91 @@ const (
 	SchedulingRuleTolerationEffectNoExecute        = "NoExecute"
 	SchedulingRuleTolerationEffectPreferNoSchedule = "PreferNoSchedule"
 )
+
+type RolloutStrategyType = string
+
+const (
+	RolloutStrategyTypeRollingUpdate RolloutStrategyType = "RollingUpdate"
+	RolloutStrategyTypeRecreate      RolloutStrategyType = "Recreate"
+	RolloutStrategyTypeOnDelete      RolloutStrategyType = "OnDelete"
+)
+
+type RolloutState = string
+
+const (
+	RolloutStateProgressing RolloutState = "progressing"
+	RolloutStateComplete    RolloutState = "complete"
+	RolloutStateFailed      RolloutState = "failed"
+)
+
+type ReleaseType = string
+
+const (
+	ReleaseTypeCanary    ReleaseType = "canary"    // Shift traffic to the new edition stepwise
+	ReleaseTypeBlueGreen ReleaseType = "blueGreen" // Switch all traffic to the new edition at once
+)
+
+type ReleaseState = string
+
+const (
+	ReleaseStateProgressing ReleaseState = "progressing"
+	ReleaseStatePaused      ReleaseState = "paused"
+	ReleaseStateSwitched    ReleaseState = "switched"  // Blue-green only, traffic served by the new edition, the old one kept for rollback
+	ReleaseStatePromoting   ReleaseState = "promoting" // The stable workload rolls out the new edition, the canary workload serves until it is done
+	ReleaseStatePromoted    ReleaseState = "promoted"
+	ReleaseStateAborted     ReleaseState = "aborted"
+)
+
+type ReleaseAction = string
+
+const (
+	ReleaseActionPause   ReleaseAction = "pause"
+	ReleaseActionResume  ReleaseAction = "resume"
+	ReleaseActionPromote ReleaseAction = "promote"
+	ReleaseActionAbort   ReleaseAction = "abort"
+)
+
+type AppContainerType = string
+
+const (
+	AppContainerTypeMain    AppContainerType = "main"
+	AppContainerTypeSidecar AppContainerType = "sidecar"
+	AppContainerTypeInit    AppContainerType = "init"
+	AppContainerTypeDebug   AppContainerType = "debug" // Ephemeral debug container
+)
+
+type AppVolumeType = string
+
+const (
+	AppVolumeTypeEmptyDir      AppVolumeType = "emptyDir"
+	AppVolumeTypePVC           AppVolumeType = "pvc"
+	AppVolumeTypeHostPath      AppVolumeType = "hostPath"
+	AppVolumeTypeNFS           AppVolumeType = "nfs"
+	AppVolumeTypeExistingClaim AppVolumeType = "existingClaim"
+	AppVolumeTypeShared        AppVolumeType = "shared"
+)
+
+type ManifestDiffAction = string
+
+const (
+	ManifestDiffActionCreate    ManifestDiffAction = "create"
+	ManifestDiffActionUpdate    ManifestDiffAction = "update"
+	ManifestDiffActionUnchanged ManifestDiffAction = "unchanged"
+	ManifestDiffActionPrune     ManifestDiffAction = "prune"
+	ManifestDiffActionFailed    ManifestDiffAction = "failed"
+)
+
+type EnvNetworkPolicyMode = string
+
+const (
+	EnvNetworkPolicyModeOpen           EnvNetworkPolicyMode = "open"             // No restriction
+	EnvNetworkPolicyModeIsolate        EnvNetworkPolicyMode = "isolate"          // Reject traffic from other envs
+	EnvNetworkPolicyModeDenyAllIngress EnvNetworkPolicyMode = "deny-all-ingress" // Reject all traffic not allowed by app network policies
+)
+
+type EnvAction = string
+
+const (
+	EnvActionStart EnvAction = "start" // Start the apps of the env, dependencies first
+	EnvActionStop  EnvAction = "stop"  // Stop the apps of the env, dependents first
+)
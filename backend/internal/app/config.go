@@ -1,7 +1,10 @@
 package app
 
 import (
+	"log/slog"
 	"os"
+	"strconv"
+	"time"
 )
 
 // GetEnv returns the value of the environment variable named by the key.
@@ -12,3 +15,123 @@ func GetEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+// GetDurationEnv returns the duration of the environment variable named by
+// the key, e.g. "30m". It returns defaultVal if the variable is not present
+// or invalid.
+func GetDurationEnv(key string, defaultVal time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		slog.Warn("invalid duration, using the default", "key", key, "value", val, "default", defaultVal)
+		return defaultVal
+	}
+	return d
+}
+
+// GetInt64Env returns the integer of the environment variable named by the
+// key. It returns defaultVal if the variable is not present or invalid.
+func GetInt64Env(key string, defaultVal int64) int64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		slog.Warn("invalid integer, using the default", "key", key, "value", val, "default", defaultVal)
+		return defaultVal
+	}
+	return i
+}
+
+// GetFloat64Env returns the number of the environment variable named by the
+// key. It returns defaultVal if the variable is not present or invalid.
+func GetFloat64Env(key string, defaultVal float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		slog.Warn("invalid number, using the default", "key", key, "value", val, "default", defaultVal)
+		return defaultVal
+	}
+	return f
+}
+
+// GetBoolEnv returns the boolean of the environment variable named by the
+// key, e.g. "true" or "1". It returns defaultVal if the variable is not present
+// or invalid.
+func GetBoolEnv(key string, defaultVal bool) bool {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		slog.Warn("invalid boolean, using the default", "key", key, "value", val, "default", defaultVal)
+		return defaultVal
+	}
+	return b
+}
+
+// DebugImage returns the default image of ephemeral debug containers.
+func DebugImage() string {
+	return GetEnv("APP_DEBUG_IMAGE", "busybox:1.36")
+}
+
+// TerminalIdleTimeout returns how long a web terminal stays open without
+// input, zero keeps it open.
+func TerminalIdleTimeout() time.Duration {
+	return GetDurationEnv("APP_TERMINAL_IDLE_TIMEOUT", 30*time.Minute)
+}
+
+// TerminalRecordingDir returns the directory web terminal sessions are
+// recorded to, recording is disabled when it is empty. The directory is local
+// to the api server, recordings are only served by the replica that wrote
+// them unless it is on storage shared by all replicas.
+func TerminalRecordingDir() string {
+	return GetEnv("APP_TERMINAL_RECORDING_DIR", "")
+}
+
+// TerminalRecordInput returns whether terminal recordings include what users
+// type, which may contain passwords and secrets. Only output is recorded by
+// default.
+func TerminalRecordInput() bool {
+	return GetBoolEnv("APP_TERMINAL_RECORD_INPUT", false)
+}
+
+// RegistryTestAllowPrivateNetworks returns whether registry credential tests
+// may connect to loopback, private and link-local addresses, e.g. for
+// registries in the cluster network. They are refused by default.
+func RegistryTestAllowPrivateNetworks() bool {
+	return GetBoolEnv("APP_REGISTRY_TEST_ALLOW_PRIVATE_NETWORKS", false)
+}
+
+// FileTransferMaxSize returns the maximum bytes of a file upload or download
+// to app containers, configured in MiB.
+func FileTransferMaxSize() int64 {
+	return GetInt64Env("APP_FILE_TRANSFER_MAX_SIZE", 512) << 20
+}
+
+// MetricsAddr returns the address the Prometheus metrics are served on, apart
+// from the public API so they are not exposed with it. Empty disables them.
+func MetricsAddr() string {
+	return GetEnv("APP_METRICS_ADDR", ":9090")
+}
+
+// GatewayNamespace returns the namespace the gateway data plane runs in,
+// app network policies allowing gateway traffic admit pods from it.
+func GatewayNamespace() string {
+	return GetEnv("APP_GATEWAY_NAMESPACE", "nginx-gateway")
+}
+
+// IngressNamespace returns the namespace the ingress controller runs in on
+// clusters routing through Ingresses, app network policies allowing gateway
+// traffic admit pods from it there.
+func IngressNamespace() string {
+	return GetEnv("APP_INGRESS_NAMESPACE", "ingress-nginx")
+}
@@ -16,13 +16,22 @@ limitations under the License.
 
 package app
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+)
 
 type Error interface {
 	Code() int
 	Message() string
 }
 
+// DetailedError is an Error carrying structured details for the response.
+type DetailedError interface {
+	Error
+	Details() any
+}
+
 type appError struct {
 	code    int
 	message string
@@ -62,6 +71,44 @@ var (
 	}
 )
 
+// FieldConflict is a field that another field manager owns and a server-side
+// apply tried to set to a different value.
+type FieldConflict struct {
+	Manager string `json:"manager"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ApplyConflictError is returned when a server-side apply is rejected because
+// of field ownership conflicts. Admins can retry with force to take ownership.
+type ApplyConflictError struct {
+	Kind      string          `json:"kind"`
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace,omitempty"`
+	Conflicts []FieldConflict `json:"conflicts"`
+}
+
+func NewApplyConflictError(kind, namespace, name string, conflicts []FieldConflict) *ApplyConflictError {
+	return &ApplyConflictError{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Conflicts: conflicts,
+	}
+}
+
+func (e *ApplyConflictError) Code() int {
+	return http.StatusConflict
+}
+
+func (e *ApplyConflictError) Message() string {
+	return fmt.Sprintf("Apply %s %s conflicts with fields managed by other controllers", e.Kind, e.Name)
+}
+
+func (e *ApplyConflictError) Details() any {
+	return e
+}
+
 func (e *appError) Code() int {
 	return e.code
 }
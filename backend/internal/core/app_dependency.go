@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AppMetadataDependency is an app the app depends on, its service address is
+// injected into the app as env vars.
+type AppMetadataDependency struct {
+	AppSlug   string  `json:"appSlug"`
+	Namespace string  `json:"namespace"`
+	EnvPrefix string  `json:"envPrefix"`
+	Ports     []int32 `json:"ports,omitempty"`
+}
+
+// DependencyEnvPrefix returns the default prefix of the env vars injected for
+// a dependency, e.g. "REDIS_CACHE" for "redis-cache".
+func DependencyEnvPrefix(appSlug string) string {
+	return strings.ToUpper(strings.ReplaceAll(appSlug, "-", "_"))
+}
+
+// ServiceHost returns the DNS name of the service rendered for an app, see
+// serviceManifest. The short form resolves through the search domains of
+// pods, so it doesn't depend on the cluster domain.
+func ServiceHost(appSlug, namespace string) string {
+	return fmt.Sprintf("%s.%s.svc", appSlug, namespace)
+}
+
+// EnvVars returns the env vars injected for the dependency: <PREFIX>_HOST,
+// <PREFIX>_PORT with the lowest port and <PREFIX>_PORTS with all of them.
+// Ports are only set when the dependency has ports or gateways, which its
+// service is rendered for.
+func (d AppMetadataDependency) EnvVars() []AppMetadataEnvVar {
+	prefix := d.EnvPrefix
+	if prefix == "" {
+		prefix = DependencyEnvPrefix(d.AppSlug)
+	}
+
+	result := []AppMetadataEnvVar{
+		{Key: prefix + "_HOST", Value: ServiceHost(d.AppSlug, d.Namespace)},
+	}
+	if len(d.Ports) > 0 {
+		ports := make([]string, 0, len(d.Ports))
+		for _, port := range d.Ports {
+			ports = append(ports, strconv.Itoa(int(port)))
+		}
+		result = append(result,
+			AppMetadataEnvVar{Key: prefix + "_PORT", Value: ports[0]},
+			AppMetadataEnvVar{Key: prefix + "_PORTS", Value: strings.Join(ports, ",")},
+		)
+	}
+	return result
+}
+
+// injectDependencyEnvVars appends the env vars of the dependencies, env vars
+// set on the app take precedence.
+func (a *AppMetadata) injectDependencyEnvVars() {
+	defined := make(map[string]bool, len(a.EnvVars))
+	for _, envVar := range a.EnvVars {
+		defined[envVar.Key] = true
+	}
+	for _, dependency := range a.Dependencies {
+		for _, envVar := range dependency.EnvVars() {
+			if defined[envVar.Key] {
+				continue
+			}
+			defined[envVar.Key] = true
+			a.EnvVars = append(a.EnvVars, envVar)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package core
+
+import (
+	"github.com/ketches/ketches/pkg/utils"
+	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// AppMetadataGatewayRule is a rule of the HTTPRoute of an http or https
+// gateway. It is stored JSON encoded on the gateway, apps are referenced by ID
+// there and resolved to their slugs when the metadata is built.
+type AppMetadataGatewayRule struct {
+	Matches         []AppMetadataGatewayMatch         `json:"matches,omitempty"`
+	RequestHeaders  *AppMetadataGatewayHeaderModifier `json:"requestHeaders,omitempty"`
+	ResponseHeaders *AppMetadataGatewayHeaderModifier `json:"responseHeaders,omitempty"`
+	Rewrite         *AppMetadataGatewayRewrite        `json:"rewrite,omitempty"`
+	Redirect        *AppMetadataGatewayRedirect       `json:"redirect,omitempty"`
+	Mirror          *AppMetadataGatewayMirror         `json:"mirror,omitempty"`
+	Backends        []AppMetadataGatewayBackend       `json:"backends,omitempty"`
+}
+
+type AppMetadataGatewayMatch struct {
+	PathType    string                         `json:"pathType,omitempty"` // Exact, PathPrefix or RegularExpression
+	Path        string                         `json:"path,omitempty"`
+	Method      string                         `json:"method,omitempty"`
+	Headers     []AppMetadataGatewayValueMatch `json:"headers,omitempty"`
+	QueryParams []AppMetadataGatewayValueMatch `json:"queryParams,omitempty"`
+}
+
+type AppMetadataGatewayValueMatch struct {
+	Type  string `json:"type,omitempty"` // Exact or RegularExpression
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type AppMetadataGatewayHeaderModifier struct {
+	Set    []AppMetadataGatewayHeader `json:"set,omitempty"`
+	Add    []AppMetadataGatewayHeader `json:"add,omitempty"`
+	Remove []string                   `json:"remove,omitempty"`
+}
+
+type AppMetadataGatewayHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type AppMetadataGatewayRewrite struct {
+	Hostname string `json:"hostname,omitempty"`
+	PathType string `json:"pathType,omitempty"` // ReplaceFullPath or ReplacePrefixMatch
+	Path     string `json:"path,omitempty"`
+}
+
+type AppMetadataGatewayRedirect struct {
+	Scheme     string `json:"scheme,omitempty"`
+	Hostname   string `json:"hostname,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	PathType   string `json:"pathType,omitempty"` // ReplaceFullPath or ReplacePrefixMatch
+	Path       string `json:"path,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+// AppMetadataGatewayMirror mirrors requests to an app of the same env.
+type AppMetadataGatewayMirror struct {
+	AppID   string `json:"appID,omitempty"` // Empty for the app itself
+	AppSlug string `json:"appSlug,omitempty"`
+	Port    int32  `json:"port,omitempty"` // Defaults to the gateway port for the app itself
+	Percent *int32 `json:"percent,omitempty"`
+}
+
+// AppMetadataGatewayBackend is an app of the same env requests are split to
+// by weight.
+type AppMetadataGatewayBackend struct {
+	AppID   string `json:"appID,omitempty"` // Empty for the app itself
+	AppSlug string `json:"appSlug,omitempty"`
+	Port    int32  `json:"port,omitempty"` // Defaults to the gateway port for the app itself
+	Weight  *int32 `json:"weight,omitempty"`
+}
+
+// httpRouteRules maps the rules of the gateway onto HTTPRoute rules, a gateway
+// without rules routes its path prefix to the app.
+func (a *AppMetadata) httpRouteRules(gateway AppMetadataGateway) []gatewayapisv1.HTTPRouteRule {
+	defaultMatches := []gatewayapisv1.HTTPRouteMatch{
+		{
+			Path: &gatewayapisv1.HTTPPathMatch{
+				Type:  utils.Ptr(gatewayapisv1.PathMatchPathPrefix),
+				Value: utils.Ptr(gateway.Path),
+			},
+		},
+	}
+	defaultBackendRefs := a.httpBackendRefs(AppMetadataGatewayBackend{}, gateway.Port)
+
+	if len(gateway.Rules) == 0 {
+		return []gatewayapisv1.HTTPRouteRule{
+			{
+				Matches:     defaultMatches,
+				BackendRefs: defaultBackendRefs,
+			},
+		}
+	}
+
+	result := make([]gatewayapisv1.HTTPRouteRule, 0, len(gateway.Rules))
+	for _, rule := range gateway.Rules {
+		routeRule := gatewayapisv1.HTTPRouteRule{
+			Matches: defaultMatches,
+		}
+		if len(rule.Matches) > 0 {
+			routeRule.Matches = make([]gatewayapisv1.HTTPRouteMatch, 0, len(rule.Matches))
+			for _, match := range rule.Matches {
+				routeRule.Matches = append(routeRule.Matches, httpRouteMatch(match))
+			}
+		}
+
+		if rule.RequestHeaders != nil {
+			routeRule.Filters = append(routeRule.Filters, gatewayapisv1.HTTPRouteFilter{
+				Type:                  gatewayapisv1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: httpHeaderFilter(rule.RequestHeaders),
+			})
+		}
+		if rule.ResponseHeaders != nil {
+			routeRule.Filters = append(routeRule.Filters, gatewayapisv1.HTTPRouteFilter{
+				Type:                   gatewayapisv1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: httpHeaderFilter(rule.ResponseHeaders),
+			})
+		}
+		if rule.Rewrite != nil {
+			rewrite := &gatewayapisv1.HTTPURLRewriteFilter{
+				Path: httpPathModifier(rule.Rewrite.PathType, rule.Rewrite.Path),
+			}
+			if rule.Rewrite.Hostname != "" {
+				rewrite.Hostname = utils.Ptr(gatewayapisv1.PreciseHostname(rule.Rewrite.Hostname))
+			}
+			routeRule.Filters = append(routeRule.Filters, gatewayapisv1.HTTPRouteFilter{
+				Type:       gatewayapisv1.HTTPRouteFilterURLRewrite,
+				URLRewrite: rewrite,
+			})
+		}
+		if rule.Mirror != nil {
+			port := rule.Mirror.Port
+			if port == 0 {
+				port = gateway.Port
+			}
+			routeRule.Filters = append(routeRule.Filters, gatewayapisv1.HTTPRouteFilter{
+				Type: gatewayapisv1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &gatewayapisv1.HTTPRequestMirrorFilter{
+					BackendRef: a.backendObjectReference(rule.Mirror.AppSlug, port),
+					Percent:    rule.Mirror.Percent,
+				},
+			})
+		}
+
+		if rule.Redirect != nil {
+			// Redirected requests don't reach any backend
+			redirect := &gatewayapisv1.HTTPRequestRedirectFilter{
+				Path: httpPathModifier(rule.Redirect.PathType, rule.Redirect.Path),
+			}
+			if rule.Redirect.Scheme != "" {
+				redirect.Scheme = utils.Ptr(rule.Redirect.Scheme)
+			}
+			if rule.Redirect.Hostname != "" {
+				redirect.Hostname = utils.Ptr(gatewayapisv1.PreciseHostname(rule.Redirect.Hostname))
+			}
+			if rule.Redirect.Port != 0 {
+				redirect.Port = utils.Ptr(gatewayapisv1.PortNumber(rule.Redirect.Port))
+			}
+			if rule.Redirect.StatusCode != 0 {
+				redirect.StatusCode = utils.Ptr(rule.Redirect.StatusCode)
+			}
+			routeRule.Filters = append(routeRule.Filters, gatewayapisv1.HTTPRouteFilter{
+				Type:            gatewayapisv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: redirect,
+			})
+			result = append(result, routeRule)
+			continue
+		}
+
+		for _, backend := range rule.Backends {
+			routeRule.BackendRefs = append(routeRule.BackendRefs, a.httpBackendRefs(backend, gateway.Port)...)
+		}
+		if len(routeRule.BackendRefs) == 0 {
+			routeRule.BackendRefs = defaultBackendRefs
+		}
+		result = append(result, routeRule)
+	}
+	return result
+}
+
+func (a *AppMetadata) backendObjectReference(appSlug string, port int32) gatewayapisv1.BackendObjectReference {
+	if appSlug == "" {
+		appSlug = a.AppSlug
+	}
+	return gatewayapisv1.BackendObjectReference{
+		Name: gatewayapisv1.ObjectName(appSlug),
+		Port: utils.Ptr(gatewayapisv1.PortNumber(port)),
+	}
+}
+
+// httpBackendRefs returns the backend refs of a backend, requests to the app
+// itself are split between the editions of its release by weight.
+func (a *AppMetadata) httpBackendRefs(backend AppMetadataGatewayBackend, gatewayPort int32) []gatewayapisv1.HTTPBackendRef {
+	port := backend.Port
+	if port == 0 {
+		port = gatewayPort
+	}
+	if backend.AppSlug != "" || !a.Release.splitsTraffic() {
+		return []gatewayapisv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayapisv1.BackendRef{
+					BackendObjectReference: a.backendObjectReference(backend.AppSlug, port),
+					Weight:                 backend.Weight,
+				},
+			},
+		}
+	}
+
+	weight := int32(100)
+	if backend.Weight != nil {
+		weight = *backend.Weight
+	}
+	canaryWeight := weight * a.Release.Weight / 100
+	return []gatewayapisv1.HTTPBackendRef{
+		{
+			BackendRef: gatewayapisv1.BackendRef{
+				BackendObjectReference: a.backendObjectReference(stableServiceName(a.AppSlug), port),
+				Weight:                 utils.Ptr(weight - canaryWeight),
+			},
+		},
+		{
+			BackendRef: gatewayapisv1.BackendRef{
+				BackendObjectReference: a.backendObjectReference(CanaryWorkloadName(a.AppSlug), port),
+				Weight:                 utils.Ptr(canaryWeight),
+			},
+		},
+	}
+}
+
+func httpRouteMatch(match AppMetadataGatewayMatch) gatewayapisv1.HTTPRouteMatch {
+	var result gatewayapisv1.HTTPRouteMatch
+	if match.Path != "" {
+		pathType := gatewayapisv1.PathMatchPathPrefix
+		if match.PathType != "" {
+			pathType = gatewayapisv1.PathMatchType(match.PathType)
+		}
+		result.Path = &gatewayapisv1.HTTPPathMatch{
+			Type:  utils.Ptr(pathType),
+			Value: utils.Ptr(match.Path),
+		}
+	}
+	if match.Method != "" {
+		result.Method = utils.Ptr(gatewayapisv1.HTTPMethod(match.Method))
+	}
+	for _, header := range match.Headers {
+		headerMatch := gatewayapisv1.HTTPHeaderMatch{
+			Name:  gatewayapisv1.HTTPHeaderName(header.Name),
+			Value: header.Value,
+		}
+		if header.Type != "" {
+			headerMatch.Type = utils.Ptr(gatewayapisv1.HeaderMatchType(header.Type))
+		}
+		result.Headers = append(result.Headers, headerMatch)
+	}
+	for _, param := range match.QueryParams {
+		paramMatch := gatewayapisv1.HTTPQueryParamMatch{
+			Name:  gatewayapisv1.HTTPHeaderName(param.Name),
+			Value: param.Value,
+		}
+		if param.Type != "" {
+			paramMatch.Type = utils.Ptr(gatewayapisv1.QueryParamMatchType(param.Type))
+		}
+		result.QueryParams = append(result.QueryParams, paramMatch)
+	}
+	return result
+}
+
+func httpHeaderFilter(modifier *AppMetadataGatewayHeaderModifier) *gatewayapisv1.HTTPHeaderFilter {
+	result := &gatewayapisv1.HTTPHeaderFilter{
+		Remove: modifier.Remove,
+	}
+	for _, header := range modifier.Set {
+		result.Set = append(result.Set, gatewayapisv1.HTTPHeader{
+			Name:  gatewayapisv1.HTTPHeaderName(header.Name),
+			Value: header.Value,
+		})
+	}
+	for _, header := range modifier.Add {
+		result.Add = append(result.Add, gatewayapisv1.HTTPHeader{
+			Name:  gatewayapisv1.HTTPHeaderName(header.Name),
+			Value: header.Value,
+		})
+	}
+	return result
+}
+
+func httpPathModifier(pathType, path string) *gatewayapisv1.HTTPPathModifier {
+	switch gatewayapisv1.HTTPPathModifierType(pathType) {
+	case gatewayapisv1.FullPathHTTPPathModifier:
+		return &gatewayapisv1.HTTPPathModifier{
+			Type:            gatewayapisv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: utils.Ptr(path),
+		}
+	case gatewayapisv1.PrefixMatchHTTPPathModifier:
+		return &gatewayapisv1.HTTPPathModifier{
+			Type:               gatewayapisv1.PrefixMatchHTTPPathModifier,
+			ReplacePrefixMatch: utils.Ptr(path),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// AppMetadataCert is the TLS certificate an https gateway is served with.
+type AppMetadataCert struct {
+	Slug    string `json:"slug"`
+	TLSCert string `json:"tlsCert"`
+	TLSKey  string `json:"tlsKey"`
+}
+
+// tlsSecretName returns the name of the secret a cert is rendered into for
+// the app.
+func tlsSecretName(appSlug, certSlug string) string {
+	return fmt.Sprintf("%s-tls-%s", appSlug, certSlug)
+}
+
+// ingressManifests renders the http and https gateways of the app as a single
+// Ingress for clusters routing through an ingress controller, along with a
+// TLS secret per cert of its https gateways. Ingresses only express path
+// matches routed to a single backend, other gateway rules are rejected when
+// saved, and TCP and UDP gateways are not rendered at all.
+func (a *AppMetadata) ingressManifests() []client.Object {
+	var (
+		result  []client.Object
+		rules   []networkingv1.IngressRule
+		tls     []networkingv1.IngressTLS
+		secrets = make(map[string]bool)
+	)
+	for _, gateway := range a.Gateways {
+		if !gateway.Exposed || gateway.Domain == "" {
+			continue
+		}
+		if gateway.Protocol != app.AppGatewayProtocolHTTP && gateway.Protocol != app.AppGatewayProtocolHTTPS {
+			continue
+		}
+		if gateway.Path == "" {
+			gateway.Path = "/" // Default path for HTTP/HTTPS gateways
+		}
+
+		rules = append(rules, networkingv1.IngressRule{
+			Host: gateway.Domain,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: a.ingressPaths(gateway),
+				},
+			},
+		})
+
+		if gateway.Protocol != app.AppGatewayProtocolHTTPS || gateway.Cert == nil {
+			continue
+		}
+		secretName := tlsSecretName(a.AppSlug, gateway.Cert.Slug)
+		tls = append(tls, networkingv1.IngressTLS{
+			Hosts:      []string{gateway.Domain},
+			SecretName: secretName,
+		})
+		if !secrets[secretName] {
+			secrets[secretName] = true
+			result = append(result, a.tlsSecretManifest(secretName, gateway.Cert))
+		}
+	}
+	if len(rules) == 0 {
+		return result
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.AppSlug,
+			Namespace: a.ClusterNamespace,
+			Labels:    a.standardLabels(),
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: rules,
+			TLS:   tls,
+		},
+	}
+	if a.IngressClassName != "" {
+		ingress.Spec.IngressClassName = utils.Ptr(a.IngressClassName)
+	}
+	return append(result, ingress)
+}
+
+// ingressPaths maps the rules of the gateway onto Ingress paths, a gateway
+// without rules routes its path prefix to the app.
+func (a *AppMetadata) ingressPaths(gateway AppMetadataGateway) []networkingv1.HTTPIngressPath {
+	if len(gateway.Rules) == 0 {
+		return []networkingv1.HTTPIngressPath{
+			ingressPath("", gateway.Path, a.ingressBackend(AppMetadataGatewayBackend{}, gateway.Port)),
+		}
+	}
+
+	var result []networkingv1.HTTPIngressPath
+	for _, rule := range gateway.Rules {
+		if rule.Redirect != nil {
+			continue
+		}
+		var backend AppMetadataGatewayBackend
+		if len(rule.Backends) > 0 {
+			backend = rule.Backends[0]
+		}
+		ingressBackend := a.ingressBackend(backend, gateway.Port)
+
+		matched := false
+		for _, match := range rule.Matches {
+			if match.Path == "" {
+				continue
+			}
+			matched = true
+			result = append(result, ingressPath(match.PathType, match.Path, ingressBackend))
+		}
+		if !matched {
+			result = append(result, ingressPath("", gateway.Path, ingressBackend))
+		}
+	}
+	return result
+}
+
+func ingressPath(pathType, path string, backend networkingv1.IngressBackend) networkingv1.HTTPIngressPath {
+	result := networkingv1.HTTPIngressPath{
+		Path:     path,
+		PathType: utils.Ptr(networkingv1.PathTypePrefix),
+		Backend:  backend,
+	}
+	switch pathType {
+	case string(gatewayapisv1.PathMatchExact):
+		result.PathType = utils.Ptr(networkingv1.PathTypeExact)
+	case string(gatewayapisv1.PathMatchRegularExpression):
+		// Whether regular expressions are supported depends on the ingress controller
+		result.PathType = utils.Ptr(networkingv1.PathTypeImplementationSpecific)
+	}
+	return result
+}
+
+// ingressBackend returns the service backend of a backend. Ingresses can't
+// split traffic, during a release requests to the app itself go through its
+// service, which selects the editions serving traffic.
+func (a *AppMetadata) ingressBackend(backend AppMetadataGatewayBackend, gatewayPort int32) networkingv1.IngressBackend {
+	name := backend.AppSlug
+	if name == "" {
+		name = a.AppSlug
+	}
+	port := backend.Port
+	if port == 0 {
+		port = gatewayPort
+	}
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: name,
+			Port: networkingv1.ServiceBackendPort{
+				Number: port,
+			},
+		},
+	}
+}
+
+func (a *AppMetadata) tlsSecretManifest(name string, cert *AppMetadataCert) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: a.ClusterNamespace,
+			Labels:    a.standardLabels(),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(cert.TLSCert),
+			corev1.TLSPrivateKeyKey: []byte(cert.TLSKey),
+		},
+	}
+}
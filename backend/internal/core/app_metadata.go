@@ -3,14 +3,16 @@ package core
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/tracing"
 	"github.com/ketches/ketches/pkg/utils"
 	"github.com/spf13/cast"
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -22,33 +24,42 @@ import (
 )
 
 type AppMetadata struct {
-	AppID            string                     `json:"appId"`
-	AppSlug          string                     `json:"appSlug"`
-	DisplayName      string                     `json:"displayName"`
-	Description      string                     `json:"description"`
-	AppType          string                     `json:"appType"`
-	RequestCPU       int32                      `json:"requestCPU"`
-	RequestMemory    int32                      `json:"requestMemory"`
-	LimitCPU         int32                      `json:"limitCPU"`
-	LimitMemory      int32                      `json:"limitMemory"`
-	Replicas         int32                      `json:"replicas"`
-	ContainerImage   string                     `json:"containerImage"`
-	RegistryUsername string                     `json:"registryUsername"`
-	RegistryPassword string                     `json:"registryPassword"`
-	ContainerCommand string                     `json:"containerCommand"`
-	EnvVars          []AppMetadataEnvVar        `json:"envVars,omitempty"`
-	Volumes          []AppMetadataVolume        `json:"volumes,omitempty"`
-	ConfigFiles      []AppMetadataConfigFile    `json:"configFiles,omitempty"`
-	Gateways         []AppMetadataGateway       `json:"gateways,omitempty"`
-	Probes           []AppMetadataProbe         `json:"probes,omitempty"`
-	SchedulingRule   *AppMetadataSchedulingRule `json:"schedulingRule,omitempty"`
-	Edition          string                     `json:"edition,omitempty"`
-	DebugMode        bool                       `json:"debugMode,omitempty"`
-	EnvID            string                     `json:"envId,omitempty"`
-	EnvSlug          string                     `json:"envSlug,omitempty"`
-	ProjectID        string                     `json:"projectId,omitempty"`
-	ProjectSlug      string                     `json:"projectSlug,omitempty"`
-	ClusterNamespace string                     `json:"clusterNamespace"`
+	AppID                 string                      `json:"appId"`
+	AppSlug               string                      `json:"appSlug"`
+	DisplayName           string                      `json:"displayName"`
+	Description           string                      `json:"description"`
+	AppType               string                      `json:"appType"`
+	RequestCPU            int32                       `json:"requestCPU"`
+	RequestMemory         int32                       `json:"requestMemory"`
+	LimitCPU              int32                       `json:"limitCPU"`
+	LimitMemory           int32                       `json:"limitMemory"`
+	Replicas              int32                       `json:"replicas"`
+	ContainerImage        string                      `json:"containerImage"`
+	RegistryUsername      string                      `json:"registryUsername"`
+	RegistryPassword      string                      `json:"registryPassword"`
+	RegistryCredentialIDs []string                    `json:"registryCredentialIds,omitempty"` // Project registry credentials matching the app images
+	ContainerCommand      string                      `json:"containerCommand"`
+	EnvVars               []AppMetadataEnvVar         `json:"envVars,omitempty"`
+	Volumes               []AppMetadataVolume         `json:"volumes,omitempty"`
+	ConfigFiles           []AppMetadataConfigFile     `json:"configFiles,omitempty"`
+	Ports                 []AppMetadataPort           `json:"ports,omitempty"`
+	Gateways              []AppMetadataGateway        `json:"gateways,omitempty"`
+	Probes                []AppMetadataProbe          `json:"probes,omitempty"`
+	Containers            []AppMetadataContainer      `json:"containers,omitempty"`
+	SchedulingRule        *AppMetadataSchedulingRule  `json:"schedulingRule,omitempty"`
+	RolloutStrategy       *AppMetadataRolloutStrategy `json:"rolloutStrategy,omitempty"`
+	NetworkPolicy         *AppMetadataNetworkPolicy   `json:"networkPolicy,omitempty"`
+	Dependencies          []AppMetadataDependency     `json:"dependencies,omitempty"`
+	Release               *AppMetadataRelease         `json:"release,omitempty"`
+	Edition               string                      `json:"edition,omitempty"`
+	DebugMode             bool                        `json:"debugMode,omitempty"`
+	EnvID                 string                      `json:"envId,omitempty"`
+	EnvSlug               string                      `json:"envSlug,omitempty"`
+	ProjectID             string                      `json:"projectId,omitempty"`
+	ProjectSlug           string                      `json:"projectSlug,omitempty"`
+	ClusterNamespace      string                      `json:"clusterNamespace"`
+	RoutingBackend        string                      `json:"routingBackend,omitempty"`   // Routing backend of the cluster, see app.ClusterRoutingBackend
+	IngressClassName      string                      `json:"ingressClassName,omitempty"` // Ingress class of the ingress routing backend
 }
 
 type AppMetadataEnvVar struct {
@@ -57,14 +68,21 @@ type AppMetadataEnvVar struct {
 }
 
 type AppMetadataVolume struct {
-	Slug         string   `json:"slug"`
-	MountPath    string   `json:"mountPath"`
-	SubPath      string   `json:"subPath,omitempty"`
-	StorageClass string   `json:"storageClass,omitempty"`
-	AccessModes  []string `json:"accessModes,omitempty"`
-	VolumeType   string   `json:"volumeType"`
-	Capacity     int      `json:"capacity"`
-	VolumeMode   string   `json:"volumeMode"`
+	Slug           string   `json:"slug"`
+	MountPath      string   `json:"mountPath"`
+	SubPath        string   `json:"subPath,omitempty"`
+	StorageClass   string   `json:"storageClass,omitempty"`
+	AccessModes    []string `json:"accessModes,omitempty"`
+	VolumeType     string   `json:"volumeType"`
+	Capacity       int      `json:"capacity"`
+	VolumeMode     string   `json:"volumeMode"`
+	ReadOnly       bool     `json:"readOnly,omitempty"`
+	EmptyDirMedium string   `json:"emptyDirMedium,omitempty"`
+	HostPath       string   `json:"hostPath,omitempty"`
+	HostPathType   string   `json:"hostPathType,omitempty"`
+	NFSServer      string   `json:"nfsServer,omitempty"`
+	NFSPath        string   `json:"nfsPath,omitempty"`
+	ClaimName      string   `json:"claimName,omitempty"`
 }
 
 type AppMetadataConfigFile struct {
@@ -74,6 +92,14 @@ type AppMetadataConfigFile struct {
 	FileMode  string `json:"fileMode"`
 }
 
+type AppMetadataPort struct {
+	Name          string `json:"name"`
+	ContainerPort int32  `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+	ServicePort   int32  `json:"servicePort"`
+	AppProtocol   string `json:"appProtocol,omitempty"`
+}
+
 type AppMetadataGateway struct {
 	Port        int32  `json:"port"`
 	Protocol    string `json:"protocol"`
@@ -82,6 +108,10 @@ type AppMetadataGateway struct {
 	Path        string `json:"path,omitempty"`
 	GatewayIP   string `json:"gatewayIP,omitempty"`
 	GatewayPort int32  `json:"gatewayPort,omitempty"`
+	PortName    string `json:"portName,omitempty"`
+
+	Cert  *AppMetadataCert         `json:"cert,omitempty"`  // TLS certificate, https gateways only
+	Rules []AppMetadataGatewayRule `json:"rules,omitempty"` // HTTP route rules, http and https gateways only
 }
 
 type AppMetadataProbe struct {
@@ -98,6 +128,26 @@ type AppMetadataProbe struct {
 	FailureThreshold    int32  `json:"failureThreshold"`
 }
 
+type AppMetadataContainer struct {
+	Slug             string                   `json:"slug"`
+	ContainerType    string                   `json:"containerType"`
+	ContainerImage   string                   `json:"containerImage"`
+	ContainerCommand string                   `json:"containerCommand,omitempty"`
+	RequestCPU       int32                    `json:"requestCPU,omitempty"`
+	RequestMemory    int32                    `json:"requestMemory,omitempty"`
+	LimitCPU         int32                    `json:"limitCPU,omitempty"`
+	LimitMemory      int32                    `json:"limitMemory,omitempty"`
+	EnvVars          []AppMetadataEnvVar      `json:"envVars,omitempty"`
+	VolumeMounts     []AppMetadataVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+type AppMetadataVolumeMount struct {
+	VolumeSlug string `json:"volumeSlug"` // Slug of an app volume or config file
+	MountPath  string `json:"mountPath"`
+	SubPath    string `json:"subPath,omitempty"`
+	ReadOnly   bool   `json:"readOnly,omitempty"`
+}
+
 type Toleration struct {
 	Key      string `json:"key,omitempty"`
 	Value    string `json:"value,omitempty"`
@@ -116,39 +166,78 @@ type AppMetadataSchedulingRule struct {
 type AppDeployOption struct {
 	ZeroReplicas bool // If true, set replicas to 0 for initial deployment
 	DebugMode    bool
+	PruneVolumes bool // If true, also delete PVCs the app no longer renders
+	ForceApply   bool // If true, take over fields owned by other field managers
+	AbortRelease bool // If true, serve the stable edition only and prune the canary workload of the release
 }
 
-func (a *AppMetadata) Deploy(ctx context.Context, cli client.Client, options *AppDeployOption) app.Error {
-	if options != nil {
-		if options.ZeroReplicas {
-			a.Replicas = 0 // Set replicas to 0 for initial deployment
-		}
+func (a *AppMetadata) Deploy(ctx context.Context, cli client.Client, options *AppDeployOption) (err app.Error) {
+	ctx, span := tracing.Start(ctx, "AppMetadata.Deploy",
+		attribute.String("app.id", a.AppID),
+		attribute.String("app.slug", a.AppSlug),
+		attribute.String("k8s.namespace", a.ClusterNamespace),
+	)
+	defer func() {
+		tracing.EndApp(span, err)
+	}()
 
-		if options.DebugMode {
-			a.DebugMode = true
-			a.ContainerCommand = "sleep infinity" // Set a debug command to keep the container running
-		}
-	}
+	a.applyDeployOption(options)
 
-	manifests, err := a.GetApplyManifests()
+	manifests, err := a.GetApplyManifests(ctx)
 	if err != nil {
 		return err
 	}
+	span.SetAttributes(attribute.Int("app.manifests", len(manifests)))
+
+	var applyOpts []client.PatchOption
+	if options != nil && options.ForceApply {
+		applyOpts = append(applyOpts, client.ForceOwnership)
+	}
+	applyCtx, applySpan := tracing.Start(ctx, "AppMetadata.ApplyManifests")
 	for _, resource := range manifests {
-		if err := ApplyResource(ctx, cli, resource); err != nil {
+		if err := ApplyResource(applyCtx, cli, resource, applyOpts...); err != nil {
+			tracing.EndApp(applySpan, err)
 			return err
 		}
 	}
+	applySpan.End()
+
+	pruneVolumes := options != nil && options.PruneVolumes
+	pruneCtx, pruneSpan := tracing.Start(ctx, "AppMetadata.PruneResources")
+	err = PruneResources(pruneCtx, cli, a.ClusterNamespace, a.AppID, a.AppSlug, append(manifests, a.retainedObjects()...), pruneVolumes)
+	tracing.EndApp(pruneSpan, err)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
+func (a *AppMetadata) applyDeployOption(options *AppDeployOption) {
+	if options == nil {
+		return
+	}
+
+	if options.ZeroReplicas {
+		a.Replicas = 0 // Set replicas to 0 for initial deployment
+	}
+
+	if options.DebugMode {
+		a.DebugMode = true
+		a.ContainerCommand = "sleep infinity" // Set a debug command to keep the container running
+	}
+
+	if options.AbortRelease && a.Release != nil {
+		a.Release.Aborted = true
+	}
+}
+
 func (a *AppMetadata) Undeploy(ctx context.Context, cli client.Client) app.Error {
-	manifests, err := a.GetApplyManifests()
+	manifests, err := a.GetApplyManifests(ctx)
 	if err != nil {
 		return err
 	}
-	for _, resource := range manifests {
+	for _, resource := range append(manifests, a.retainedObjects()...) {
 		if err := DeleteResource(ctx, cli, resource); err != nil {
 			return err
 		}
@@ -157,12 +246,12 @@ func (a *AppMetadata) Undeploy(ctx context.Context, cli client.Client) app.Error
 	return nil
 }
 
-func (a *AppMetadata) GetApplyManifests() ([]client.Object, app.Error) {
+func (a *AppMetadata) GetApplyManifests(ctx context.Context) ([]client.Object, app.Error) {
 	switch a.AppType {
 	case app.AppTypeDeployment:
-		return a.deploymentManifests()
+		return a.deploymentManifests(ctx)
 	case app.AppTypeStatefulSet:
-		return a.statefulSetManifests()
+		return a.statefulSetManifests(ctx)
 	default:
 		return nil, app.NewError(http.StatusBadRequest, "Not supported app type: "+a.AppType)
 	}
@@ -178,6 +267,9 @@ func (a *AppMetadata) standardLabels() map[string]string {
 	if a.DebugMode {
 		result["ketches.cn/debugging"] = "true"
 	}
+	if a.NetworkPolicy != nil {
+		result[networkPolicyLabel] = "true"
+	}
 	return result
 }
 
@@ -194,7 +286,7 @@ func (a *AppMetadata) standardAnnotations() map[string]string {
 	}
 }
 
-func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
+func (a *AppMetadata) deploymentManifests(ctx context.Context) ([]client.Object, app.Error) {
 	var result []client.Object
 
 	envs := make([]corev1.EnvVar, 0, len(a.EnvVars))
@@ -209,33 +301,22 @@ func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
 	annotations := a.standardAnnotations()
 	selectorLabels := a.standardSelectorLabels()
 
-	for _, pvc := range a.persistentVolumeClaimManifests() {
+	for _, pvc := range a.persistentVolumeClaimManifests(false) {
 		result = append(result, &pvc)
 	}
 
+	registrySecrets, err := a.registrySecretManifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, registrySecrets...)
+
 	for _, configMap := range a.configMapManifests() {
 		result = append(result, &configMap)
 	}
 
-	volumeMounts := make([]corev1.VolumeMount, 0, len(a.Volumes)+len(a.ConfigFiles))
-	volumes := make([]corev1.Volume, 0, len(a.Volumes)+len(a.ConfigFiles))
-
-	// Add volume mounts for persistent volumes
-	for _, volume := range a.Volumes {
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      volume.Slug,
-			MountPath: volume.MountPath,
-			SubPath:   volume.SubPath,
-		})
-		volumes = append(volumes, corev1.Volume{
-			Name: volume.Slug,
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: appVolumeName(a.AppSlug, volume.Slug),
-				},
-			},
-		})
-	}
+	// Add volume mounts for app volumes
+	volumeMounts, volumes := a.appVolumes(false)
 
 	// Add volume mounts for config files
 	for _, configFile := range a.ConfigFiles {
@@ -257,10 +338,10 @@ func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
 		})
 	}
 
-	if len(a.Gateways) > 0 {
-		result = append(result, a.serviceManifest()...)
-		result = append(result, a.gatewayManifests()...)
-	}
+	result = append(result, a.serviceManifest()...)
+	result = append(result, a.gatewayManifests()...)
+
+	result = append(result, a.networkPolicyManifests()...)
 
 	var (
 		command []string
@@ -327,7 +408,7 @@ func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
 			for _, selector := range a.SchedulingRule.NodeSelector {
 				parts := strings.SplitN(selector, "=", 2)
 				if len(parts) != 2 {
-					log.Printf("invalid node selector format: %s", selector)
+					logging.Warnf(ctx, "invalid node selector format: %s", selector)
 					continue
 				}
 				if schedulingRuleNodeSelector == nil {
@@ -365,6 +446,8 @@ func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
 		}
 	}
 
+	sidecars, initContainers := a.extraContainers(false)
+
 	result = append(result, &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        a.AppSlug,
@@ -373,23 +456,31 @@ func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
 			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &a.Replicas,
+			Replicas:                &a.Replicas,
+			Strategy:                a.deploymentStrategy(),
+			MinReadySeconds:         a.minReadySeconds(),
+			ProgressDeadlineSeconds: a.progressDeadlineSeconds(),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
+					Annotations: map[string]string{
+						defaultContainerAnnotation: a.AppSlug,
+					},
 				},
 				Spec: corev1.PodSpec{
-					NodeName:     schedulingRuleNodeName,
-					NodeSelector: schedulingRuleNodeSelector,
-					Containers: []corev1.Container{
+					NodeName:       schedulingRuleNodeName,
+					NodeSelector:   schedulingRuleNodeSelector,
+					InitContainers: initContainers,
+					Containers: append([]corev1.Container{
 						{
 							Name:            a.AppSlug,
 							Image:           a.ContainerImage,
 							ImagePullPolicy: corev1.PullAlways,
 							Command:         command,
+							Ports:           a.containerPorts(),
 							Args:            args,
 							Env:             envs,
 							Resources: corev1.ResourceRequirements{
@@ -407,21 +498,22 @@ func (a *AppMetadata) deploymentManifests() ([]client.Object, app.Error) {
 							StartupProbe:   startupProbe,
 							VolumeMounts:   volumeMounts,
 						},
-					},
+					}, sidecars...),
 					Volumes: volumes,
 					Affinity: &corev1.Affinity{
 						NodeAffinity: nodeAffinity,
 					},
-					Tolerations: tolerations,
+					Tolerations:      tolerations,
+					ImagePullSecrets: a.imagePullSecrets(),
 				},
 			},
 		},
 	})
 
-	return result, nil
+	return a.releaseManifests(result), nil
 }
 
-func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
+func (a *AppMetadata) statefulSetManifests(ctx context.Context) ([]client.Object, app.Error) {
 	var result []client.Object
 
 	envs := make([]corev1.EnvVar, 0, len(a.EnvVars))
@@ -437,33 +529,29 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 	annotations := a.standardAnnotations()
 
 	var (
-		volumeClaims = a.persistentVolumeClaimManifests()
-		volumeMounts = make([]corev1.VolumeMount, 0, len(a.Volumes)+len(a.ConfigFiles))
-		volumes      = make([]corev1.Volume, 0, len(a.Volumes)+len(a.ConfigFiles))
+		volumeMounts []corev1.VolumeMount
+		volumes      []corev1.Volume
 	)
 
+	// Shared volumes are claimed once in the namespace, per-replica storage
+	// goes to the volume claim templates
+	for _, pvc := range a.persistentVolumeClaimManifests(true) {
+		result = append(result, &pvc)
+	}
+
+	registrySecrets, err := a.registrySecretManifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, registrySecrets...)
+
 	// Add ConfigMaps for config files
 	for _, configMap := range a.configMapManifests() {
 		result = append(result, &configMap)
 	}
 
-	// Add volume mounts for persistent volumes
-	for _, volume := range a.Volumes {
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      volume.Slug,
-			MountPath: volume.MountPath,
-			SubPath:   volume.SubPath,
-		})
-
-		volumes = append(volumes, corev1.Volume{
-			Name: volume.Slug,
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: appVolumeName(a.AppSlug, volume.Slug),
-				},
-			},
-		})
-	}
+	// Add volume mounts for app volumes
+	volumeMounts, volumes = a.appVolumes(true)
 
 	// Add volume mounts for config files
 	for _, configFile := range a.ConfigFiles {
@@ -485,10 +573,10 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 		})
 	}
 
-	if len(a.Gateways) > 0 {
-		result = append(result, a.serviceManifest()...)
-		result = append(result, a.gatewayManifests()...)
-	}
+	result = append(result, a.serviceManifest()...)
+	result = append(result, a.gatewayManifests()...)
+
+	result = append(result, a.networkPolicyManifests()...)
 
 	var (
 		command []string
@@ -513,7 +601,7 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 			for _, selector := range a.SchedulingRule.NodeSelector {
 				parts := strings.SplitN(selector, "=", 2)
 				if len(parts) != 2 {
-					log.Printf("invalid node selector format: %s", selector)
+					logging.Warnf(ctx, "invalid node selector format: %s", selector)
 					continue
 				}
 				if schedulingRuleNodeSelector == nil {
@@ -551,6 +639,9 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 		}
 	}
 
+	sidecars, initContainers := a.extraContainers(true)
+	annotations[defaultContainerAnnotation] = a.AppSlug
+
 	result = append(result, &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      a.AppSlug,
@@ -558,9 +649,11 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 			Labels:    labels,
 		},
 		Spec: appsv1.StatefulSetSpec{
-			VolumeClaimTemplates: volumeClaims,
+			VolumeClaimTemplates: a.volumeClaimTemplates(),
 			ServiceName:          a.AppSlug,
 			Replicas:             &a.Replicas,
+			UpdateStrategy:       a.statefulSetUpdateStrategy(),
+			MinReadySeconds:      a.minReadySeconds(),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
@@ -570,14 +663,16 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					NodeName:     schedulingRuleNodeName,
-					NodeSelector: schedulingRuleNodeSelector,
-					Containers: []corev1.Container{
+					NodeName:       schedulingRuleNodeName,
+					NodeSelector:   schedulingRuleNodeSelector,
+					InitContainers: initContainers,
+					Containers: append([]corev1.Container{
 						{
 							Name:            a.AppSlug,
 							Image:           a.ContainerImage,
 							ImagePullPolicy: corev1.PullAlways,
 							Command:         command,
+							Ports:           a.containerPorts(),
 							Args:            args,
 							Env:             envs,
 							Resources: corev1.ResourceRequirements{
@@ -592,12 +687,13 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 							},
 							VolumeMounts: volumeMounts,
 						},
-					},
+					}, sidecars...),
 					Volumes: volumes,
 					Affinity: &corev1.Affinity{
 						NodeAffinity: nodeAffinity,
 					},
-					Tolerations: tolerations,
+					Tolerations:      tolerations,
+					ImagePullSecrets: a.imagePullSecrets(),
 				},
 			},
 			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
@@ -609,6 +705,84 @@ func (a *AppMetadata) statefulSetManifests() ([]client.Object, app.Error) {
 	return result, nil
 }
 
+// defaultContainerAnnotation marks the main container of the app's pods, it's
+// also honored by kubectl logs and exec.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// extraContainers renders the sidecar and init containers of the app, their
+// volume mounts refer to the pod volumes of the app's volumes and config files,
+// or to the claim templates of pvc volumes with claimTemplates set.
+func (a *AppMetadata) extraContainers(claimTemplates bool) (sidecars, initContainers []corev1.Container) {
+	volumeMountNames := make(map[string]string, len(a.Volumes))
+	for _, volume := range a.Volumes {
+		volumeMountNames[volume.Slug] = appVolumeMountName(a.AppSlug, volume, claimTemplates)
+	}
+
+	for _, c := range a.Containers {
+		container := corev1.Container{
+			Name:            c.Slug,
+			Image:           c.ContainerImage,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Resources:       containerResources(c.RequestCPU, c.RequestMemory, c.LimitCPU, c.LimitMemory),
+		}
+		if c.ContainerCommand != "" {
+			container.Command = []string{"sh"}
+			container.Args = []string{"-c", c.ContainerCommand}
+		}
+		for _, envVar := range c.EnvVars {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  envVar.Key,
+				Value: envVar.Value,
+			})
+		}
+		for _, mount := range c.VolumeMounts {
+			name, ok := volumeMountNames[mount.VolumeSlug]
+			if !ok {
+				name = mount.VolumeSlug // Config files are mounted by slug
+			}
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      name,
+				MountPath: mount.MountPath,
+				SubPath:   mount.SubPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+
+		switch c.ContainerType {
+		case app.AppContainerTypeInit:
+			initContainers = append(initContainers, container)
+		default:
+			sidecars = append(sidecars, container)
+		}
+	}
+	return sidecars, initContainers
+}
+
+// containerResources renders the resource requirements of a sidecar or init
+// container, zero values are left unset.
+func containerResources(requestCPU, requestMemory, limitCPU, limitMemory int32) corev1.ResourceRequirements {
+	var result corev1.ResourceRequirements
+	if requestCPU > 0 || requestMemory > 0 {
+		result.Requests = corev1.ResourceList{}
+	}
+	if limitCPU > 0 || limitMemory > 0 {
+		result.Limits = corev1.ResourceList{}
+	}
+	if requestCPU > 0 {
+		result.Requests[corev1.ResourceCPU] = resource.MustParse(fmt.Sprintf("%dm", requestCPU))
+	}
+	if requestMemory > 0 {
+		result.Requests[corev1.ResourceMemory] = resource.MustParse(fmt.Sprintf("%dMi", requestMemory))
+	}
+	if limitCPU > 0 {
+		result.Limits[corev1.ResourceCPU] = resource.MustParse(fmt.Sprintf("%dm", limitCPU))
+	}
+	if limitMemory > 0 {
+		result.Limits[corev1.ResourceMemory] = resource.MustParse(fmt.Sprintf("%dMi", limitMemory))
+	}
+	return result
+}
+
 func appConfigFileName(appSlug, configSlug string) string {
 	return fmt.Sprintf("%s-config-file-%s", appSlug, configSlug)
 }
@@ -639,114 +813,11 @@ func (a *AppMetadata) configMapManifests() []corev1.ConfigMap {
 	return result
 }
 
-func pvcAccessModes(accessModes []string) []corev1.PersistentVolumeAccessMode {
-	var kubeAccessModes []corev1.PersistentVolumeAccessMode
-	for _, mode := range accessModes {
-		kubeAccessModes = append(kubeAccessModes, corev1.PersistentVolumeAccessMode(mode))
-	}
-	return kubeAccessModes
-}
-
-func appVolumeName(appSlug, volumeSlug string) string {
-	return fmt.Sprintf("%s-volume-%s", appSlug, volumeSlug)
-}
-
-func (a *AppMetadata) persistentVolumeClaimManifests() []corev1.PersistentVolumeClaim {
-	var result []corev1.PersistentVolumeClaim
-
-	for _, volume := range a.Volumes {
-		result = append(result, corev1.PersistentVolumeClaim{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       "PersistentVolumeClaim", // Specify the kind explicitly, used in apply logic
-				APIVersion: "v1",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      appVolumeName(a.AppSlug, volume.Slug),
-				Namespace: a.ClusterNamespace,
-				Labels:    a.standardLabels(),
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				VolumeMode:  utils.Ptr(corev1.PersistentVolumeMode(volume.VolumeMode)),
-				AccessModes: pvcAccessModes(volume.AccessModes),
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dMi", volume.Capacity)),
-					},
-					Limits: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dMi", volume.Capacity)),
-					},
-				},
-				StorageClassName: &volume.StorageClass,
-			},
-		})
-	}
-
-	return result
-}
-
-func (a *AppMetadata) serviceManifest() []client.Object {
-	gateways := make(map[string]AppMetadataGateway)
-	for _, gateway := range a.Gateways {
-		key := fmt.Sprintf("%s-%s-%d", a.AppSlug, gateway.Protocol, gateway.Port)
-		if _, ok := gateways[key]; !ok {
-			gateways[key] = gateway
-		}
-	}
-
-	result := make([]client.Object, 0, len(gateways)+1)
-
-	labels := a.standardLabels()
-	selectorLabels := a.standardSelectorLabels()
-
-	ports := make([]corev1.ServicePort, 0, len(a.Gateways))
-	for _, g := range gateways {
-		// All ports in one service
-		ports = append(ports, corev1.ServicePort{
-			Protocol: corev1.ProtocolTCP,
-			Port:     g.Port,
-			TargetPort: intstr.IntOrString{
-				IntVal: g.Port,
-			},
-		})
-
-		// Create a service for each gateway
-		result = append(result, &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-%s-%d", a.AppSlug, g.Protocol, g.Port),
-				Namespace: a.ClusterNamespace,
-				Labels:    labels,
-			},
-			Spec: corev1.ServiceSpec{
-				Selector: selectorLabels,
-				Ports: []corev1.ServicePort{
-					{
-						Protocol: corev1.ProtocolTCP,
-						Port:     g.Port,
-						TargetPort: intstr.IntOrString{
-							IntVal: g.Port,
-						},
-					},
-				},
-			},
-		})
+func (a *AppMetadata) gatewayManifests() []client.Object {
+	if a.RoutingBackend == app.ClusterRoutingBackendIngress {
+		return a.ingressManifests()
 	}
 
-	result = append(result, &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      a.AppSlug,
-			Namespace: a.ClusterNamespace,
-			Labels:    labels,
-		},
-		Spec: corev1.ServiceSpec{
-			Selector: labels,
-			Ports:    ports,
-		},
-	})
-
-	return result
-}
-
-func (a *AppMetadata) gatewayManifests() []client.Object {
 	var result []client.Object
 	for _, gateway := range a.Gateways {
 		if !gateway.Exposed {
@@ -811,28 +882,7 @@ func (a *AppMetadata) gatewayManifests() []client.Object {
 					Hostnames: []gatewayapisv1.Hostname{
 						gatewayapisv1.Hostname(gateway.Domain),
 					},
-					Rules: []gatewayapisv1.HTTPRouteRule{
-						{
-							Matches: []gatewayapisv1.HTTPRouteMatch{
-								{
-									Path: &gatewayapisv1.HTTPPathMatch{
-										Type:  utils.Ptr(gatewayapisv1.PathMatchPathPrefix),
-										Value: utils.Ptr(gateway.Path),
-									},
-								},
-							},
-							BackendRefs: []gatewayapisv1.HTTPBackendRef{
-								{
-									BackendRef: gatewayapisv1.BackendRef{
-										BackendObjectReference: gatewayapisv1.BackendObjectReference{
-											Name: gatewayapisv1.ObjectName(a.AppSlug),
-											Port: utils.Ptr(gatewayapisv1.PortNumber(gateway.Port)),
-										},
-									},
-								},
-							},
-						},
-					},
+					Rules: a.httpRouteRules(gateway),
 				},
 			})
 		case app.AppGatewayProtocolTCP, app.AppGatewayProtocolUDP:
@@ -50,7 +50,7 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 		return nil, err
 	}
 
-	appConfigFiles, err := orm.AllAppConfigFiles(b.appEntity.ID)
+	appConfigFiles, err := orm.AllAppConfigFiles(b.ctx, b.appEntity.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,11 +60,21 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 		return nil, err
 	}
 
+	appPorts, err := orm.AllAppPorts(b.ctx, b.appEntity.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	appProbes, err := orm.AllAppProbes(b.appEntity.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	cluster, err := orm.GetClusterByID(b.ctx, b.appEntity.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &AppMetadata{
 		AppID:            b.appEntity.ID,
 		AppSlug:          b.appEntity.Slug,
@@ -86,6 +96,8 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 		ProjectID:        b.appEntity.ProjectID,
 		ProjectSlug:      b.appEntity.ProjectSlug,
 		ClusterNamespace: b.appEntity.ClusterNamespace,
+		RoutingBackend:   cluster.RoutingBackend,
+		IngressClassName: cluster.IngressClassName,
 	}
 
 	for _, envVar := range appEnvVars {
@@ -97,14 +109,21 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 
 	for _, volume := range appVolumes {
 		result.Volumes = append(result.Volumes, AppMetadataVolume{
-			Slug:         volume.Slug,
-			MountPath:    volume.MountPath,
-			SubPath:      volume.SubPath,
-			StorageClass: volume.StorageClass,
-			AccessModes:  splitAccessModes(volume.AccessModes),
-			VolumeType:   volume.VolumeType,
-			Capacity:     volume.Capacity,
-			VolumeMode:   volume.VolumeMode,
+			Slug:           volume.Slug,
+			MountPath:      volume.MountPath,
+			SubPath:        volume.SubPath,
+			StorageClass:   volume.StorageClass,
+			AccessModes:    splitAccessModes(volume.AccessModes),
+			VolumeType:     volume.VolumeType,
+			Capacity:       volume.Capacity,
+			VolumeMode:     volume.VolumeMode,
+			ReadOnly:       volume.ReadOnly,
+			EmptyDirMedium: volume.EmptyDirMedium,
+			HostPath:       volume.HostPath,
+			HostPathType:   volume.HostPathType,
+			NFSServer:      volume.NFSServer,
+			NFSPath:        volume.NFSPath,
+			ClaimName:      volume.ClaimName,
 		})
 	}
 
@@ -117,15 +136,56 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 		})
 	}
 
+	servicePorts := make(map[string]int32, len(appPorts))
+	for _, port := range appPorts {
+		result.Ports = append(result.Ports, AppMetadataPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      port.Protocol,
+			ServicePort:   port.ServicePort,
+			AppProtocol:   port.AppProtocol,
+		})
+		servicePorts[port.Name] = port.ServicePort
+	}
+
 	for _, gateway := range appGateways {
-		result.Gateways = append(result.Gateways, AppMetadataGateway{
-			Port:        gateway.Port,
+		port := gateway.Port
+		if gateway.PortName != "" {
+			servicePort, ok := servicePorts[gateway.PortName]
+			if !ok {
+				return nil, app.NewError(http.StatusBadRequest, "Gateway routes to port "+gateway.PortName+" the app doesn't have")
+			}
+			port = servicePort
+		}
+		g := AppMetadataGateway{
+			Port:        port,
 			Protocol:    gateway.Protocol,
 			Exposed:     gateway.Exposed,
 			Domain:      gateway.Domain,
 			Path:        gateway.Path,
 			GatewayPort: gateway.GatewayPort,
-		})
+			PortName:    gateway.PortName,
+		}
+		if gateway.Protocol == app.AppGatewayProtocolHTTPS && gateway.CertID != "" {
+			cert, err := orm.GetCertByID(b.ctx, gateway.CertID)
+			if err != nil {
+				return nil, err
+			}
+			g.Cert = &AppMetadataCert{
+				Slug:    cert.Slug,
+				TLSCert: cert.TLSCert,
+				TLSKey:  cert.TLSKey,
+			}
+		}
+		if gateway.Rules != "" {
+			if err := json.Unmarshal([]byte(gateway.Rules), &g.Rules); err != nil {
+				return nil, app.NewError(http.StatusInternalServerError, "Failed to parse gateway rules: "+err.Error())
+			}
+			if err := b.resolveGatewayRuleApps(g.Rules); err != nil {
+				return nil, err
+			}
+		}
+		result.Gateways = append(result.Gateways, g)
 	}
 
 	for _, probe := range appProbes {
@@ -144,6 +204,35 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 		})
 	}
 
+	appContainers, err := orm.AllAppContainers(b.ctx, b.appEntity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range appContainers {
+		c := AppMetadataContainer{
+			Slug:             container.Slug,
+			ContainerType:    container.ContainerType,
+			ContainerImage:   container.ContainerImage,
+			ContainerCommand: container.ContainerCommand,
+			RequestCPU:       container.RequestCPU,
+			RequestMemory:    container.RequestMemory,
+			LimitCPU:         container.LimitCPU,
+			LimitMemory:      container.LimitMemory,
+		}
+		if container.EnvVars != "" {
+			if err := json.Unmarshal([]byte(container.EnvVars), &c.EnvVars); err != nil {
+				return nil, app.NewError(http.StatusInternalServerError, "Failed to parse env vars of container "+container.Slug)
+			}
+		}
+		if container.VolumeMounts != "" {
+			if err := json.Unmarshal([]byte(container.VolumeMounts), &c.VolumeMounts); err != nil {
+				return nil, app.NewError(http.StatusInternalServerError, "Failed to parse volume mounts of container "+container.Slug)
+			}
+		}
+		result.Containers = append(result.Containers, c)
+	}
+
 	appSchedulingRule, err := orm.GetAppSchedulingRule(b.ctx, b.appEntity.ID)
 	if err != nil {
 		return nil, err
@@ -164,6 +253,93 @@ func (b *appMetadataBuilder) Build() (*AppMetadata, app.Error) {
 		result.SchedulingRule = schedulingRule
 	}
 
+	appRolloutStrategy, err := orm.GetAppRolloutStrategy(b.ctx, b.appEntity.ID)
+	if err != nil {
+		return nil, err
+	}
+	if appRolloutStrategy != nil {
+		result.RolloutStrategy = &AppMetadataRolloutStrategy{
+			StrategyType:            appRolloutStrategy.StrategyType,
+			MaxSurge:                appRolloutStrategy.MaxSurge,
+			MaxUnavailable:          appRolloutStrategy.MaxUnavailable,
+			Partition:               appRolloutStrategy.Partition,
+			MinReadySeconds:         appRolloutStrategy.MinReadySeconds,
+			ProgressDeadlineSeconds: appRolloutStrategy.ProgressDeadlineSeconds,
+		}
+	}
+
+	appNetworkPolicy, err := orm.GetAppNetworkPolicy(b.ctx, b.appEntity.ID)
+	if err != nil {
+		return nil, err
+	}
+	if appNetworkPolicy != nil {
+		networkPolicy := &AppMetadataNetworkPolicy{
+			AllowGateway: appNetworkPolicy.AllowGateway,
+		}
+		fromAppIDs := splitAppIDs(appNetworkPolicy.AllowFromApps)
+		slugs, err := orm.GetEnvAppSlugs(b.ctx, b.appEntity.EnvID, fromAppIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range fromAppIDs {
+			// Apps deleted after the policy was set are skipped
+			if slug, ok := slugs[id]; ok {
+				networkPolicy.AllowFromApps = append(networkPolicy.AllowFromApps, slug)
+			}
+		}
+		result.NetworkPolicy = networkPolicy
+	}
+
+	appDependencies, err := orm.AllAppDependencies(b.ctx, b.appEntity.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, dependency := range appDependencies {
+		dependsOn, err := orm.GetAppByID(b.ctx, dependency.DependsOnAppID)
+		if err != nil {
+			return nil, err
+		}
+		dependsOnPorts, err := orm.AllAppPorts(b.ctx, dependsOn.ID)
+		if err != nil {
+			return nil, err
+		}
+		dependsOnGateways, err := orm.AllAppGateways(dependsOn.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Dependencies = append(result.Dependencies, AppMetadataDependency{
+			AppSlug:   dependsOn.Slug,
+			Namespace: dependsOn.ClusterNamespace,
+			EnvPrefix: dependency.EnvPrefix,
+			Ports:     ServicePorts(dependsOnPorts, dependsOnGateways),
+		})
+	}
+	result.injectDependencyEnvVars()
+
+	if b.appEntity.AppType == app.AppTypeDeployment {
+		appRelease, err := orm.GetActiveAppRelease(b.ctx, b.appEntity.ID)
+		if err != nil {
+			return nil, err
+		}
+		// The stable workload of an active release is kept whatever is deployed,
+		// deploys of a changed config restart the release for its edition first
+		if appRelease != nil {
+			result.Release = &AppMetadataRelease{
+				ReleaseType:   appRelease.ReleaseType,
+				StableEdition: appRelease.StableEdition,
+				CanaryEdition: appRelease.CanaryEdition,
+				Weight:        appRelease.Weight,
+				Promoting:     appRelease.State == app.ReleaseStatePromoting,
+			}
+		}
+	}
+
+	registryCredentials, err := orm.AllProjectRegistryCredentials(b.ctx, b.appEntity.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	result.MatchRegistryCredentials(registryCredentials)
+
 	return result, nil
 }
 
@@ -173,3 +349,56 @@ func splitAccessModes(modes string) []string {
 	}
 	return strings.Split(modes, ";")
 }
+
+// resolveGatewayRuleApps sets the slugs of the apps gateway rules route or
+// mirror to. Apps deleted after the rules were saved are dropped, rules left
+// without backends route to the app itself.
+func (b *appMetadataBuilder) resolveGatewayRuleApps(rules []AppMetadataGatewayRule) app.Error {
+	var appIDs []string
+	for _, rule := range rules {
+		for _, backend := range rule.Backends {
+			if backend.AppID != "" {
+				appIDs = append(appIDs, backend.AppID)
+			}
+		}
+		if rule.Mirror != nil && rule.Mirror.AppID != "" {
+			appIDs = append(appIDs, rule.Mirror.AppID)
+		}
+	}
+	slugs, err := orm.GetEnvAppSlugs(b.ctx, b.appEntity.EnvID, appIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		backends := rules[i].Backends[:0]
+		for _, backend := range rules[i].Backends {
+			if backend.AppID != "" {
+				slug, ok := slugs[backend.AppID]
+				if !ok {
+					continue
+				}
+				backend.AppSlug = slug
+			}
+			backends = append(backends, backend)
+		}
+		rules[i].Backends = backends
+
+		if mirror := rules[i].Mirror; mirror != nil && mirror.AppID != "" {
+			slug, ok := slugs[mirror.AppID]
+			if !ok {
+				rules[i].Mirror = nil
+				continue
+			}
+			mirror.AppSlug = slug
+		}
+	}
+	return nil
+}
+
+func splitAppIDs(ids string) []string {
+	if ids == "" {
+		return nil
+	}
+	return strings.Split(ids, ",")
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/models"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// metricsAvailabilityTTL is how long the metrics-server check of a cluster is
+// trusted, so metrics show up shortly after metrics-server is installed.
+const metricsAvailabilityTTL = 5 * time.Minute
+
+type metricsAvailability struct {
+	available bool
+	checkedAt time.Time
+}
+
+var (
+	metricsAvailabilityMu sync.Mutex
+	clusterMetrics        = map[string]metricsAvailability{}
+)
+
+// MetricsAvailable tells whether metrics-server serves the metrics.k8s.io API
+// of the cluster.
+func MetricsAvailable(ctx context.Context, clusterID string) bool {
+	metricsAvailabilityMu.Lock()
+	cached, ok := clusterMetrics[clusterID]
+	metricsAvailabilityMu.Unlock()
+	if ok && time.Since(cached.checkedAt) < metricsAvailabilityTTL {
+		return cached.available
+	}
+
+	available := false
+	if cli, err := kube.ClusterRuntimeClient(ctx, clusterID); err == nil {
+		available, _ = CheckMetricsServerInstalled(ctx, cli)
+	}
+
+	metricsAvailabilityMu.Lock()
+	clusterMetrics[clusterID] = metricsAvailability{available: available, checkedAt: time.Now()}
+	metricsAvailabilityMu.Unlock()
+	return available
+}
+
+// SetAppInstancesUsage fills in the usage of the instances and their
+// containers, which is left empty on clusters without metrics.
+func SetAppInstancesUsage(ctx context.Context, appEntity *entities.App, instances []*models.AppInstanceModel) {
+	if len(instances) == 0 || !MetricsAvailable(ctx, appEntity.ClusterID) {
+		return
+	}
+
+	usages, err := kube.ListPodUsage(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return
+	}
+	for _, instance := range instances {
+		usage, ok := usages[instance.InstanceName]
+		if !ok {
+			continue
+		}
+		instance.Usage = ResourceUsage(usage.CPU, usage.Memory)
+		for _, container := range instance.Containers {
+			if containerUsage, ok := usage.Containers[container.ContainerName]; ok {
+				container.Usage = ResourceUsage(*containerUsage.Cpu(), *containerUsage.Memory())
+			}
+		}
+	}
+}
+
+// NamespaceUsage returns the total usage of the apps in the namespace, nil on
+// clusters without metrics.
+func NamespaceUsage(ctx context.Context, clusterID, namespace string) *models.ResourceUsageModel {
+	if !MetricsAvailable(ctx, clusterID) {
+		return nil
+	}
+
+	usages, err := kube.ListPodUsage(ctx, clusterID, namespace, "")
+	if err != nil {
+		return nil
+	}
+	var cpu, memory resource.Quantity
+	for _, usage := range usages {
+		cpu.Add(usage.CPU)
+		memory.Add(usage.Memory)
+	}
+	return ResourceUsage(cpu, memory)
+}
+
+// ResourceUsage returns the usage model of the quantities, memory is rounded
+// up to MiB.
+func ResourceUsage(cpu, memory resource.Quantity) *models.ResourceUsageModel {
+	cpuMilli := cpu.MilliValue()
+	memoryBytes := memory.Value()
+	memoryMiB := (memoryBytes + 1<<20 - 1) >> 20
+	return &models.ResourceUsageModel{
+		CPU:         resource.NewMilliQuantity(cpuMilli, resource.DecimalSI).String(),
+		Memory:      resource.NewQuantity(memoryMiB<<20, resource.BinarySI).String(),
+		CPUMilli:    cpuMilli,
+		MemoryBytes: memoryBytes,
+	}
+}
+
+// AddResourceUsage returns the sum of the usages, nil ones are skipped.
+func AddResourceUsage(usages ...*models.ResourceUsageModel) *models.ResourceUsageModel {
+	var (
+		cpu, memory resource.Quantity
+		found       bool
+	)
+	for _, usage := range usages {
+		if usage == nil {
+			continue
+		}
+		found = true
+		cpu.Add(*resource.NewMilliQuantity(usage.CPUMilli, resource.DecimalSI))
+		memory.Add(*resource.NewQuantity(usage.MemoryBytes, resource.BinarySI))
+	}
+	if !found {
+		return nil
+	}
+	return ResourceUsage(cpu, memory)
+}
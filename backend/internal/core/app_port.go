@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServicePorts returns the sorted ports of the service rendered for an app
+// with the ports and gateways, see serviceManifest.
+func ServicePorts(ports []*entities.AppPort, gateways []*entities.AppGateway) []int32 {
+	result := make([]int32, 0, len(ports)+len(gateways))
+	for _, port := range ports {
+		result = append(result, port.ServicePort)
+	}
+	for _, gateway := range gateways {
+		result = append(result, gateway.Port)
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+func (a *AppMetadata) containerPorts() []corev1.ContainerPort {
+	if len(a.Ports) == 0 {
+		return nil
+	}
+
+	result := make([]corev1.ContainerPort, 0, len(a.Ports))
+	for _, port := range a.Ports {
+		result = append(result, corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      corev1.Protocol(port.Protocol),
+		})
+	}
+	return result
+}
+
+// servicePorts returns the ports of the app service: the app ports, then the
+// ports of gateways not covered by them, which predate app ports.
+func (a *AppMetadata) servicePorts() []corev1.ServicePort {
+	result := make([]corev1.ServicePort, 0, len(a.Ports)+len(a.Gateways))
+	covered := make(map[int32]bool, len(a.Ports))
+	for _, port := range a.Ports {
+		servicePort := corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   corev1.Protocol(port.Protocol),
+			Port:       port.ServicePort,
+			TargetPort: intstr.FromString(port.Name),
+		}
+		if port.AppProtocol != "" {
+			servicePort.AppProtocol = &port.AppProtocol
+		}
+		result = append(result, servicePort)
+		if servicePort.Protocol == corev1.ProtocolTCP {
+			covered[port.ServicePort] = true
+		}
+	}
+
+	for _, gateway := range a.Gateways {
+		if covered[gateway.Port] {
+			continue
+		}
+		covered[gateway.Port] = true
+		result = append(result, corev1.ServicePort{
+			Name:       fmt.Sprintf("port-%d", gateway.Port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       gateway.Port,
+			TargetPort: intstr.FromInt32(gateway.Port),
+		})
+	}
+
+	return result
+}
+
+// serviceManifest renders the service of the app with all its ports, it is
+// headless for StatefulSets to give their instances stable DNS names. During
+// a release it comes with a service per edition, see releaseServiceManifests.
+// Gateways not routed to a named port get a service of their own as well.
+func (a *AppMetadata) serviceManifest() []client.Object {
+	labels := a.standardLabels()
+	selectorLabels := a.standardSelectorLabels()
+
+	var result []client.Object
+	gatewayServices := make(map[string]bool)
+	for _, g := range a.Gateways {
+		if g.PortName != "" {
+			continue
+		}
+		name := fmt.Sprintf("%s-%s-%d", a.AppSlug, g.Protocol, g.Port)
+		if gatewayServices[name] {
+			continue
+		}
+		gatewayServices[name] = true
+
+		result = append(result, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: a.ClusterNamespace,
+				Labels:    labels,
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: selectorLabels,
+				Ports: []corev1.ServicePort{
+					{
+						Protocol:   corev1.ProtocolTCP,
+						Port:       g.Port,
+						TargetPort: intstr.FromInt32(g.Port),
+					},
+				},
+			},
+		})
+	}
+
+	ports := a.servicePorts()
+	if len(ports) == 0 {
+		return result
+	}
+
+	selector := selectorLabels
+	if edition := a.servedEdition(); edition != "" {
+		selector = maps.Clone(selectorLabels)
+		selector["ketches.cn/edition"] = edition
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.AppSlug,
+			Namespace: a.ClusterNamespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+	if a.AppType == app.AppTypeStatefulSet {
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+
+	result = append(result, service)
+	return append(result, a.releaseServiceManifests(labels, selectorLabels, ports)...)
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/fielddiff"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ignoredDiffFields are maintained by the API server or change on every
+// deploy, so they are never reported as changes.
+var ignoredDiffFields = []string{
+	"metadata.resourceVersion",
+	"metadata.generation",
+	"metadata.uid",
+	"metadata.creationTimestamp",
+	"metadata.managedFields",
+	"status",
+}
+
+// Preview renders the app manifests, runs them through a server-side dry-run
+// apply and compares the result with the live objects in the cluster. Owned
+// objects that are no longer rendered are reported as to be pruned.
+func (a *AppMetadata) Preview(ctx context.Context, cli client.Client, options *AppDeployOption) ([]*models.AppManifestDiffModel, app.Error) {
+	a.applyDeployOption(options)
+
+	manifests, err := a.GetApplyManifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result  = make([]*models.AppManifestDiffModel, 0, len(manifests))
+		desired = make(map[string]struct{}, len(manifests))
+	)
+	force := options != nil && options.ForceApply
+	for _, obj := range manifests {
+		diff, err := previewResource(ctx, cli, obj, force)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, diff)
+		desired[inventoryKey(diff.Kind, diff.Name)] = struct{}{}
+	}
+	// Workloads a release keeps are not rendered but never pruned, see Deploy
+	for _, obj := range a.retainedObjects() {
+		desired[inventoryKey(obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())] = struct{}{}
+	}
+
+	orphans, err := orphanedObjects(ctx, cli, a.ClusterNamespace, a.AppID, a.AppSlug, desired)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range orphans {
+		diff := &models.AppManifestDiffModel{
+			Kind:      obj.GetKind(),
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Action:    app.ManifestDiffActionPrune,
+		}
+		if obj.GetKind() == "PersistentVolumeClaim" && (options == nil || !options.PruneVolumes) {
+			diff.Action = app.ManifestDiffActionUnchanged
+			diff.Message = "Orphaned volume is kept, enable volume pruning to delete it"
+		}
+		result = append(result, diff)
+	}
+
+	return result, nil
+}
+
+func previewResource(ctx context.Context, cli client.Client, obj client.Object, force bool) (*models.AppManifestDiffModel, app.Error) {
+	desired, err := kube.ToUnstructured(ctx, cli, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.AppManifestDiffModel{
+		Kind:      desired.GetKind(),
+		Name:      desired.GetName(),
+		Namespace: desired.GetNamespace(),
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		switch {
+		case k8serrors.IsNotFound(err):
+			live = nil
+		case meta.IsNoMatchError(err):
+			result.Action = app.ManifestDiffActionFailed
+			result.Message = fmt.Sprintf("%s is not installed in the cluster", desired.GroupVersionKind().GroupKind())
+			return result, nil
+		default:
+			logging.Errorf(ctx, "failed to get live %s %s/%s: %v", result.Kind, result.Namespace, result.Name, err)
+			return nil, app.ErrClusterOperationFailed
+		}
+	}
+
+	dryRun := desired.DeepCopy()
+	opts := []client.PatchOption{client.DryRunAll, client.FieldOwner(kube.FieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	patchErr := cli.Patch(ctx, dryRun, client.Apply, opts...)
+	if k8serrors.IsConflict(patchErr) && live != nil {
+		// Mirror the conflicts kube.ApplyResource settles on its own: replicas
+		// of other managers are left out, fields of the former client-side
+		// path are taken over, which a forced dry run previews
+		takeover := kube.NeedsApplyTakeover(live) && kube.OnlyLegacyConflicts(patchErr)
+		if kube.OmitForeignReplicas(live, desired) || takeover {
+			if takeover {
+				opts = append(opts, client.ForceOwnership)
+			}
+			dryRun = desired.DeepCopy()
+			patchErr = cli.Patch(ctx, dryRun, client.Apply, opts...)
+		}
+	}
+	if patchErr != nil {
+		result.Action = app.ManifestDiffActionFailed
+		result.Message = patchErr.Error()
+		return result, nil
+	}
+
+	if live == nil {
+		result.Action = app.ManifestDiffActionCreate
+		return result, nil
+	}
+
+	result.ChangedFields = fielddiff.Fields(live.Object, dryRun.Object, isIgnoredDiffField)
+	if len(result.ChangedFields) == 0 {
+		result.Action = app.ManifestDiffActionUnchanged
+	} else {
+		result.Action = app.ManifestDiffActionUpdate
+	}
+	return result, nil
+}
+
+func isIgnoredDiffField(path string) bool {
+	for _, ignored := range ignoredDiffFields {
+		if path == ignored {
+			return true
+		}
+	}
+	// The deploy timestamp annotation changes on every deploy
+	return strings.HasSuffix(path, `annotations["ketches.cn/deployed-at"]`)
+}
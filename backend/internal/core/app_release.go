@@ -0,0 +1,181 @@
+package core
+
+import (
+	"context"
+	"maps"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// releaseLabel marks the canary workload and its pods, keeping its selector
+// apart from the stable workload's.
+const releaseLabel = "ketches.cn/release"
+
+// AppMetadataRelease is the canary or blue-green release of the app edition.
+// The stable workload keeps running the previous edition and is never
+// rendered during a release, the app config only describes the new edition.
+type AppMetadataRelease struct {
+	ReleaseType   string `json:"releaseType"`
+	StableEdition string `json:"stableEdition"`
+	CanaryEdition string `json:"canaryEdition"`
+	Weight        int32  `json:"weight"`              // Percentage of gateway traffic routed to the canary edition
+	Aborted       bool   `json:"aborted,omitempty"`   // Only the stable edition is served, the canary workload is pruned
+	Promoting     bool   `json:"promoting,omitempty"` // The stable workload rolls out the new edition, the canary workload is kept until it is done
+}
+
+// splitsTraffic reports whether gateway traffic is split between the stable
+// and canary services of the release.
+func (r *AppMetadataRelease) splitsTraffic() bool {
+	return r != nil && !r.Aborted && !r.Promoting
+}
+
+// CanaryWorkloadName returns the name of the workload running the new edition
+// of an app during a release.
+func CanaryWorkloadName(appSlug string) string {
+	return appSlug + "-canary"
+}
+
+func stableServiceName(appSlug string) string {
+	return appSlug + "-stable"
+}
+
+// releaseManifests turns the Deployment of the app into the canary workload
+// of the release, or drops it when the release is aborted. Promoting releases
+// render the Deployment of the app as is, rolling out the new edition.
+func (a *AppMetadata) releaseManifests(manifests []client.Object) []client.Object {
+	if a.Release == nil || a.Release.Promoting {
+		return manifests
+	}
+
+	result := make([]client.Object, 0, len(manifests))
+	for _, obj := range manifests {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok || deployment.Name != a.AppSlug {
+			result = append(result, obj)
+			continue
+		}
+		if a.Release.Aborted {
+			continue
+		}
+
+		deployment.Name = CanaryWorkloadName(a.AppSlug)
+		deployment.Labels = withReleaseLabel(deployment.Labels)
+		deployment.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: withReleaseLabel(deployment.Spec.Selector.MatchLabels),
+		}
+		deployment.Spec.Template.Labels = withReleaseLabel(deployment.Spec.Template.Labels)
+		result = append(result, deployment)
+	}
+	return result
+}
+
+func withReleaseLabel(labels map[string]string) map[string]string {
+	result := maps.Clone(labels)
+	result[releaseLabel] = "canary"
+	return result
+}
+
+// retainedObjects returns the objects of the app that are not rendered but
+// must not be pruned, the stable workload during a release, the canary
+// workload while the release is promoted.
+func (a *AppMetadata) retainedObjects() []client.Object {
+	if a.Release == nil {
+		return nil
+	}
+	name := a.AppSlug
+	if a.Release.Promoting {
+		name = CanaryWorkloadName(a.AppSlug)
+	}
+	return []client.Object{
+		&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: a.ClusterNamespace,
+			},
+		},
+	}
+}
+
+// servedEdition returns the edition the app service selects during a
+// release, empty outside of releases. Only gateway traffic is split by the
+// weight of a canary release, in-cluster clients of the app service stay on
+// the stable edition until the release is promoted.
+func (a *AppMetadata) servedEdition() string {
+	switch {
+	case a.Release == nil:
+		return ""
+	case a.Release.Aborted:
+		return a.Release.StableEdition
+	case a.Release.Promoting:
+		// Pods of both workloads run the new edition
+		return a.Release.CanaryEdition
+	case a.Release.ReleaseType == app.ReleaseTypeBlueGreen && a.Release.Weight >= 100:
+		return a.Release.CanaryEdition
+	default:
+		return a.Release.StableEdition
+	}
+}
+
+// releaseServiceManifests renders a service per edition of the release,
+// gateway traffic is split between them by weight.
+func (a *AppMetadata) releaseServiceManifests(labels, selectorLabels map[string]string, ports []corev1.ServicePort) []client.Object {
+	if !a.Release.splitsTraffic() {
+		return nil
+	}
+
+	editions := []struct {
+		name    string
+		edition string
+	}{
+		{name: stableServiceName(a.AppSlug), edition: a.Release.StableEdition},
+		{name: CanaryWorkloadName(a.AppSlug), edition: a.Release.CanaryEdition},
+	}
+	result := make([]client.Object, 0, len(editions))
+	for _, e := range editions {
+		selector := maps.Clone(selectorLabels)
+		selector["ketches.cn/edition"] = e.edition
+		result = append(result, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      e.name,
+				Namespace: a.ClusterNamespace,
+				Labels:    labels,
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: selector,
+				Ports:    ports,
+			},
+		})
+	}
+	return result
+}
+
+// IsAppEditionHealthy reports whether the app has pods running the edition
+// and all of them are ready and none abnormal.
+func IsAppEditionHealthy(ctx context.Context, appEntity *entities.App, edition string) (bool, app.Error) {
+	pods, err := kube.ListPods(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	for _, pod := range pods {
+		if pod.Labels["ketches.cn/edition"] != edition || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if kube.IsAbnormalPod(pod) || !isPodReady(pod) {
+			return false, nil
+		}
+		count++
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,240 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type AppMetadataRolloutStrategy struct {
+	StrategyType            string `json:"strategyType"`
+	MaxSurge                string `json:"maxSurge,omitempty"`
+	MaxUnavailable          string `json:"maxUnavailable,omitempty"`
+	Partition               int32  `json:"partition,omitempty"`
+	MinReadySeconds         int32  `json:"minReadySeconds,omitempty"`
+	ProgressDeadlineSeconds int32  `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// deploymentStrategy renders the Deployment strategy, nil keeps the default
+// rolling update.
+func (a *AppMetadata) deploymentStrategy() appsv1.DeploymentStrategy {
+	rs := a.RolloutStrategy
+	if rs == nil {
+		return appsv1.DeploymentStrategy{}
+	}
+
+	switch rs.StrategyType {
+	case app.RolloutStrategyTypeRecreate:
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	default:
+		rollingUpdate := &appsv1.RollingUpdateDeployment{}
+		if rs.MaxSurge != "" {
+			rollingUpdate.MaxSurge = parseIntOrPercent(rs.MaxSurge)
+		}
+		if rs.MaxUnavailable != "" {
+			rollingUpdate.MaxUnavailable = parseIntOrPercent(rs.MaxUnavailable)
+		}
+		return appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: rollingUpdate,
+		}
+	}
+}
+
+// statefulSetUpdateStrategy renders the StatefulSet update strategy, nil
+// keeps the default rolling update.
+func (a *AppMetadata) statefulSetUpdateStrategy() appsv1.StatefulSetUpdateStrategy {
+	rs := a.RolloutStrategy
+	if rs == nil {
+		return appsv1.StatefulSetUpdateStrategy{}
+	}
+
+	switch rs.StrategyType {
+	case app.RolloutStrategyTypeOnDelete:
+		return appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}
+	default:
+		rollingUpdate := &appsv1.RollingUpdateStatefulSetStrategy{}
+		if rs.Partition > 0 {
+			rollingUpdate.Partition = &rs.Partition
+		}
+		if rs.MaxUnavailable != "" {
+			rollingUpdate.MaxUnavailable = parseIntOrPercent(rs.MaxUnavailable)
+		}
+		return appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: rollingUpdate,
+		}
+	}
+}
+
+func (a *AppMetadata) minReadySeconds() int32 {
+	if a.RolloutStrategy == nil {
+		return 0
+	}
+	return a.RolloutStrategy.MinReadySeconds
+}
+
+func (a *AppMetadata) progressDeadlineSeconds() *int32 {
+	if a.RolloutStrategy == nil || a.RolloutStrategy.ProgressDeadlineSeconds <= 0 {
+		return nil
+	}
+	return &a.RolloutStrategy.ProgressDeadlineSeconds
+}
+
+func parseIntOrPercent(value string) *intstr.IntOrString {
+	result := intstr.Parse(value)
+	return &result
+}
+
+type AppRolloutEdition struct {
+	Edition       string `json:"edition"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+}
+
+type AppRolloutProgress struct {
+	DesiredEdition    string              `json:"desiredEdition"`
+	ActualEdition     string              `json:"actualEdition"`
+	DesiredReplicas   int32               `json:"desiredReplicas"`
+	UpdatedReplicas   int32               `json:"updatedReplicas"`
+	ReadyReplicas     int32               `json:"readyReplicas"`
+	AvailableReplicas int32               `json:"availableReplicas"`
+	State             app.RolloutState    `json:"state"`
+	Message           string              `json:"message,omitempty"`
+	Editions          []AppRolloutEdition `json:"editions"`
+}
+
+// GetAppRolloutProgress reports the rollout of the app's workload, with the
+// instances grouped by the edition they run.
+func GetAppRolloutProgress(ctx context.Context, appEntity *entities.App) (*AppRolloutProgress, app.Error) {
+	result := &AppRolloutProgress{
+		DesiredEdition: appEntity.Edition,
+	}
+
+	switch appEntity.AppType {
+	case app.AppTypeDeployment:
+		deployment, err := kube.GetDeployment(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+		if err != nil {
+			return nil, err
+		}
+		result.ActualEdition = deployment.Labels["ketches.cn/edition"]
+		if deployment.Spec.Replicas != nil {
+			result.DesiredReplicas = *deployment.Spec.Replicas
+		}
+		result.UpdatedReplicas = deployment.Status.UpdatedReplicas
+		result.ReadyReplicas = deployment.Status.ReadyReplicas
+		result.AvailableReplicas = deployment.Status.AvailableReplicas
+
+		switch {
+		case deployment.Status.ObservedGeneration < deployment.Generation:
+			result.State = app.RolloutStateProgressing
+		case isDeploymentRolloutFailed(deployment):
+			result.State = app.RolloutStateFailed
+			result.Message = deploymentProgressingMessage(deployment)
+		case result.UpdatedReplicas == result.DesiredReplicas &&
+			deployment.Status.Replicas == result.DesiredReplicas &&
+			result.AvailableReplicas == result.DesiredReplicas:
+			result.State = app.RolloutStateComplete
+		default:
+			result.State = app.RolloutStateProgressing
+		}
+	case app.AppTypeStatefulSet:
+		statefulSet, err := kube.GetStatefulSet(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+		if err != nil {
+			return nil, err
+		}
+		result.ActualEdition = statefulSet.Labels["ketches.cn/edition"]
+		if statefulSet.Spec.Replicas != nil {
+			result.DesiredReplicas = *statefulSet.Spec.Replicas
+		}
+		result.UpdatedReplicas = statefulSet.Status.UpdatedReplicas
+		result.ReadyReplicas = statefulSet.Status.ReadyReplicas
+		result.AvailableReplicas = statefulSet.Status.AvailableReplicas
+
+		var partition int32
+		if ru := statefulSet.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+			partition = *ru.Partition
+		}
+		if statefulSet.Status.ObservedGeneration >= statefulSet.Generation &&
+			result.UpdatedReplicas >= result.DesiredReplicas-partition &&
+			result.AvailableReplicas == result.DesiredReplicas {
+			result.State = app.RolloutStateComplete
+		} else {
+			result.State = app.RolloutStateProgressing
+		}
+	default:
+		return nil, app.NewError(http.StatusBadRequest, "Not supported app type: "+appEntity.AppType)
+	}
+
+	pods, err := kube.ListPods(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	editions := make(map[string]*AppRolloutEdition)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		edition := pod.Labels["ketches.cn/edition"]
+		e, ok := editions[edition]
+		if !ok {
+			e = &AppRolloutEdition{Edition: edition}
+			editions[edition] = e
+		}
+		e.Replicas++
+		if isPodReady(pod) {
+			e.ReadyReplicas++
+		}
+	}
+	result.Editions = make([]AppRolloutEdition, 0, len(editions))
+	for _, e := range editions {
+		result.Editions = append(result.Editions, *e)
+	}
+	// newest edition first, editions are millisecond timestamps
+	sort.Slice(result.Editions, func(i, j int) bool {
+		return result.Editions[i].Edition > result.Editions[j].Edition
+	})
+
+	return result, nil
+}
+
+// isDeploymentRolloutFailed reports whether the deployment controller gave up
+// waiting for the rollout to progress.
+func isDeploymentRolloutFailed(deployment *appsv1.Deployment) bool {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing {
+			return cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
+
+func deploymentProgressingMessage(deployment *appsv1.Deployment) string {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing {
+			return cond.Message
+		}
+	}
+	return ""
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
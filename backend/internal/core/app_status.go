@@ -6,6 +6,7 @@ import (
 
 	"github.com/ketches/ketches/internal/app"
 	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
 	"github.com/ketches/ketches/internal/kube"
 	"github.com/ketches/ketches/internal/models"
 	corev1 "k8s.io/api/core/v1"
@@ -16,6 +17,8 @@ type AppStatusDescription struct {
 	DesiredEdition  string        `json:"desiredEdition"`
 	ActualReplicas  int32         `json:"actualReplicas"`
 	ActualEdition   string        `json:"actualEdition"`
+	CanaryEdition   string        `json:"canaryEdition,omitempty"`  // Edition released beside the actual edition, see AppMetadataRelease
+	CanaryReplicas  int32         `json:"canaryReplicas,omitempty"` // Instances of the canary edition, included in ActualReplicas
 	Status          app.AppStatus `json:"status"`
 }
 
@@ -42,6 +45,19 @@ func GetAppStatus(ctx context.Context, appEntity *entities.App) AppStatusDescrip
 			result.Status = app.AppStatusDebugging
 			return result
 		}
+		if isDeploymentRolloutFailed(deployment) {
+			result.ActualReplicas = deployment.Status.Replicas
+			result.Status = app.AppStatusRolloutFailed
+			return result
+		}
+		release, err := orm.GetActiveAppRelease(ctx, appEntity.ID)
+		if err != nil {
+			result.Status = app.AppStatusUnknown
+			return result
+		}
+		if release != nil {
+			result.CanaryEdition = release.CanaryEdition
+		}
 	case app.AppTypeStatefulSet:
 		statefulSet, err := kube.GetStatefulSet(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
 		if err != nil {
@@ -85,7 +101,9 @@ func GetAppStatus(ctx context.Context, appEntity *entities.App) AppStatusDescrip
 		}
 
 		edition := pod.Labels["ketches.cn/edition"]
-		if edition != result.ActualEdition {
+		if result.CanaryEdition != "" && edition == result.CanaryEdition {
+			result.CanaryReplicas++
+		} else if edition != result.ActualEdition {
 			updating = true
 			continue
 		}
@@ -129,6 +147,10 @@ func GetAppStatus(ctx context.Context, appEntity *entities.App) AppStatusDescrip
 	}
 
 	if runningPodCount == result.ActualReplicas {
+		if result.CanaryEdition != "" {
+			result.Status = app.AppStatusReleasing
+			return result
+		}
 		result.Status = app.AppStatusRunning
 		return result
 	}
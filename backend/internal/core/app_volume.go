@@ -0,0 +1,206 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func appVolumeName(appSlug, volumeSlug string) string {
+	return fmt.Sprintf("%s-volume-%s", appSlug, volumeSlug)
+}
+
+// SharedVolumeClaimName is the claim of a shared project volume in an env
+// namespace, every app mounting the shared volume binds to it.
+func SharedVolumeClaimName(name string) string {
+	return "shared-volume-" + name
+}
+
+func pvcAccessModes(accessModes []string) []corev1.PersistentVolumeAccessMode {
+	var kubeAccessModes []corev1.PersistentVolumeAccessMode
+	for _, mode := range accessModes {
+		kubeAccessModes = append(kubeAccessModes, corev1.PersistentVolumeAccessMode(mode))
+	}
+	return kubeAccessModes
+}
+
+// appVolumes renders the mounts and pod volumes of the app volumes. With
+// claimTemplates set, pvc volumes are left to the StatefulSet volume claim
+// templates, which keep the claim names of earlier releases.
+func (a *AppMetadata) appVolumes(claimTemplates bool) ([]corev1.VolumeMount, []corev1.Volume) {
+	volumeMounts := make([]corev1.VolumeMount, 0, len(a.Volumes)+len(a.ConfigFiles))
+	volumes := make([]corev1.Volume, 0, len(a.Volumes)+len(a.ConfigFiles))
+
+	for _, volume := range a.Volumes {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      appVolumeMountName(a.AppSlug, volume, claimTemplates),
+			MountPath: volume.MountPath,
+			SubPath:   volume.SubPath,
+			ReadOnly:  volume.ReadOnly,
+		})
+		if claimTemplates && volume.VolumeType == app.AppVolumeTypePVC {
+			continue
+		}
+
+		volumes = append(volumes, corev1.Volume{
+			Name:         volume.Slug,
+			VolumeSource: a.volumeSource(volume),
+		})
+	}
+
+	return volumeMounts, volumes
+}
+
+// appVolumeMountName is the name containers mount an app volume by: the claim
+// template of pvc volumes with claimTemplates set, the pod volume otherwise.
+func appVolumeMountName(appSlug string, volume AppMetadataVolume, claimTemplates bool) string {
+	if claimTemplates && volume.VolumeType == app.AppVolumeTypePVC {
+		return appVolumeName(appSlug, volume.Slug)
+	}
+	return volume.Slug
+}
+
+func (a *AppMetadata) volumeSource(volume AppMetadataVolume) corev1.VolumeSource {
+	switch volume.VolumeType {
+	case app.AppVolumeTypeEmptyDir:
+		emptyDir := &corev1.EmptyDirVolumeSource{
+			Medium: corev1.StorageMedium(volume.EmptyDirMedium),
+		}
+		if volume.Capacity > 0 {
+			emptyDir.SizeLimit = utils.Ptr(resource.MustParse(fmt.Sprintf("%dMi", volume.Capacity)))
+		}
+		return corev1.VolumeSource{EmptyDir: emptyDir}
+	case app.AppVolumeTypeHostPath:
+		hostPath := &corev1.HostPathVolumeSource{
+			Path: volume.HostPath,
+		}
+		if volume.HostPathType != "" {
+			hostPath.Type = utils.Ptr(corev1.HostPathType(volume.HostPathType))
+		}
+		return corev1.VolumeSource{HostPath: hostPath}
+	case app.AppVolumeTypeNFS:
+		return corev1.VolumeSource{
+			NFS: &corev1.NFSVolumeSource{
+				Server:   volume.NFSServer,
+				Path:     volume.NFSPath,
+				ReadOnly: volume.ReadOnly,
+			},
+		}
+	case app.AppVolumeTypeExistingClaim:
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volume.ClaimName,
+				ReadOnly:  volume.ReadOnly,
+			},
+		}
+	case app.AppVolumeTypeShared:
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: SharedVolumeClaimName(volume.ClaimName),
+				ReadOnly:  volume.ReadOnly,
+			},
+		}
+	default:
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: appVolumeName(a.AppSlug, volume.Slug),
+				ReadOnly:  volume.ReadOnly,
+			},
+		}
+	}
+}
+
+// persistentVolumeClaimManifests renders the claims the app creates in its
+// namespace. Shared volume claims carry no app ID label, so they outlive the
+// apps mounting them. pvc volumes are skipped with claimTemplates set.
+func (a *AppMetadata) persistentVolumeClaimManifests(claimTemplates bool) []corev1.PersistentVolumeClaim {
+	var result []corev1.PersistentVolumeClaim
+
+	for _, volume := range a.Volumes {
+		switch volume.VolumeType {
+		case app.AppVolumeTypePVC:
+			if claimTemplates {
+				continue
+			}
+			result = append(result, corev1.PersistentVolumeClaim{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "PersistentVolumeClaim", // Specify the kind explicitly, used in apply logic
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      appVolumeName(a.AppSlug, volume.Slug),
+					Namespace: a.ClusterNamespace,
+					Labels:    a.standardLabels(),
+				},
+				Spec: volumeClaimSpec(volume),
+			})
+		case app.AppVolumeTypeShared:
+			result = append(result, corev1.PersistentVolumeClaim{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "PersistentVolumeClaim",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      SharedVolumeClaimName(volume.ClaimName),
+					Namespace: a.ClusterNamespace,
+					Labels: map[string]string{
+						"ketches.cn/owned":         "true",
+						"ketches.cn/shared-volume": volume.ClaimName,
+					},
+				},
+				Spec: volumeClaimSpec(volume),
+			})
+		}
+	}
+
+	return result
+}
+
+// volumeClaimTemplates renders the per-replica claims of a StatefulSet, named
+// like the claims of Deployments. The templates only carry the selector
+// labels: claims created from them must not be pruned as orphans of the app.
+// Templates are immutable, existing StatefulSets keep theirs, see
+// applyStatefulSet.
+func (a *AppMetadata) volumeClaimTemplates() []corev1.PersistentVolumeClaim {
+	var result []corev1.PersistentVolumeClaim
+
+	for _, volume := range a.Volumes {
+		if volume.VolumeType != app.AppVolumeTypePVC {
+			continue
+		}
+		result = append(result, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   appVolumeName(a.AppSlug, volume.Slug),
+				Labels: a.standardSelectorLabels(),
+			},
+			Spec: volumeClaimSpec(volume),
+		})
+	}
+
+	return result
+}
+
+func volumeClaimSpec(volume AppMetadataVolume) corev1.PersistentVolumeClaimSpec {
+	spec := corev1.PersistentVolumeClaimSpec{
+		AccessModes: pvcAccessModes(volume.AccessModes),
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dMi", volume.Capacity)),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dMi", volume.Capacity)),
+			},
+		},
+	}
+	if volume.VolumeMode != "" {
+		spec.VolumeMode = utils.Ptr(corev1.PersistentVolumeMode(volume.VolumeMode))
+	}
+	if volume.StorageClass != "" {
+		spec.StorageClassName = &volume.StorageClass
+	}
+	return spec
+}
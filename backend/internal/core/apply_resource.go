@@ -2,95 +2,139 @@ package core
 
 import (
 	"context"
-	"log"
-	"reflect"
+	"net/http"
+	"slices"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func ApplyResource(ctx context.Context, cli client.Client, obj client.Object) app.Error {
-	kind := obj.GetObjectKind().GroupVersionKind().Kind
-	switch kind {
-	case "PersistentVolumeClaim":
+// ApplyResource applies obj with server-side apply, see kube.ApplyResource.
+func ApplyResource(ctx context.Context, cli client.Client, obj client.Object, opts ...client.PatchOption) (err app.Error) {
+	ctx, span := tracing.Start(ctx, "ApplyResource",
+		attribute.String("k8s.kind", obj.GetObjectKind().GroupVersionKind().Kind),
+		attribute.String("k8s.namespace", obj.GetNamespace()),
+		attribute.String("k8s.name", obj.GetName()),
+	)
+	defer func() {
+		tracing.EndApp(span, err)
+	}()
+
+	if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
 		// Special handling for PVC cause it has immutable fields
-		applyPVC(ctx, cli, obj.(*corev1.PersistentVolumeClaim))
-	default:
-		applyResource(ctx, cli, obj)
+		return applyPVC(ctx, cli, pvc, opts...)
 	}
-	return nil
-}
-
-func newEmptyObjectFrom(obj client.Object) client.Object {
-	t := reflect.TypeOf(obj).Elem()
-	return reflect.New(t).Interface().(client.Object)
+	if sts, ok := obj.(*appsv1.StatefulSet); ok {
+		// Special handling for StatefulSet cause its claim templates are immutable
+		return applyStatefulSet(ctx, cli, sts, opts...)
+	}
+	if svc, ok := obj.(*corev1.Service); ok {
+		// Special handling for Service cause its cluster IP is immutable
+		return applyService(ctx, cli, svc, opts...)
+	}
+	return kube.ApplyResource(ctx, cli, obj, opts...)
 }
 
-func applyResource(ctx context.Context, cli client.Client, obj client.Object) app.Error {
-	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		got := newEmptyObjectFrom(obj)
-		err := cli.Get(ctx, client.ObjectKey{Name: obj.GetName(), Namespace: obj.GetNamespace()}, got)
-		if err != nil {
-			if k8serrors.IsNotFound(err) {
-				obj.SetResourceVersion("")
-				return cli.Create(ctx, obj)
-			}
-			log.Println("failed to get resource:", err)
-			return err
+func applyPVC(ctx context.Context, cli client.Client, obj *corev1.PersistentVolumeClaim, opts ...client.PatchOption) app.Error {
+	got := &corev1.PersistentVolumeClaim{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), got); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// PVC does not exist, apply the full manifest
+			return kube.ApplyResource(ctx, cli, obj, opts...)
 		}
-		obj.SetResourceVersion(got.GetResourceVersion())
-		return cli.Update(ctx, obj)
-	}); err != nil {
-		log.Println("failed to apply resource:", err)
+		logging.Errorf(ctx, "failed to get PVC: %v", err)
 		return app.ErrClusterOperationFailed
 	}
 
-	return nil
+	// The spec of a claim is immutable after creation except for growing its
+	// storage request, the full manifest is applied with the live values of
+	// immutable fields so the field manager keeps owning them
+	desired := obj.DeepCopy()
+	desired.Spec.AccessModes = got.Spec.AccessModes
+	if desired.Spec.StorageClassName != nil {
+		desired.Spec.StorageClassName = got.Spec.StorageClassName
+	}
+	if desired.Spec.VolumeMode != nil {
+		desired.Spec.VolumeMode = got.Spec.VolumeMode
+	}
+	if desired.Spec.VolumeName != "" {
+		desired.Spec.VolumeName = got.Spec.VolumeName
+	}
+	if desired.Spec.Selector != nil {
+		desired.Spec.Selector = got.Spec.Selector
+	}
+	if desired.Spec.DataSource != nil || desired.Spec.DataSourceRef != nil {
+		desired.Spec.DataSource, desired.Spec.DataSourceRef = got.Spec.DataSource, got.Spec.DataSourceRef
+	}
+	if live, ok := got.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		if requested, ok := desired.Spec.Resources.Requests[corev1.ResourceStorage]; ok && live.Cmp(requested) > 0 {
+			// Claims cannot shrink
+			desired.Spec.Resources.Requests[corev1.ResourceStorage] = live
+		}
+	}
+	return kube.ApplyResource(ctx, cli, desired, opts...)
 }
 
-func applyPVC(ctx context.Context, cli client.Client, obj *corev1.PersistentVolumeClaim) app.Error {
-	got := &corev1.PersistentVolumeClaim{}
-	gotErr := cli.Get(ctx, client.ObjectKey{Name: obj.Name, Namespace: obj.Namespace}, got)
-	if gotErr != nil {
-		if k8serrors.IsNotFound(gotErr) {
-			// PVC does not exist, create it
-			createErr := cli.Create(ctx, obj)
-			if createErr != nil {
-				log.Println("failed to create PVC:", createErr)
-				return app.ErrClusterOperationFailed
-			}
-			return nil
+func applyStatefulSet(ctx context.Context, cli client.Client, obj *appsv1.StatefulSet, opts ...client.PatchOption) app.Error {
+	got := &appsv1.StatefulSet{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), got); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return kube.ApplyResource(ctx, cli, obj, opts...)
 		}
+		logging.Errorf(ctx, "failed to get statefulset: %v", err)
+		return app.ErrClusterOperationFailed
 	}
 
-	// PVC spec is immutable after creation except resources.requests
-	// and volumeAttributesClassName for bound claims
-	got.Labels = obj.Labels
-	got.Annotations = obj.Annotations
-	got.Spec.Resources.Requests = obj.Spec.Resources.Requests
-	obj = got
+	// Claim templates cannot change once the StatefulSet exists, the live
+	// templates are kept as long as they claim the same volumes
+	gotNames := make([]string, 0, len(got.Spec.VolumeClaimTemplates))
+	for _, template := range got.Spec.VolumeClaimTemplates {
+		gotNames = append(gotNames, template.Name)
+	}
+	names := make([]string, 0, len(obj.Spec.VolumeClaimTemplates))
+	for _, template := range obj.Spec.VolumeClaimTemplates {
+		names = append(names, template.Name)
+	}
+	slices.Sort(gotNames)
+	slices.Sort(names)
+	if !slices.Equal(gotNames, names) {
+		return app.NewError(http.StatusConflict, "Persistent volumes of a StatefulSet app changed, redeploy the app to apply them")
+	}
 
-	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		obj.ResourceVersion = got.ResourceVersion
-		updateErr := cli.Update(ctx, got)
-		if updateErr != nil {
-			log.Println("failed to update PVC:", updateErr)
-			gotNewer := &corev1.PersistentVolumeClaim{}
-			gotErr := cli.Get(ctx, client.ObjectKey{Name: obj.Name, Namespace: obj.Namespace}, gotNewer)
-			if gotErr != nil {
-				return gotErr
-			}
-			obj.ResourceVersion = gotNewer.ResourceVersion
-			return updateErr
-		}
+	desired := obj.DeepCopy()
+	desired.Spec.VolumeClaimTemplates = got.Spec.VolumeClaimTemplates
+	return kube.ApplyResource(ctx, cli, desired, opts...)
+}
 
-		return nil
-	}); err != nil {
+func applyService(ctx context.Context, cli client.Client, obj *corev1.Service, opts ...client.PatchOption) app.Error {
+	got := &corev1.Service{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), got); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return kube.ApplyResource(ctx, cli, obj, opts...)
+		}
+		logging.Errorf(ctx, "failed to get service: %v", err)
 		return app.ErrClusterOperationFailed
 	}
 
-	return nil
+	// Switching a service between headless and cluster IP needs it to be
+	// recreated, which drops the cluster IP clients may still use. Services
+	// keep the shape they were created with, e.g. the cluster IP service of a
+	// StatefulSet app deployed before its service became headless, until the
+	// app is undeployed.
+	if (obj.Spec.ClusterIP == corev1.ClusterIPNone) != (got.Spec.ClusterIP == corev1.ClusterIPNone) {
+		obj = obj.DeepCopy()
+		if got.Spec.ClusterIP == corev1.ClusterIPNone {
+			obj.Spec.ClusterIP = corev1.ClusterIPNone
+		} else {
+			obj.Spec.ClusterIP = ""
+		}
+	}
+	return kube.ApplyResource(ctx, cli, obj, opts...)
 }
@@ -2,12 +2,14 @@ package core
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -31,7 +33,9 @@ func CheckNativeExtensions(ctx context.Context, cli client.Client) []NativeExten
 }
 
 var nativeExtensions = map[string]nativeExtensionChecker{
-	"gateway-api": &gatewayAPIExtension{},
+	"gateway-api":     &gatewayAPIExtension{},
+	"volume-snapshot": &volumeSnapshotExtension{},
+	"metrics-server":  &metricsServerExtension{},
 }
 
 type nativeExtensionChecker interface {
@@ -46,7 +50,7 @@ func (g *gatewayAPIExtension) Check(ctx context.Context, cli client.Client) (*Na
 		if k8serrors.IsNotFound(err) {
 			return &NativeExtension{Slug: "gateway-api", DisplayName: "Gateway API", Description: "Enables the Gateway API for managing application gateways.", Installed: false}, nil
 		}
-		log.Println("failed to check Gateway API CRD:", err)
+		logging.Errorf(ctx, "failed to check Gateway API CRD: %v", err)
 		return nil, app.ErrClusterOperationFailed
 	}
 	return &NativeExtension{
@@ -60,9 +64,84 @@ func (g *gatewayAPIExtension) Check(ctx context.Context, cli client.Client) (*Na
 }
 
 func CheckGatewayAPIInstalled(ctx context.Context, cli client.Client) (bool, app.Error) {
-	_, err := nativeExtensions["gateway-api"].Check(ctx, cli)
+	ext, err := nativeExtensions["gateway-api"].Check(ctx, cli)
 	if err != nil {
 		return false, err
 	}
-	return true, nil
+	return ext.Installed, nil
+}
+
+type volumeSnapshotExtension struct{}
+
+func (v *volumeSnapshotExtension) Check(ctx context.Context, cli client.Client) (*NativeExtension, app.Error) {
+	var snapshotCRD apiextensionsv1.CustomResourceDefinition
+	if err := cli.Get(ctx, client.ObjectKey{Name: "volumesnapshots.snapshot.storage.k8s.io"}, &snapshotCRD); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &NativeExtension{Slug: "volume-snapshot", DisplayName: "Volume Snapshot", Description: "Enables CSI volume snapshots for backing up and restoring app volumes.", Installed: false}, nil
+		}
+		logging.Errorf(ctx, "failed to check VolumeSnapshot CRD: %v", err)
+		return nil, app.ErrClusterOperationFailed
+	}
+	result := &NativeExtension{
+		Slug:        "volume-snapshot",
+		DisplayName: "Volume Snapshot",
+		Description: "Enables CSI volume snapshots for backing up and restoring app volumes.",
+		Installed:   true,
+		CreatedAt:   snapshotCRD.CreationTimestamp.Time,
+	}
+	for _, version := range snapshotCRD.Spec.Versions {
+		if version.Storage {
+			result.Version = version.Name
+		}
+	}
+	return result, nil
+}
+
+func CheckVolumeSnapshotInstalled(ctx context.Context, cli client.Client) (bool, app.Error) {
+	ext, err := nativeExtensions["volume-snapshot"].Check(ctx, cli)
+	if err != nil {
+		return false, err
+	}
+	return ext.Installed, nil
+}
+
+type metricsServerExtension struct{}
+
+// Check looks up the APIService metrics-server registers, which is only
+// installed once it is available.
+func (m *metricsServerExtension) Check(ctx context.Context, cli client.Client) (*NativeExtension, app.Error) {
+	apiService := &unstructured.Unstructured{}
+	apiService.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"})
+	if err := cli.Get(ctx, client.ObjectKey{Name: "v1beta1.metrics.k8s.io"}, apiService); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &NativeExtension{Slug: "metrics-server", DisplayName: "Metrics Server", Description: "Enables CPU and memory usage of app instances.", Installed: false}, nil
+		}
+		logging.Errorf(ctx, "failed to check metrics APIService: %v", err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	available := false
+	conditions, _, _ := unstructured.NestedSlice(apiService.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Available" && condition["status"] == "True" {
+			available = true
+		}
+	}
+	return &NativeExtension{
+		Slug:        "metrics-server",
+		DisplayName: "Metrics Server",
+		Description: "Enables CPU and memory usage of app instances.",
+		Installed:   available,
+		Version:     "v1beta1",
+		CreatedAt:   apiService.GetCreationTimestamp().Time,
+	}, nil
+}
+
+func CheckMetricsServerInstalled(ctx context.Context, cli client.Client) (bool, app.Error) {
+	ext, err := nativeExtensions["metrics-server"].Check(ctx, cli)
+	if err != nil {
+		return false, err
+	}
+	return ext.Installed, nil
 }
@@ -2,9 +2,9 @@ package core
 
 import (
 	"context"
-	"log"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -15,7 +15,7 @@ func DeleteResource(ctx context.Context, cli client.Client, obj client.Object) a
 			// Resource already deleted
 			return nil
 		}
-		log.Println("failed to delete resource:", err)
+		logging.Errorf(ctx, "failed to delete resource: %v", err)
 		return app.ErrClusterOperationFailed
 	}
 	return nil
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+
+	"github.com/ketches/ketches/internal/app"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EnvNetworkPolicyName is the name of the default network policy of an
+	// env namespace.
+	EnvNetworkPolicyName = "ketches-env-default"
+
+	// networkPolicyLabel marks pods of apps with their own network policy,
+	// the env default policy does not select them so that it can't widen
+	// what the app policy admits.
+	networkPolicyLabel = "ketches.cn/network-policy"
+
+	gatewayNameLabel = "gateway.networking.k8s.io/gateway-name"
+)
+
+type AppMetadataNetworkPolicy struct {
+	AllowGateway  bool     `json:"allowGateway,omitempty"`
+	AllowFromApps []string `json:"allowFromApps,omitempty"` // Slugs of apps in the same env
+}
+
+// EnvNetworkPolicyManifest renders the default network policy of an env
+// namespace, it returns nil for the open mode.
+func EnvNetworkPolicyManifest(namespace, mode string, labels map[string]string) *networkingv1.NetworkPolicy {
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	switch mode {
+	case app.EnvNetworkPolicyModeIsolate:
+		// Admit pods of the same env and of namespaces not managed by
+		// ketches, like the gateway and monitoring.
+		ingress = []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						PodSelector: &metav1.LabelSelector{},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{
+									Key:      "ketches.cn/owned",
+									Operator: metav1.LabelSelectorOpDoesNotExist,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	case app.EnvNetworkPolicyModeDenyAllIngress:
+		// No ingress rule, only app network policies admit traffic
+	default:
+		return nil
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EnvNetworkPolicyName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      networkPolicyLabel,
+						Operator: metav1.LabelSelectorOpDoesNotExist,
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
+
+// ApplyEnvNetworkPolicy applies the default network policy of an env
+// namespace, or deletes it when mode is open.
+func ApplyEnvNetworkPolicy(ctx context.Context, cli client.Client, namespace, mode string, labels map[string]string) app.Error {
+	policy := EnvNetworkPolicyManifest(namespace, mode, labels)
+	if policy == nil {
+		return DeleteResource(ctx, cli, &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      EnvNetworkPolicyName,
+				Namespace: namespace,
+			},
+		})
+	}
+	return ApplyResource(ctx, cli, policy)
+}
+
+func (a *AppMetadata) networkPolicyManifests() []client.Object {
+	if a.NetworkPolicy == nil {
+		return nil
+	}
+
+	// Without peers the policy rejects all ingress traffic of the app
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, slug := range a.NetworkPolicy.AllowFromApps {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"ketches.cn/owned": "true",
+					"ketches.cn/app":   slug,
+				},
+			},
+		})
+	}
+	if a.NetworkPolicy.AllowGateway && a.RoutingBackend == app.ClusterRoutingBackendIngress {
+		// Ingress controller
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"kubernetes.io/metadata.name": app.IngressNamespace(),
+				},
+			},
+		})
+	} else if a.NetworkPolicy.AllowGateway {
+		peers = append(peers,
+			// Gateway data plane provisioned in the env namespace
+			networkingv1.NetworkPolicyPeer{
+				PodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      gatewayNameLabel,
+							Operator: metav1.LabelSelectorOpExists,
+						},
+					},
+				},
+			},
+			// Shared gateway data plane
+			networkingv1.NetworkPolicyPeer{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"kubernetes.io/metadata.name": app.GatewayNamespace(),
+					},
+				},
+			},
+		)
+	}
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if len(peers) > 0 {
+		ingress = []networkingv1.NetworkPolicyIngressRule{{From: peers}}
+	}
+
+	return []client.Object{
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      a.AppSlug,
+				Namespace: a.ClusterNamespace,
+				Labels:    a.standardLabels(),
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: a.standardSelectorLabels(),
+				},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				Ingress:     ingress,
+			},
+		},
+	}
+}
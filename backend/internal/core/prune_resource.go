@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ownedResourceKinds lists every kind an app can render, used to find the
+// objects labeled with the app ID in its namespace.
+var ownedResourceKinds = []schema.GroupVersionKind{
+	{Group: "", Version: "v1", Kind: "ConfigMap"},
+	{Group: "", Version: "v1", Kind: "PersistentVolumeClaim"},
+	{Group: "", Version: "v1", Kind: "Secret"},
+	{Group: "", Version: "v1", Kind: "Service"},
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+	{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"},
+	{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"},
+	{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TCPRoute"},
+}
+
+// PruneResources deletes the objects labeled with the app ID that are not in
+// manifests anymore. PVCs are only deleted when pruneVolumes is set, so that
+// removing a volume from the app never drops its data implicitly. Claims the
+// StatefulSet of the app created from its claim templates are never pruned.
+func PruneResources(ctx context.Context, cli client.Client, namespace, appID, appSlug string, manifests []client.Object, pruneVolumes bool) app.Error {
+	desired := make(map[string]struct{}, len(manifests))
+	for _, obj := range manifests {
+		gvk, err := apiutil.GVKForObject(obj, cli.Scheme())
+		if err != nil {
+			logging.Errorf(ctx, "failed to get gvk of %s: %v", obj.GetName(), err)
+			return app.ErrClusterOperationFailed
+		}
+		desired[inventoryKey(gvk.Kind, obj.GetName())] = struct{}{}
+	}
+
+	orphans, err := orphanedObjects(ctx, cli, namespace, appID, appSlug, desired)
+	if err != nil {
+		return err
+	}
+	for i := range orphans {
+		obj := &orphans[i]
+		if obj.GetKind() == "PersistentVolumeClaim" && !pruneVolumes {
+			continue
+		}
+		logging.Infof(ctx, "pruning orphaned %s %s/%s of app %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), appID)
+		if err := DeleteResource(ctx, cli, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orphanedObjects returns the objects owned by the app whose kind and name
+// are not in desired. Claims created from the claim templates of the app's
+// StatefulSet are never rendered, they are left out.
+func orphanedObjects(ctx context.Context, cli client.Client, namespace, appID, appSlug string, desired map[string]struct{}) ([]unstructured.Unstructured, app.Error) {
+	owned, err := listOwnedObjects(ctx, cli, namespace, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []unstructured.Unstructured
+	for _, obj := range owned {
+		if _, ok := desired[inventoryKey(obj.GetKind(), obj.GetName())]; ok {
+			continue
+		}
+		if obj.GetKind() == "PersistentVolumeClaim" && createdFromClaimTemplate(&obj, appSlug) {
+			continue
+		}
+		result = append(result, obj)
+	}
+	return result, nil
+}
+
+// createdFromClaimTemplate reports whether the claim holds the data of a
+// replica of the StatefulSet, created from a claim template and named
+// <template>-<statefulset>-<ordinal>. Templates of earlier releases carried
+// the app ID label, so such claims are listed as owned by the app.
+func createdFromClaimTemplate(obj *unstructured.Unstructured, statefulSet string) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "StatefulSet" {
+			return true
+		}
+	}
+
+	prefix, ordinal, ok := cutLast(obj.GetName(), "-")
+	if !ok || ordinal == "" || strings.Trim(ordinal, "0123456789") != "" {
+		return false
+	}
+	return strings.HasSuffix(prefix, "-"+statefulSet)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func listOwnedObjects(ctx context.Context, cli client.Client, namespace, appID string) ([]unstructured.Unstructured, app.Error) {
+	var result []unstructured.Unstructured
+	for _, gvk := range ownedResourceKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := cli.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{"ketches.cn/id": appID}); err != nil {
+			if meta.IsNoMatchError(err) {
+				// CRDs like Gateway API may not be installed in the cluster
+				continue
+			}
+			logging.Errorf(ctx, "failed to list %s owned by app %s: %v", gvk.Kind, appID, err)
+			return nil, app.ErrClusterOperationFailed
+		}
+		for _, item := range list.Items {
+			item.SetGroupVersionKind(gvk)
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func inventoryKey(kind, name string) string {
+	return kind + "/" + name
+}
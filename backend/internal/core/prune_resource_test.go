@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOrphanedObjectsKeepsTemplateClaims(t *testing.T) {
+	claim := func(name string, owners ...metav1.OwnerReference) client.Object {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "env",
+				Labels:          map[string]string{"ketches.cn/id": "app-id"},
+				OwnerReferences: owners,
+			},
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		claim("web-volume-data"),
+		claim("web-volume-removed"),
+		// Created from a template of an earlier release, labeled with the app ID
+		claim("web-volume-data-web-0"),
+		claim("data-web-12"),
+		claim("cache-web-1", metav1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web", UID: "uid"}),
+		claim("data-webapp-0"),
+	).Build()
+
+	desired := map[string]struct{}{
+		inventoryKey("PersistentVolumeClaim", "web-volume-data"): {},
+	}
+	orphans, err := orphanedObjects(context.Background(), cli, "env", "app-id", "web", desired)
+	if err != nil {
+		t.Fatalf("orphanedObjects() error = %v", err.Message())
+	}
+
+	var got []string
+	for _, obj := range orphans {
+		got = append(got, obj.GetName())
+	}
+	want := []string{"data-webapp-0", "web-volume-removed"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orphanedObjects() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RegistryCredentialSecretName is the name of the pull secret synced into env
+// namespaces for a project registry credential.
+func RegistryCredentialSecretName(credentialID string) string {
+	return "ketches-registry-" + credentialID
+}
+
+func appRegistrySecretName(appSlug string) string {
+	return appSlug + "-registry"
+}
+
+// RegistryCredentialSecret renders the dockerconfigjson Secret of a project
+// registry credential in the namespace.
+func RegistryCredentialSecret(ctx context.Context, namespace string, credential *entities.ProjectRegistryCredential) (*corev1.Secret, app.Error) {
+	data, err := registry.DockerConfigJSON(credential.Host, credential.Username, credential.Token)
+	if err != nil {
+		logging.Errorf(ctx, "failed to render docker config of registry credential %s: %v", credential.ID, err)
+		return nil, app.NewError(http.StatusInternalServerError, "Failed to render registry credential")
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RegistryCredentialSecretName(credential.ID),
+			Namespace: namespace,
+			Labels: map[string]string{
+				"ketches.cn/owned":              "true",
+				"ketches.cn/projectID":          credential.ProjectID,
+				"ketches.cn/registryCredential": credential.ID,
+			},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		},
+	}, nil
+}
+
+// SyncRegistryCredentialSecrets applies the pull secrets of the credentials
+// into the namespace.
+func SyncRegistryCredentialSecrets(ctx context.Context, cli client.Client, namespace string, credentials []*entities.ProjectRegistryCredential) app.Error {
+	for _, credential := range credentials {
+		secret, err := RegistryCredentialSecret(ctx, namespace, credential)
+		if err != nil {
+			return err
+		}
+		if err := ApplyResource(ctx, cli, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageHosts returns the registry hosts of the main and extra container
+// images of the app.
+func (a *AppMetadata) imageHosts() map[string]struct{} {
+	result := map[string]struct{}{
+		registry.ImageHost(a.ContainerImage): {},
+	}
+	for _, container := range a.Containers {
+		result[registry.ImageHost(container.ContainerImage)] = struct{}{}
+	}
+	return result
+}
+
+// MatchRegistryCredentials keeps the project credentials whose host serves
+// one of the app images, their pull secrets are referenced by the app pods.
+func (a *AppMetadata) MatchRegistryCredentials(credentials []*entities.ProjectRegistryCredential) {
+	hosts := a.imageHosts()
+	a.RegistryCredentialIDs = nil
+	for _, credential := range credentials {
+		if _, ok := hosts[registry.NormalizeHost(credential.Host)]; ok {
+			a.RegistryCredentialIDs = append(a.RegistryCredentialIDs, credential.ID)
+		}
+	}
+}
+
+// registrySecretManifests renders the pull secret of the app's own registry
+// login, if any.
+func (a *AppMetadata) registrySecretManifests(ctx context.Context) ([]client.Object, app.Error) {
+	if a.RegistryUsername == "" {
+		return nil, nil
+	}
+
+	data, err := registry.DockerConfigJSON(registry.ImageHost(a.ContainerImage), a.RegistryUsername, a.RegistryPassword)
+	if err != nil {
+		logging.Errorf(ctx, "failed to render docker config of app %s: %v", a.AppID, err)
+		return nil, app.NewError(http.StatusInternalServerError, "Failed to render registry login of the app")
+	}
+
+	return []client.Object{
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      appRegistrySecretName(a.AppSlug),
+				Namespace: a.ClusterNamespace,
+				Labels:    a.standardLabels(),
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: data,
+			},
+		},
+	}, nil
+}
+
+func (a *AppMetadata) imagePullSecrets() []corev1.LocalObjectReference {
+	var result []corev1.LocalObjectReference
+	if a.RegistryUsername != "" {
+		result = append(result, corev1.LocalObjectReference{Name: appRegistrySecretName(a.AppSlug)})
+	}
+	for _, credentialID := range a.RegistryCredentialIDs {
+		result = append(result, corev1.LocalObjectReference{Name: RegistryCredentialSecretName(credentialID)})
+	}
+	return result
+}
@@ -0,0 +1,310 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VolumeSnapshotGVK is the CSI snapshot kind, handled as unstructured objects
+// so the snapshot CRDs stay an optional cluster extension.
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+type VolumeSnapshot struct {
+	Name          string    `json:"name"`
+	ClaimName     string    `json:"claimName"`
+	SnapshotClass string    `json:"snapshotClass,omitempty"`
+	Scheduled     bool      `json:"scheduled"`
+	ReadyToUse    bool      `json:"readyToUse"`
+	RestoreSize   string    `json:"restoreSize,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// AppVolumeClaimName returns the claim backing an app volume. StatefulSet pvc
+// volumes have one claim per replica, named after the claim template.
+func AppVolumeClaimName(appEntity *entities.App, volume *entities.AppVolume, replica int) (string, app.Error) {
+	switch volume.VolumeType {
+	case app.AppVolumeTypePVC:
+		if appEntity.AppType == app.AppTypeStatefulSet {
+			return fmt.Sprintf("%s-%s-%d", appVolumeName(appEntity.Slug, volume.Slug), appEntity.Slug, replica), nil
+		}
+		return appVolumeName(appEntity.Slug, volume.Slug), nil
+	case app.AppVolumeTypeExistingClaim:
+		return volume.ClaimName, nil
+	case app.AppVolumeTypeShared:
+		return SharedVolumeClaimName(volume.ClaimName), nil
+	default:
+		return "", app.NewError(http.StatusBadRequest, "Snapshots are only supported by claimed volumes, not "+volume.VolumeType)
+	}
+}
+
+func volumeSnapshotLabels(appEntity *entities.App, volume *entities.AppVolume, scheduled bool) map[string]string {
+	return map[string]string{
+		"ketches.cn/owned":     "true",
+		"ketches.cn/app":       appEntity.Slug,
+		"ketches.cn/appID":     appEntity.ID,
+		"ketches.cn/volumeID":  volume.ID,
+		"ketches.cn/scheduled": fmt.Sprint(scheduled),
+	}
+}
+
+// CreateVolumeSnapshot snapshots the claim of an app volume.
+func CreateVolumeSnapshot(ctx context.Context, cli client.Client, appEntity *entities.App, volume *entities.AppVolume, claimName, snapshotClass string, scheduled bool) (*VolumeSnapshot, app.Error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetNamespace(appEntity.ClusterNamespace)
+	// The random suffix keeps snapshots of the same second apart
+	snapshot.SetName(fmt.Sprintf("%s-%s-%s", claimName, time.Now().Format("20060102-150405"), utilrand.String(5)))
+	snapshot.SetLabels(volumeSnapshotLabels(appEntity, volume, scheduled))
+	if err := unstructured.SetNestedField(snapshot.Object, claimName, "spec", "source", "persistentVolumeClaimName"); err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "Failed to render volume snapshot")
+	}
+	if snapshotClass != "" {
+		if err := unstructured.SetNestedField(snapshot.Object, snapshotClass, "spec", "volumeSnapshotClassName"); err != nil {
+			return nil, app.NewError(http.StatusInternalServerError, "Failed to render volume snapshot")
+		}
+	}
+
+	if err := cli.Create(ctx, snapshot); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil, app.NewError(http.StatusConflict, "Volume snapshot already exists")
+		}
+		logging.Errorf(ctx, "failed to create volume snapshot %s/%s: %v", snapshot.GetNamespace(), snapshot.GetName(), err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	return volumeSnapshotFromUnstructured(snapshot), nil
+}
+
+// ListVolumeSnapshots lists the snapshots of an app volume, newest first.
+func ListVolumeSnapshots(ctx context.Context, cli client.Client, appEntity *entities.App, volume *entities.AppVolume) ([]*VolumeSnapshot, app.Error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(VolumeSnapshotGVK.GroupVersion().WithKind(VolumeSnapshotGVK.Kind + "List"))
+	if err := cli.List(ctx, list, client.InNamespace(appEntity.ClusterNamespace), client.MatchingLabels{
+		"ketches.cn/appID":    appEntity.ID,
+		"ketches.cn/volumeID": volume.ID,
+	}); err != nil {
+		logging.Errorf(ctx, "failed to list volume snapshots of volume %s: %v", volume.ID, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	result := make([]*VolumeSnapshot, 0, len(list.Items))
+	for i := range list.Items {
+		result = append(result, volumeSnapshotFromUnstructured(&list.Items[i]))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// GetVolumeSnapshot gets a snapshot of an app volume by name.
+func GetVolumeSnapshot(ctx context.Context, cli client.Client, appEntity *entities.App, volume *entities.AppVolume, name string) (*VolumeSnapshot, app.Error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: appEntity.ClusterNamespace, Name: name}, snapshot); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, app.NewError(http.StatusNotFound, "Volume snapshot not found")
+		}
+		logging.Errorf(ctx, "failed to get volume snapshot %s/%s: %v", appEntity.ClusterNamespace, name, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+	if snapshot.GetLabels()["ketches.cn/volumeID"] != volume.ID {
+		return nil, app.NewError(http.StatusNotFound, "Volume snapshot not found")
+	}
+
+	return volumeSnapshotFromUnstructured(snapshot), nil
+}
+
+// DeleteVolumeSnapshot deletes a snapshot in the app namespace.
+func DeleteVolumeSnapshot(ctx context.Context, cli client.Client, namespace, name string) app.Error {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetName(name)
+	return DeleteResource(ctx, cli, snapshot)
+}
+
+// RestoreVolumeSnapshot creates the claim named claimName from the snapshot,
+// with the storage settings of the app volume. With replace set the existing
+// claim is deleted first, which is only allowed for the claims the app owns
+// and refused while a pod still mounts the claim.
+func RestoreVolumeSnapshot(ctx context.Context, cli client.Client, appEntity *entities.App, volume *entities.AppVolume, snapshot *VolumeSnapshot, claimName string, replace bool) app.Error {
+	if !snapshot.ReadyToUse {
+		return app.NewError(http.StatusConflict, "Volume snapshot is not ready to use")
+	}
+	if replace && volume.VolumeType != app.AppVolumeTypePVC {
+		return app.NewError(http.StatusBadRequest, "In-place restore is only supported by pvc volumes, restore "+volume.VolumeType+" volumes to a new claim")
+	}
+
+	namespace := appEntity.ClusterNamespace
+	if replace {
+		podName, err := claimMountedBy(ctx, cli, namespace, claimName)
+		if err != nil {
+			return err
+		}
+		if podName != "" {
+			return app.NewError(http.StatusConflict, "Volume claim "+claimName+" is still mounted by pod "+podName)
+		}
+	}
+	source := &corev1.PersistentVolumeClaim{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: snapshot.ClaimName}, source); err != nil && !k8serrors.IsNotFound(err) {
+		logging.Errorf(ctx, "failed to get source claim %s/%s: %v", namespace, snapshot.ClaimName, err)
+		return app.ErrClusterOperationFailed
+	}
+
+	labels := map[string]string{
+		"ketches.cn/owned":            "true",
+		"ketches.cn/restored-from":    snapshot.Name,
+		"ketches.cn/restored-for-app": appEntity.Slug,
+	}
+	if replace {
+		// Keep the labels of the replaced claim so it stays owned by the app
+		labels = source.Labels
+	}
+
+	storage := resource.MustParse(fmt.Sprintf("%dMi", volume.Capacity))
+	if snapshot.RestoreSize != "" {
+		if size, err := resource.ParseQuantity(snapshot.RestoreSize); err == nil && size.Cmp(storage) > 0 {
+			storage = size
+		}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: pvcAccessModes(splitAccessModes(volume.AccessModes)),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage,
+				},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: utils.Ptr(VolumeSnapshotGVK.Group),
+				Kind:     VolumeSnapshotGVK.Kind,
+				Name:     snapshot.Name,
+			},
+		},
+	}
+	if source.Spec.StorageClassName != nil {
+		pvc.Spec.StorageClassName = source.Spec.StorageClassName
+	} else if volume.StorageClass != "" {
+		pvc.Spec.StorageClassName = &volume.StorageClass
+	}
+	if volume.VolumeMode != "" {
+		pvc.Spec.VolumeMode = utils.Ptr(corev1.PersistentVolumeMode(volume.VolumeMode))
+	}
+
+	if replace {
+		if err := DeleteResource(ctx, cli, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: namespace},
+		}); err != nil {
+			return err
+		}
+		// The claim is only gone once its protection finalizer is released
+		if err := wait.PollUntilContextTimeout(ctx, time.Second, time.Minute, true, func(ctx context.Context) (bool, error) {
+			err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: claimName}, &corev1.PersistentVolumeClaim{})
+			if k8serrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}); err != nil {
+			logging.Errorf(ctx, "failed to wait for claim %s/%s deletion: %v", namespace, claimName, err)
+			return app.NewError(http.StatusConflict, "Volume claim is still in use, make sure the app is stopped")
+		}
+	}
+
+	if err := cli.Create(ctx, pvc); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return app.NewError(http.StatusConflict, "Volume claim "+claimName+" already exists")
+		}
+		logging.Errorf(ctx, "failed to restore claim %s/%s from snapshot %s: %v", namespace, claimName, snapshot.Name, err)
+		return app.ErrClusterOperationFailed
+	}
+	return nil
+}
+
+// claimMountedBy returns the name of a pod in the namespace mounting the claim,
+// empty if there is none.
+func claimMountedBy(ctx context.Context, cli client.Client, namespace, claimName string) (string, app.Error) {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		logging.Errorf(ctx, "failed to list pods in namespace %s: %v", namespace, err)
+		return "", app.ErrClusterOperationFailed
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claimName {
+				return pod.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// PruneVolumeSnapshots deletes the oldest scheduled snapshots of the volume
+// beyond the retention count, manual snapshots are kept.
+func PruneVolumeSnapshots(ctx context.Context, cli client.Client, appEntity *entities.App, volume *entities.AppVolume, retention int) app.Error {
+	snapshots, err := ListVolumeSnapshots(ctx, cli, appEntity, volume)
+	if err != nil {
+		return err
+	}
+
+	// snapshots are sorted newest first, one snapshot list per claim
+	kept := make(map[string]int)
+	for _, snapshot := range snapshots {
+		if !snapshot.Scheduled {
+			continue
+		}
+		kept[snapshot.ClaimName]++
+		if kept[snapshot.ClaimName] <= retention {
+			continue
+		}
+		if err := DeleteVolumeSnapshot(ctx, cli, appEntity.ClusterNamespace, snapshot.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func volumeSnapshotFromUnstructured(u *unstructured.Unstructured) *VolumeSnapshot {
+	result := &VolumeSnapshot{
+		Name:      u.GetName(),
+		Scheduled: u.GetLabels()["ketches.cn/scheduled"] == "true",
+		CreatedAt: u.GetCreationTimestamp().Time,
+	}
+	result.ClaimName, _, _ = unstructured.NestedString(u.Object, "spec", "source", "persistentVolumeClaimName")
+	result.SnapshotClass, _, _ = unstructured.NestedString(u.Object, "spec", "volumeSnapshotClassName")
+	result.ReadyToUse, _, _ = unstructured.NestedBool(u.Object, "status", "readyToUse")
+	result.RestoreSize, _, _ = unstructured.NestedString(u.Object, "status", "restoreSize")
+	result.Error, _, _ = unstructured.NestedString(u.Object, "status", "error", "message")
+	return result
+}
@@ -5,12 +5,15 @@ package db
 // "go-apiserver-template/pkg/log"
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strings"
 	"sync"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/metrics"
+	"github.com/ketches/ketches/internal/tracing"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
@@ -66,14 +69,27 @@ func Instance() *gorm.DB {
 		if err != nil {
 			log.Fatalf("error connecting to database, %v", err)
 		}
+		if err := instance.Use(metrics.GormPlugin{}); err != nil {
+			log.Fatalf("error registering database metrics, %v", err)
+		}
+		if err := instance.Use(tracing.GormPlugin{}); err != nil {
+			log.Fatalf("error registering database tracing, %v", err)
+		}
 
 		Migrate(instance)
 	})
 	return instance
 }
 
-func Transaction(fn func(tx *gorm.DB) error) error {
-	return Instance().Transaction(fn)
+// WithContext returns the database bound to ctx, so statements are traced
+// within the request. Statements are not canceled with ctx, work started by a
+// request still completes once the client is gone.
+func WithContext(ctx context.Context) *gorm.DB {
+	return Instance().WithContext(context.WithoutCancel(ctx))
+}
+
+func Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return WithContext(ctx).Transaction(fn)
 }
 
 func IsErrRecordNotFound(err error) bool {
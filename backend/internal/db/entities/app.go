@@ -36,15 +36,22 @@ type AppEnvVar struct {
 
 type AppVolume struct {
 	UUIDBase
-	AppID        string `json:"appID" gorm:"not null;uniqueIndex:idx_appID_slug;uniqueIndex:idx_appID_mountPath_subPath;size:36"` // App UUID this volume belongs to
-	Slug         string `json:"slug" gorm:"not null;uniqueIndex:idx_appID_slug;size:64"`                                          // Volume slug
-	MountPath    string `json:"mountPath" gorm:"not null;uniqueIndex:idx_appID_mountPath_subPath;size:255"`                       // Mount path in container
-	SubPath      string `json:"subPath" gorm:"uniqueIndex:idx_appID_mountPath_subPath;size:255"`                                  // Optional subPath for the volume
-	VolumeMode   string `json:"volumeMode" gorm:"not null;size:16;default:Filesystem"`                                            // Volume mode (e.g. Filesystem, Block)
-	Capacity     int    `json:"capacity" gorm:"not null"`                                                                         // Capacity, in MiB
-	VolumeType   string `json:"volumeType" gorm:"not null;size:32"`                                                               // Type (e.g. emptyDir, pvc, hostPath)
-	AccessModes  string `json:"accessModes" gorm:"not null;size:255"`                                                             // Access modes, semicolon separated (e.g. "ReadWriteOnce;ReadOnlyMany")
-	StorageClass string `json:"storageClass" gorm:"size:64"`                                                                      // StorageClass for PVC
+	AppID          string `json:"appID" gorm:"not null;uniqueIndex:idx_appID_slug;uniqueIndex:idx_appID_mountPath_subPath;size:36"` // App UUID this volume belongs to
+	Slug           string `json:"slug" gorm:"not null;uniqueIndex:idx_appID_slug;size:64"`                                          // Volume slug
+	MountPath      string `json:"mountPath" gorm:"not null;uniqueIndex:idx_appID_mountPath_subPath;size:255"`                       // Mount path in container
+	SubPath        string `json:"subPath" gorm:"uniqueIndex:idx_appID_mountPath_subPath;size:255"`                                  // Optional subPath for the volume
+	VolumeMode     string `json:"volumeMode" gorm:"not null;size:16;default:Filesystem"`                                            // Volume mode (e.g. Filesystem, Block)
+	Capacity       int    `json:"capacity" gorm:"not null"`                                                                         // Capacity, in MiB
+	VolumeType     string `json:"volumeType" gorm:"not null;size:32"`                                                               // Type (e.g. emptyDir, pvc, hostPath, nfs, existingClaim, shared)
+	AccessModes    string `json:"accessModes" gorm:"not null;size:255"`                                                             // Access modes, semicolon separated (e.g. "ReadWriteOnce;ReadOnlyMany")
+	StorageClass   string `json:"storageClass" gorm:"size:64"`                                                                      // StorageClass for PVC
+	ReadOnly       bool   `json:"readOnly" gorm:"not null;default:false"`                                                           // Mount the volume read-only
+	EmptyDirMedium string `json:"emptyDirMedium" gorm:"size:16"`                                                                    // emptyDir medium, "" for node disk or "Memory" for tmpfs
+	HostPath       string `json:"hostPath" gorm:"size:255"`                                                                         // hostPath path on the node
+	HostPathType   string `json:"hostPathType" gorm:"size:32"`                                                                      // hostPath type (e.g. Directory, DirectoryOrCreate)
+	NFSServer      string `json:"nfsServer" gorm:"size:255"`                                                                        // NFS server address
+	NFSPath        string `json:"nfsPath" gorm:"size:255"`                                                                          // Exported path on the NFS server
+	ClaimName      string `json:"claimName" gorm:"size:255"`                                                                        // Pre-existing claim name, or shared volume name of the project
 	AuditBase
 }
 
@@ -57,7 +64,9 @@ type AppGateway struct {
 	Path        string `json:"path" gorm:"not null;uniqueIndex:idx_appID_domain_path;size:255"`
 	CertID      string `json:"certID" gorm:"size:36"`
 	GatewayPort int32  `json:"gatewayPort" gorm:"not null;uniqueIndex:idx_appID_gatewayPort;default:80"` // Port on the gateway to expose this app
+	PortName    string `json:"portName" gorm:"size:15"`                                                  // Name of the app port routed to, Port follows its service port
 	Exposed     bool   `json:"exposed" gorm:"not null;default:false"`
+	Rules       string `json:"rules" gorm:"type:text"`                  // JSON encoded HTTP route rules, http and https gateways only
 	EnvID       string `json:"envID" gorm:"not null;index;size:36"`     // Env UUID this gateway belongs to
 	ProjectID   string `json:"projectID" gorm:"not null;index;size:36"` // Project UUID this gateway belongs to
 	AuditBase
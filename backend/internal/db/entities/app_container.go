@@ -0,0 +1,20 @@
+package entities
+
+// AppContainer is a sidecar or init container running in the app's pods
+// beside the main container.
+type AppContainer struct {
+	UUIDBase
+	AppID            string `json:"appID" gorm:"not null;uniqueIndex:idx_appID_slug;index;size:36"`
+	Slug             string `json:"slug" gorm:"not null;uniqueIndex:idx_appID_slug;size:36"` // Container name in the pod
+	ContainerType    string `json:"containerType" gorm:"not null;size:16"`                   // sidecar, init
+	ContainerImage   string `json:"containerImage" gorm:"not null;size:255"`
+	ContainerCommand string `json:"containerCommand" gorm:"type:text"`
+	RequestCPU       int32  `json:"requestCPU" gorm:"not null;default:0"`    // in milliCPU, 0 means not set
+	RequestMemory    int32  `json:"requestMemory" gorm:"not null;default:0"` // in MiB, 0 means not set
+	LimitCPU         int32  `json:"limitCPU" gorm:"not null;default:0"`      // in milliCPU, 0 means not set
+	LimitMemory      int32  `json:"limitMemory" gorm:"not null;default:0"`   // in MiB, 0 means not set
+	EnvVars          string `json:"envVars" gorm:"type:text"`                // JSON encoded list of key/value pairs
+	VolumeMounts     string `json:"volumeMounts" gorm:"type:text"`           // JSON encoded list of mounts of app volumes or config files
+	SortOrder        int    `json:"sortOrder" gorm:"not null;default:0"`     // Start order of init containers
+	AuditBase
+}
@@ -0,0 +1,9 @@
+package entities
+
+type AppDependency struct {
+	UUIDBase
+	AppID          string `json:"appID" gorm:"not null;uniqueIndex:idx_appID_dependsOnAppID;size:36"`
+	DependsOnAppID string `json:"dependsOnAppID" gorm:"not null;uniqueIndex:idx_appID_dependsOnAppID;index;size:36"`
+	EnvPrefix      string `json:"envPrefix" gorm:"size:64"` // Prefix of the injected env vars, derived from the app slug when empty
+	AuditBase
+}
@@ -0,0 +1,9 @@
+package entities
+
+type AppNetworkPolicy struct {
+	UUIDBase
+	AppID         string `json:"appID" gorm:"not null;uniqueIndex;size:36"`
+	AllowGateway  bool   `json:"allowGateway" gorm:"not null;default:false"` // Admit traffic from the gateway data plane
+	AllowFromApps string `json:"allowFromApps" gorm:"type:text"`             // Comma separated IDs of apps in the same env admitted
+	AuditBase
+}
@@ -0,0 +1,12 @@
+package entities
+
+type AppPort struct {
+	UUIDBase
+	AppID         string `json:"appID" gorm:"not null;uniqueIndex:idx_appID_name;uniqueIndex:idx_appID_servicePort_protocol;size:36"`
+	Name          string `json:"name" gorm:"not null;uniqueIndex:idx_appID_name;size:15"` // IANA service name, referenced by gateways
+	ContainerPort int32  `json:"containerPort" gorm:"not null"`
+	Protocol      string `json:"protocol" gorm:"not null;uniqueIndex:idx_appID_servicePort_protocol;size:8;default:TCP"` // TCP, UDP or SCTP
+	ServicePort   int32  `json:"servicePort" gorm:"not null;uniqueIndex:idx_appID_servicePort_protocol"`
+	AppProtocol   string `json:"appProtocol" gorm:"size:64"` // Application protocol of the port, e.g. http, h2c or kubernetes.io/ws
+	AuditBase
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// AppRelease is the canary or blue-green release of an app edition. The new
+// edition runs in a workload of its own beside the stable one until the
+// release is promoted or aborted, an app keeps its latest release only.
+type AppRelease struct {
+	UUIDBase
+	AppID         string     `json:"appID" gorm:"not null;uniqueIndex;size:36"`
+	ReleaseType   string     `json:"releaseType" gorm:"not null;size:16"` // canary, blueGreen
+	StableEdition string     `json:"stableEdition" gorm:"not null;size:64"`
+	CanaryEdition string     `json:"canaryEdition" gorm:"not null;size:64"`
+	Weight        int32      `json:"weight" gorm:"not null;default:0"` // Percentage of gateway traffic routed to the canary edition
+	Step          int        `json:"step" gorm:"not null;default:0"`   // Index of the current canary step, -1 before the first
+	State         string     `json:"state" gorm:"not null;size:16"`    // progressing, paused, switched, promoted, aborted
+	StepStartedAt time.Time  `json:"stepStartedAt"`
+	HealthySince  *time.Time `json:"healthySince"` // Since when the canary pods are all ready and none abnormal, nil if they aren't
+	Message       string     `json:"message" gorm:"size:255"`
+	CheckedAt     *time.Time `json:"checkedAt"` // When the release scheduler last ran the release, claimed by one api server replica per run
+	AuditBase
+}
@@ -0,0 +1,16 @@
+package entities
+
+type AppRolloutStrategy struct {
+	UUIDBase
+	AppID                   string `json:"appID" gorm:"not null;uniqueIndex;size:36"`
+	StrategyType            string `json:"strategyType" gorm:"not null;size:32"` // RollingUpdate, Recreate (Deployment only), OnDelete (StatefulSet only)
+	MaxSurge                string `json:"maxSurge" gorm:"size:16"`              // Absolute number or percentage, Deployment only
+	MaxUnavailable          string `json:"maxUnavailable" gorm:"size:16"`        // Absolute number or percentage
+	Partition               int32  `json:"partition" gorm:"not null;default:0"`  // StatefulSet only
+	MinReadySeconds         int32  `json:"minReadySeconds" gorm:"not null;default:0"`
+	ProgressDeadlineSeconds int32  `json:"progressDeadlineSeconds" gorm:"not null;default:0"` // Deployment only, 0 means the Kubernetes default
+	ReleaseType             string `json:"releaseType" gorm:"size:16"`                        // canary, blueGreen, Deployment only, empty to update in place
+	CanarySteps             string `json:"canarySteps" gorm:"size:64"`                        // Comma separated traffic percentages of canary steps
+	HealthyPeriodSeconds    int32  `json:"healthyPeriodSeconds" gorm:"not null;default:0"`    // How long the new edition must be healthy before traffic shifts further
+	AuditBase
+}
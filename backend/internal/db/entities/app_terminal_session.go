@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// AppTerminalSession is an audit record of a web terminal session opened on
+// an app instance. RecordingFile is empty when the session was not recorded.
+type AppTerminalSession struct {
+	UUIDBase
+	AppID         string     `json:"appID" gorm:"not null;index;size:36"`
+	InstanceName  string     `json:"instanceName" gorm:"not null;size:253"`
+	ContainerName string     `json:"containerName" gorm:"not null;size:63"`
+	Command       string     `json:"command" gorm:"size:255"`
+	UserID        string     `json:"userID" gorm:"not null;index;size:36"`
+	StartedAt     time.Time  `json:"startedAt" gorm:"column:started_at"`
+	EndedAt       *time.Time `json:"endedAt" gorm:"column:ended_at"`
+	RecordingFile string     `json:"recordingFile" gorm:"size:255"` // Asciicast file name in the recording directory
+	AuditBase
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// AppVolumeSnapshotPolicy takes scheduled snapshots of an app volume and
+// keeps the latest Retention of them.
+type AppVolumeSnapshotPolicy struct {
+	UUIDBase
+	AppID           string     `json:"appID" gorm:"not null;index;size:36"`
+	VolumeID        string     `json:"volumeID" gorm:"not null;uniqueIndex;size:36"`
+	SnapshotClass   string     `json:"snapshotClass" gorm:"size:64"`    // VolumeSnapshotClass, empty for the cluster default
+	IntervalMinutes int        `json:"intervalMinutes" gorm:"not null"` // Minutes between two scheduled snapshots
+	Retention       int        `json:"retention" gorm:"not null"`       // Number of scheduled snapshots kept per claim
+	Enabled         bool       `json:"enabled" gorm:"not null"`         // Whether scheduled snapshots are taken
+	LastSnapshotAt  *time.Time `json:"lastSnapshotAt" gorm:"column:last_snapshot_at"`
+	AuditBase
+}
@@ -0,0 +1,13 @@
+package entities
+
+// ProjectRegistryCredential is a private image registry login shared by the
+// apps of a project, synced as a dockerconfigjson Secret into every env
+// namespace of the project.
+type ProjectRegistryCredential struct {
+	UUIDBase
+	ProjectID string `json:"projectID" gorm:"not null;uniqueIndex:idx_projectID_host;index;size:36"`
+	Host      string `json:"host" gorm:"not null;uniqueIndex:idx_projectID_host;size:255"` // Registry host, e.g., 'ghcr.io', 'registry.example.com:5000'
+	Username  string `json:"username" gorm:"not null;size:64"`
+	Token     string `json:"token" gorm:"not null;size:1024"` // Password or access token
+	AuditBase
+}
@@ -15,6 +15,11 @@ import (
 // Append new database entities here, database migrations
 // will be handled on the application startup.
 func Migrate(db *gorm.DB) {
+	// App volumes of every type used to be rendered as claims, rows from
+	// before volume types were rendered are recognised by the missing
+	// columns of the newer types
+	legacyAppVolumes := db.Migrator().HasTable(&entities.AppVolume{}) && !db.Migrator().HasColumn(&entities.AppVolume{}, "ClaimName")
+
 	if err := db.AutoMigrate(
 		&entities.User{},
 		&entities.UserToken{},
@@ -22,21 +27,42 @@ func Migrate(db *gorm.DB) {
 		&entities.Cert{},
 		&entities.Project{},
 		&entities.ProjectMember{},
+		&entities.ProjectRegistryCredential{},
 		&entities.Env{},
 		&entities.App{},
 		&entities.AppEnvVar{},
 		&entities.AppGateway{},
+		&entities.AppPort{},
 		&entities.AppVolume{},
 		&entities.AppConfigFile{},
 		&entities.AppProbe{},
 		&entities.AppSchedulingRule{},
+		&entities.AppContainer{},
+		&entities.AppRolloutStrategy{},
+		&entities.AppNetworkPolicy{},
+		&entities.AppDependency{},
+		&entities.AppRelease{},
+		&entities.AppVolumeSnapshotPolicy{},
+		&entities.AppTerminalSession{},
 	); err != nil {
 		log.Fatalf("failed to migrate database, %v", err)
 	}
 
+	if legacyAppVolumes {
+		migrateLegacyAppVolumes(db)
+	}
+
 	checkOrInitAdminUser(db)
 }
 
+// migrateLegacyAppVolumes keeps app volumes created before volume types were
+// rendered on the claims that hold their data.
+func migrateLegacyAppVolumes(db *gorm.DB) {
+	if err := db.Model(&entities.AppVolume{}).Where("volume_type <> ?", app.AppVolumeTypePVC).Update("volume_type", app.AppVolumeTypePVC).Error; err != nil {
+		log.Fatalf("failed to migrate legacy app volumes: %v", err)
+	}
+}
+
 func checkOrInitAdminUser(db *gorm.DB) {
 	var count int64
 	if err := db.Model(&entities.User{}).Where("role = ?", app.UserRoleAdmin).Count(&count).Error; err != nil {
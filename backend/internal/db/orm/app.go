@@ -2,7 +2,6 @@ package orm
 
 import (
 	"context"
-	"log"
 	"net/http"
 	"time"
 
@@ -10,13 +9,14 @@ import (
 	"github.com/ketches/ketches/internal/app"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/spf13/cast"
 )
 
 func GetAppByID(ctx context.Context, appID string) (*entities.App, app.Error) {
 	result := &entities.App{}
-	if err := db.Instance().First(result, "id = ?", appID).Error; err != nil {
+	if err := db.WithContext(ctx).First(result, "id = ?", appID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "App not found")
 		}
@@ -28,8 +28,8 @@ func GetAppByID(ctx context.Context, appID string) (*entities.App, app.Error) {
 
 func GetProjectIDByAppID(ctx context.Context, appID string) (string, app.Error) {
 	entity := &entities.App{}
-	if err := db.Instance().Select("project_id").First(entity, "id = ?", appID).Error; err != nil {
-		log.Printf("failed to get project ID for app %s: %v", appID, err)
+	if err := db.WithContext(ctx).Select("project_id").First(entity, "id = ?", appID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get project ID for app %s: %v", appID, err)
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "App not found")
 		}
@@ -41,8 +41,8 @@ func GetProjectIDByAppID(ctx context.Context, appID string) (string, app.Error)
 
 func GetEditionByAppID(ctx context.Context, appID string) (string, app.Error) {
 	entity := &entities.App{}
-	if err := db.Instance().Select("edition").First(entity, "id = ?", appID).Error; err != nil {
-		log.Printf("failed to get edition for app %s: %v", appID, err)
+	if err := db.WithContext(ctx).Select("edition").First(entity, "id = ?", appID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get edition for app %s: %v", appID, err)
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "App not found")
 		}
@@ -59,8 +59,8 @@ func GetProjectRoleByAppID(ctx context.Context, appID string) (string, app.Error
 	}
 
 	entity := &models.ProjectMemberRole{}
-	if err := db.Instance().Model(&entities.App{}).Joins("JOIN project_members ON project_members.project_id = apps.project_id").Select("project_members.project_role").First(entity, "apps.id = ? AND project_members.user_id = ?", appID, userID).Error; err != nil {
-		log.Printf("failed to get project role for app %s: %v", appID, err)
+	if err := db.WithContext(ctx).Model(&entities.App{}).Joins("JOIN project_members ON project_members.project_id = apps.project_id").Select("project_members.project_role").First(entity, "apps.id = ? AND project_members.user_id = ?", appID, userID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get project role for app %s: %v", appID, err)
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "App not found")
 		}
@@ -78,7 +78,7 @@ func GetProjectRoleByAppID(ctx context.Context, appID string) (string, app.Error
 // Returns new edition as a string or an error if the operation fails.
 func UpdateAppEdition(ctx context.Context, appID string) (string, app.Error) {
 	newEdition := cast.ToString(time.Now().UnixMilli())
-	if err := db.Instance().Updates(&entities.App{
+	if err := db.WithContext(ctx).Updates(&entities.App{
 		UUIDBase: entities.UUIDBase{
 			ID: appID,
 		},
@@ -87,7 +87,7 @@ func UpdateAppEdition(ctx context.Context, appID string) (string, app.Error) {
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app edition: %v", err)
+		logging.Errorf(ctx, "failed to update app edition: %v", err)
 		return "", app.ErrDatabaseOperationFailed
 	}
 
@@ -121,6 +121,15 @@ func AllAppGateways(appID string) ([]*entities.AppGateway, app.Error) {
 	return result, nil
 }
 
+func AllAppPorts(ctx context.Context, appID string) ([]*entities.AppPort, app.Error) {
+	var result []*entities.AppPort
+	if err := db.WithContext(ctx).Order("service_port, protocol").Find(&result, "app_id = ?", appID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app ports for app %s: %v", appID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	return result, nil
+}
+
 func AllAppProbes(appID string) ([]*entities.AppProbe, app.Error) {
 	var result []*entities.AppProbe
 	if err := db.Instance().Find(&result, "app_id = ?", appID).Error; err != nil {
@@ -132,8 +141,8 @@ func AllAppProbes(appID string) ([]*entities.AppProbe, app.Error) {
 
 func GetAppSchedulingRule(ctx context.Context, appID string) (*entities.AppSchedulingRule, app.Error) {
 	entity := &entities.AppSchedulingRule{}
-	if err := db.Instance().First(entity, "app_id = ?", appID).Error; err != nil {
-		log.Printf("failed to get app scheduling rule for app %s: %v", appID, err)
+	if err := db.WithContext(ctx).First(entity, "app_id = ?", appID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app scheduling rule for app %s: %v", appID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, nil
 		}
@@ -143,11 +152,144 @@ func GetAppSchedulingRule(ctx context.Context, appID string) (*entities.AppSched
 	return entity, nil
 }
 
-func AllAppConfigFiles(appID string) ([]*entities.AppConfigFile, app.Error) {
+func GetAppRolloutStrategy(ctx context.Context, appID string) (*entities.AppRolloutStrategy, app.Error) {
+	entity := &entities.AppRolloutStrategy{}
+	if err := db.WithContext(ctx).First(entity, "app_id = ?", appID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, nil
+		}
+		logging.Errorf(ctx, "failed to get app rollout strategy for app %s: %v", appID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return entity, nil
+}
+
+func GetAppRelease(ctx context.Context, appID string) (*entities.AppRelease, app.Error) {
+	entity := &entities.AppRelease{}
+	if err := db.WithContext(ctx).First(entity, "app_id = ?", appID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, nil
+		}
+		logging.Errorf(ctx, "failed to get app release for app %s: %v", appID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return entity, nil
+}
+
+// GetActiveAppRelease returns the release of the app still running its
+// canary workload, nil if there is none.
+func GetActiveAppRelease(ctx context.Context, appID string) (*entities.AppRelease, app.Error) {
+	entity, err := GetAppRelease(ctx, appID)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+	switch entity.State {
+	case app.ReleaseStateProgressing, app.ReleaseStatePaused, app.ReleaseStateSwitched, app.ReleaseStatePromoting:
+		return entity, nil
+	}
+	return nil, nil
+}
+
+func GetAppNetworkPolicy(ctx context.Context, appID string) (*entities.AppNetworkPolicy, app.Error) {
+	entity := &entities.AppNetworkPolicy{}
+	if err := db.WithContext(ctx).First(entity, "app_id = ?", appID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, nil
+		}
+		logging.Errorf(ctx, "failed to get app network policy for app %s: %v", appID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return entity, nil
+}
+
+// GetEnvAppSlugs returns the slugs of the apps with the given IDs in the env,
+// keyed by app ID. Apps of other envs are left out.
+func GetEnvAppSlugs(ctx context.Context, envID string, appIDs []string) (map[string]string, app.Error) {
+	result := make(map[string]string, len(appIDs))
+	if len(appIDs) == 0 {
+		return result, nil
+	}
+
+	var apps []*entities.App
+	if err := db.WithContext(ctx).Select("id", "slug").Where("env_id = ? AND id IN ?", envID, appIDs).Find(&apps).Error; err != nil {
+		logging.Errorf(ctx, "failed to get slugs of apps in env %s: %v", envID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	for _, a := range apps {
+		result[a.ID] = a.Slug
+	}
+
+	return result, nil
+}
+
+func AllAppDependencies(ctx context.Context, appIDs ...string) ([]*entities.AppDependency, app.Error) {
+	var result []*entities.AppDependency
+	if len(appIDs) == 0 {
+		return result, nil
+	}
+	if err := db.WithContext(ctx).Order("created_at").Find(&result, "app_id IN ?", appIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app dependencies for apps %v: %v", appIDs, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	return result, nil
+}
+
+func AllAppConfigFiles(ctx context.Context, appID string) ([]*entities.AppConfigFile, app.Error) {
 	var result []*entities.AppConfigFile
-	if err := db.Instance().Find(&result, "app_id = ?", appID).Error; err != nil {
-		log.Printf("failed to get app config files for app %s: %v", appID, err)
+	if err := db.WithContext(ctx).Find(&result, "app_id = ?", appID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app config files for app %s: %v", appID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 	return result, nil
 }
+
+func AllAppContainers(ctx context.Context, appID string) ([]*entities.AppContainer, app.Error) {
+	var result []*entities.AppContainer
+	if err := db.WithContext(ctx).Order("sort_order, created_at").Find(&result, "app_id = ?", appID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app containers for app %s: %v", appID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	return result, nil
+}
+
+func GetAppVolume(ctx context.Context, appID, volumeID string) (*entities.AppVolume, app.Error) {
+	entity := &entities.AppVolume{}
+	if err := db.WithContext(ctx).First(entity, "id = ? AND app_id = ?", volumeID, appID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, app.NewError(http.StatusNotFound, "volume not found")
+		}
+		logging.Errorf(ctx, "failed to get app volume %s: %v", volumeID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return entity, nil
+}
+
+func GetAppVolumeSnapshotPolicy(ctx context.Context, volumeID string) (*entities.AppVolumeSnapshotPolicy, app.Error) {
+	entity := &entities.AppVolumeSnapshotPolicy{}
+	if err := db.WithContext(ctx).First(entity, "volume_id = ?", volumeID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, nil
+		}
+		logging.Errorf(ctx, "failed to get snapshot policy for volume %s: %v", volumeID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return entity, nil
+}
+
+func GetAppTerminalSession(ctx context.Context, appID, sessionID string) (*entities.AppTerminalSession, app.Error) {
+	entity := &entities.AppTerminalSession{}
+	if err := db.WithContext(ctx).First(entity, "id = ? AND app_id = ?", sessionID, appID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, app.NewError(http.StatusNotFound, "terminal session not found")
+		}
+		logging.Errorf(ctx, "failed to get app terminal session %s: %v", sessionID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return entity, nil
+}
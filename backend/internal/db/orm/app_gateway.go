@@ -11,7 +11,7 @@ import (
 
 func GetAppGatewayByIDs(ctx context.Context, gatewayID string) (*entities.AppGateway, app.Error) {
 	gateway := &entities.AppGateway{}
-	if err := db.Instance().First(gateway, "id = ?", gatewayID).Error; err != nil {
+	if err := db.WithContext(ctx).First(gateway, "id = ?", gatewayID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "App gateway not found")
 		}
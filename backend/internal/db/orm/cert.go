@@ -0,0 +1,24 @@
+package orm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
+)
+
+func GetCertByID(ctx context.Context, certID string) (*entities.Cert, app.Error) {
+	cert := &entities.Cert{}
+	if err := db.WithContext(ctx).First(cert, "id = ?", certID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, app.NewError(http.StatusNotFound, "Cert not found")
+		}
+		logging.Errorf(ctx, "failed to get cert %s: %v", certID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return cert, nil
+}
@@ -11,7 +11,7 @@ import (
 
 func GetClusterByID(ctx context.Context, clusterID string) (*entities.Cluster, app.Error) {
 	cluster := &entities.Cluster{}
-	if err := db.Instance().First(cluster, "id = ?", clusterID).Error; err != nil {
+	if err := db.WithContext(ctx).First(cluster, "id = ?", clusterID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Cluster not found")
 		}
@@ -23,7 +23,7 @@ func GetClusterByID(ctx context.Context, clusterID string) (*entities.Cluster, a
 
 func GetClusterSlugByID(ctx context.Context, clusterID string) (string, app.Error) {
 	cluster := &entities.Cluster{}
-	if err := db.Instance().Select("slug").First(cluster, "id = ?", clusterID).Error; err != nil {
+	if err := db.WithContext(ctx).Select("slug").First(cluster, "id = ?", clusterID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "Cluster not found")
 		}
@@ -35,7 +35,7 @@ func GetClusterSlugByID(ctx context.Context, clusterID string) (string, app.Erro
 
 func GetClusterGatewayIPByID(ctx context.Context, clusterID string) (string, app.Error) {
 	cluster := &entities.Cluster{}
-	if err := db.Instance().Select("gateway_ip").First(cluster, "id = ?", clusterID).Error; err != nil {
+	if err := db.WithContext(ctx).Select("gateway_ip").First(cluster, "id = ?", clusterID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "Cluster not found")
 		}
@@ -2,19 +2,19 @@ package orm
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/internal/app"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 )
 
 func GetEnvByID(ctx context.Context, envID string) (*entities.Env, app.Error) {
 	env := &entities.Env{}
-	if err := db.Instance().First(env, "id = ?", envID).Error; err != nil {
+	if err := db.WithContext(ctx).First(env, "id = ?", envID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -26,7 +26,7 @@ func GetEnvByID(ctx context.Context, envID string) (*entities.Env, app.Error) {
 
 func GetProjectIDByEnvID(ctx context.Context, envID string) (string, app.Error) {
 	env := &entities.Env{}
-	if err := db.Instance().Select("project_id").First(env, "id = ?", envID).Error; err != nil {
+	if err := db.WithContext(ctx).Select("project_id").First(env, "id = ?", envID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -43,8 +43,8 @@ func GetProjectRoleByEnvID(ctx context.Context, envID string) (string, app.Error
 	}
 
 	entity := &models.ProjectMemberRole{}
-	if err := db.Instance().Model(&entities.Env{}).Joins("JOIN project_members ON project_members.project_id = envs.project_id").Select("project_members.project_role").First(entity, "envs.id = ? AND project_members.user_id = ?", envID, userID).Error; err != nil {
-		log.Printf("failed to get project role for env %s: %v", envID, err)
+	if err := db.WithContext(ctx).Model(&entities.Env{}).Joins("JOIN project_members ON project_members.project_id = envs.project_id").Select("project_members.project_role").First(entity, "envs.id = ? AND project_members.user_id = ?", envID, userID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get project role for env %s: %v", envID, err)
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -60,7 +60,7 @@ func GetProjectRoleByEnvID(ctx context.Context, envID string) (string, app.Error
 
 func CountEnvApps(ctx context.Context, envID string) (int64, app.Error) {
 	var count int64
-	if err := db.Instance().Model(&entities.App{}).Where("env_id = ?", envID).Count(&count).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&entities.App{}).Where("env_id = ?", envID).Count(&count).Error; err != nil {
 		return 0, app.ErrDatabaseOperationFailed
 	}
 	return count, nil
@@ -8,12 +8,13 @@ import (
 	"github.com/ketches/ketches/internal/app"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 )
 
 func GetProjectSlugByID(ctx context.Context, projectID string) (string, app.Error) {
 	project := &entities.Project{}
-	if err := db.Instance().Select("slug").First(project, "id = ?", projectID).Error; err != nil {
+	if err := db.WithContext(ctx).Select("slug").First(project, "id = ?", projectID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "Project not found")
 		}
@@ -30,7 +31,7 @@ func GetProjectRoleByProjectID(ctx context.Context, projectID string) (string, a
 	}
 
 	entity := &models.ProjectMemberRole{}
-	if err := db.Instance().Model(&entities.Project{}).Joins("JOIN project_members ON project_members.project_id = projects.id").Select("project_members.project_role").First(entity, "projects.id = ? AND project_members.user_id = ?", projectID, userID).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&entities.Project{}).Joins("JOIN project_members ON project_members.project_id = projects.id").Select("project_members.project_role").First(entity, "projects.id = ? AND project_members.user_id = ?", projectID, userID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return "", app.NewError(http.StatusNotFound, "Project not found")
 		}
@@ -43,3 +44,24 @@ func GetProjectRoleByProjectID(ctx context.Context, projectID string) (string, a
 
 	return entity.ProjectRole, nil
 }
+
+func AllProjectRegistryCredentials(ctx context.Context, projectID string) ([]*entities.ProjectRegistryCredential, app.Error) {
+	var result []*entities.ProjectRegistryCredential
+	if err := db.WithContext(ctx).Order("host").Find(&result, "project_id = ?", projectID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get registry credentials for project %s: %v", projectID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	return result, nil
+}
+
+func GetProjectRegistryCredential(ctx context.Context, projectID, credentialID string) (*entities.ProjectRegistryCredential, app.Error) {
+	credential := &entities.ProjectRegistryCredential{}
+	if err := db.WithContext(ctx).First(credential, "id = ? AND project_id = ?", credentialID, projectID).Error; err != nil {
+		if db.IsErrRecordNotFound(err) {
+			return nil, app.NewError(http.StatusNotFound, "Registry credential not found")
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return credential, nil
+}
@@ -270,11 +270,12 @@ func UpdateAppImage(c *gin.Context) {
 }
 
 // @Summary App Action
-// @Description Perform an action on an app (e.g., deploy, restart)
+// @Description Perform an action on an app (e.g., deploy, restart), or preview its changes with dryRun
 // @Tags App
 // @Accept json
 // @Produce json
 // @Param appID path string true "App ID"
+// @Param dryRun query bool false "Preview the changes against live cluster state without applying them"
 // @Param action body models.AppActionRequest true "Action to perform on the app"
 // @Success 200 {object} api.Response{data=models.AppModel}
 // @Router /api/v1/apps/{appID}/action [post]
@@ -284,9 +285,24 @@ func AppAction(c *gin.Context) {
 		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
 		return
 	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
 	req.AppID = c.Param("appID")
 
 	s := services.NewAppService()
+	if req.DryRun {
+		preview, err := s.PreviewAppAction(c, &req)
+		if err != nil {
+			api.Error(c, err)
+			return
+		}
+
+		api.Success(c, preview)
+		return
+	}
+
 	app, err := s.AppAction(c, &req)
 	if err != nil {
 		api.Error(c, err)
@@ -365,6 +381,37 @@ func TerminateAppInstance(c *gin.Context) {
 	api.NoContent(c)
 }
 
+// @Summary Debug App Instance
+// @Description Inject an ephemeral debug container into a running app instance, the workload is not restarted
+// @Tags App
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param instanceName path string true "Instance name"
+// @Param request body models.DebugAppInstanceRequest false "Debug app instance request"
+// @Success 200 {object} api.Response{data=models.DebugAppInstanceResponse}
+// @Router /api/v1/apps/{appID}/instances/{instanceName}/debug [post]
+func DebugAppInstance(c *gin.Context) {
+	var req models.DebugAppInstanceRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+			return
+		}
+	}
+	req.AppID = c.Param("appID")
+	req.InstanceName = c.Param("instanceName")
+
+	s := services.NewAppService()
+	resp, err := s.DebugAppInstance(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+
+	api.Success(c, resp)
+}
+
 // @Summary View App Container Logs
 // @Description View logs of a specific container in an app instance
 // @Tags App
@@ -397,14 +444,14 @@ func ViewAppContainerLogs(c *gin.Context) {
 }
 
 // @Summary Exec App Container Terminal
-// @Description Exec into a terminal of a specific container in an app instance
+// @Description Exec into a terminal of a specific container in an app instance over a websocket. Clients send JSON text frames of type "stdin", "resize" or "ping", output is sent back as binary frames.
 // @Tags App
 // @Accept json
 // @Produce json
 // @Param appID path string true "App ID"
 // @Param instanceName path string true "Instance name"
 // @Param containerName path string true "Container name"
-// @Param request body models.ExecAppContainerTerminalRequest true "Request to execute command in container"
+// @Param request query models.ExecAppContainerTerminalRequest false "Shell or command and initial terminal size"
 // @Success 200 {object} api.Response{}
 // @Router /api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/exec [get]
 func ExecAppContainerTerminal(c *gin.Context) {
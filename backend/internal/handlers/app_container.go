@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppContainerHandler struct {
+	svc services.AppContainerService
+}
+
+func NewAppContainerHandler() *AppContainerHandler {
+	return &AppContainerHandler{
+		svc: services.NewAppContainerService(),
+	}
+}
+
+// @Summary List App Containers
+// @Description List sidecar and init containers for an app
+// @Tags AppContainer
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=[]models.AppContainerModel}
+// @Router /api/v1/apps/{appID}/containers [get]
+func (h *AppContainerHandler) ListAppContainers(c *gin.Context) {
+	var req models.ListAppContainersRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	containers, err := h.svc.ListAppContainers(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, containers)
+}
+
+// @Summary Create App Container
+// @Description Create a sidecar or init container for an app
+// @Tags AppContainer
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param container body models.CreateAppContainerRequest true "Container"
+// @Success 201 {object} api.Response{data=models.AppContainerModel}
+// @Router /api/v1/apps/{appID}/containers [post]
+func (h *AppContainerHandler) CreateAppContainer(c *gin.Context) {
+	var req models.CreateAppContainerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	container, err := h.svc.CreateAppContainer(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Created(c, container)
+}
+
+// @Summary Update App Container
+// @Description Update a sidecar or init container for an app
+// @Tags AppContainer
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param containerID path string true "Container ID"
+// @Param container body models.UpdateAppContainerRequest true "Container"
+// @Success 200 {object} api.Response{data=models.AppContainerModel}
+// @Router /api/v1/apps/{appID}/containers/{containerID} [put]
+func (h *AppContainerHandler) UpdateAppContainer(c *gin.Context) {
+	var req models.UpdateAppContainerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.ContainerID = c.Param("containerID")
+
+	container, err := h.svc.UpdateAppContainer(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, container)
+}
+
+// @Summary Delete App Container
+// @Description Delete a sidecar or init container for an app
+// @Tags AppContainer
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param containerID path string true "Container ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/containers/{containerID} [delete]
+func (h *AppContainerHandler) DeleteAppContainer(c *gin.Context) {
+	var req models.DeleteAppContainerRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	if err := h.svc.DeleteAppContainer(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
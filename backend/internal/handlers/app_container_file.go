@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppContainerFileHandler struct {
+	svc services.AppContainerFileService
+}
+
+func NewAppContainerFileHandler() *AppContainerFileHandler {
+	return &AppContainerFileHandler{
+		svc: services.NewAppContainerFileService(),
+	}
+}
+
+// @Summary List App Container Files
+// @Description List the entries of a directory in a container of an app instance
+// @Tags AppContainerFile
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param instanceName path string true "Instance name"
+// @Param containerName path string true "Container name"
+// @Param path query string true "Absolute path of the directory"
+// @Success 200 {object} api.Response{data=[]models.AppContainerFileModel}
+// @Router /api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/files/list [get]
+func (h *AppContainerFileHandler) ListAppContainerFiles(c *gin.Context) {
+	var req models.ListAppContainerFilesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.InstanceName = c.Param("instanceName")
+	req.ContainerName = c.Param("containerName")
+
+	files, err := h.svc.ListAppContainerFiles(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, files)
+}
+
+// @Summary Download App Container Files
+// @Description Download a file or directory from a container of an app instance as a tar archive
+// @Tags AppContainerFile
+// @Produce application/x-tar
+// @Param appID path string true "App ID"
+// @Param instanceName path string true "Instance name"
+// @Param containerName path string true "Container name"
+// @Param path query string true "Absolute path of the file or directory"
+// @Success 200 {file} file
+// @Router /api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/files [get]
+func (h *AppContainerFileHandler) DownloadAppContainerFiles(c *gin.Context) {
+	var req models.DownloadAppContainerFilesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.InstanceName = c.Param("instanceName")
+	req.ContainerName = c.Param("containerName")
+	req.Request = c.Request
+	req.ResponseWriter = c.Writer
+
+	if err := h.svc.DownloadAppContainerFiles(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+}
+
+// @Summary Upload App Container Files
+// @Description Extract a tar archive into a directory of a container of an app instance
+// @Tags AppContainerFile
+// @Accept application/x-tar
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param instanceName path string true "Instance name"
+// @Param containerName path string true "Container name"
+// @Param path query string true "Absolute path of the directory the archive is extracted to"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/files [post]
+func (h *AppContainerFileHandler) UploadAppContainerFiles(c *gin.Context) {
+	var req models.UploadAppContainerFilesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.InstanceName = c.Param("instanceName")
+	req.ContainerName = c.Param("containerName")
+	req.Request = c.Request
+	req.ResponseWriter = c.Writer
+
+	if err := h.svc.UploadAppContainerFiles(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppDependencyHandler struct {
+	svc services.AppDependencyService
+}
+
+func NewAppDependencyHandler() *AppDependencyHandler {
+	return &AppDependencyHandler{
+		svc: services.NewAppDependencyService(),
+	}
+}
+
+// @Summary List App Dependencies
+// @Description List the apps an app depends on, with the env vars injected for them
+// @Tags AppDependency
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=[]models.AppDependencyModel}
+// @Router /api/v1/apps/{appID}/dependencies [get]
+func (h *AppDependencyHandler) ListAppDependencies(c *gin.Context) {
+	var req models.ListAppDependenciesRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	dependencies, err := h.svc.ListAppDependencies(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, dependencies)
+}
+
+// @Summary Create App Dependency
+// @Description Make an app depend on another app of the project, its service address is injected into the app as env vars
+// @Tags AppDependency
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param dependency body models.CreateAppDependencyRequest true "Dependency"
+// @Success 201 {object} api.Response{data=models.AppDependencyModel}
+// @Router /api/v1/apps/{appID}/dependencies [post]
+func (h *AppDependencyHandler) CreateAppDependency(c *gin.Context) {
+	var req models.CreateAppDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	dependency, err := h.svc.CreateAppDependency(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Created(c, dependency)
+}
+
+// @Summary Delete App Dependency
+// @Description Delete a dependency of an app
+// @Tags AppDependency
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param dependencyID path string true "Dependency ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/dependencies/{dependencyID} [delete]
+func (h *AppDependencyHandler) DeleteAppDependency(c *gin.Context) {
+	var req models.DeleteAppDependencyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteAppDependency(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
+
+// @Summary Get Env Dependency Graph
+// @Description Get the dependency graph of the apps of an env, with the layer each app is started in
+// @Tags AppDependency
+// @Accept json
+// @Produce json
+// @Param envID path string true "Env ID"
+// @Success 200 {object} api.Response{data=models.AppDependencyGraphModel}
+// @Router /api/v1/envs/{envID}/dependency-graph [get]
+func (h *AppDependencyHandler) GetEnvDependencyGraph(c *gin.Context) {
+	var req models.GetEnvDependencyGraphRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	graph, err := h.svc.GetEnvDependencyGraph(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, graph)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppEventHandler struct {
+	svc services.AppEventService
+}
+
+func NewAppEventHandler() *AppEventHandler {
+	return &AppEventHandler{
+		svc: services.NewAppEventService(),
+	}
+}
+
+// @Summary List App Events
+// @Description List the Kubernetes events of an app's workload, ReplicaSets, pods, PVCs and routes, the latest first
+// @Tags App
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param request query models.ListAppEventsRequest false "Filter by instance and event type"
+// @Success 200 {object} api.Response{data=[]models.AppEventModel}
+// @Router /api/v1/apps/{appID}/events [get]
+func (h *AppEventHandler) ListAppEvents(c *gin.Context) {
+	var req models.ListAppEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	events, err := h.svc.ListAppEvents(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, events)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppLogHandler struct {
+	svc services.AppLogService
+}
+
+func NewAppLogHandler() *AppLogHandler {
+	return &AppLogHandler{
+		svc: services.NewAppLogService(),
+	}
+}
+
+// @Summary Stream App Logs
+// @Description Stream the logs of all instances of an app as server-sent events, each line prefixed with its instance
+// @Tags App
+// @Produce text/event-stream
+// @Param appID path string true "App ID"
+// @Param request query models.AppLogsRequest false "Query parameters for streaming logs"
+// @Success 200
+// @Router /api/v1/apps/{appID}/logs [get]
+func (h *AppLogHandler) StreamAppLogs(c *gin.Context) {
+	var req models.AppLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.Request = c.Request
+	req.ResponseWriter = c.Writer
+
+	if err := h.svc.StreamAppLogs(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+}
+
+// @Summary Download App Logs
+// @Description Download the logs of all instances of an app as a gzip file
+// @Tags App
+// @Produce application/gzip
+// @Param appID path string true "App ID"
+// @Param request query models.AppLogsRequest false "Query parameters for downloading logs"
+// @Success 200 {file} file
+// @Router /api/v1/apps/{appID}/logs/download [get]
+func (h *AppLogHandler) DownloadAppLogs(c *gin.Context) {
+	var req models.AppLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.Request = c.Request
+	req.ResponseWriter = c.Writer
+
+	if err := h.svc.DownloadAppLogs(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+}
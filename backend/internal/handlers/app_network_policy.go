@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppNetworkPolicyHandler struct {
+	svc services.AppNetworkPolicyService
+}
+
+func NewAppNetworkPolicyHandler() *AppNetworkPolicyHandler {
+	return &AppNetworkPolicyHandler{
+		svc: services.NewAppNetworkPolicyService(),
+	}
+}
+
+// @Summary Get App Network Policy
+// @Description Get the ingress network policy of an app
+// @Tags AppNetworkPolicy
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=models.AppNetworkPolicyModel}
+// @Router /api/v1/apps/{appID}/network-policy [get]
+func (h *AppNetworkPolicyHandler) GetAppNetworkPolicy(c *gin.Context) {
+	var req models.GetAppNetworkPolicyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	policy, err := h.svc.GetAppNetworkPolicy(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+
+	if policy == nil {
+		api.Success(c, nil)
+		return
+	}
+
+	api.Success(c, policy)
+}
+
+// @Summary Set App Network Policy
+// @Description Set the ingress network policy of an app, only the gateway and the listed apps of the same env can reach it afterwards
+// @Tags AppNetworkPolicy
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param networkPolicy body models.SetAppNetworkPolicyRequest true "Network policy"
+// @Success 200 {object} api.Response{data=models.AppNetworkPolicyModel}
+// @Router /api/v1/apps/{appID}/network-policy [put]
+func (h *AppNetworkPolicyHandler) SetAppNetworkPolicy(c *gin.Context) {
+	var req models.SetAppNetworkPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	policy, err := h.svc.SetAppNetworkPolicy(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, policy)
+}
+
+// @Summary Delete App Network Policy
+// @Description Delete the ingress network policy of an app, the env network policy applies afterwards
+// @Tags AppNetworkPolicy
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/network-policy [delete]
+func (h *AppNetworkPolicyHandler) DeleteAppNetworkPolicy(c *gin.Context) {
+	var req models.DeleteAppNetworkPolicyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteAppNetworkPolicy(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppPortHandler struct {
+	svc services.AppPortService
+}
+
+func NewAppPortHandler() *AppPortHandler {
+	return &AppPortHandler{
+		svc: services.NewAppPortService(),
+	}
+}
+
+// @Summary List App Ports
+// @Description List the ports of an app, they are exposed by its container and service
+// @Tags AppPort
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=[]models.AppPortModel}
+// @Router /api/v1/apps/{appID}/ports [get]
+func (h *AppPortHandler) ListAppPorts(c *gin.Context) {
+	var req models.ListAppPortsRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	ports, err := h.svc.ListAppPorts(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, ports)
+}
+
+// @Summary Create App Port
+// @Description Create a port of an app, gateways can route to it by name
+// @Tags AppPort
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param port body models.CreateAppPortRequest true "Port"
+// @Success 201 {object} api.Response{data=models.AppPortModel}
+// @Router /api/v1/apps/{appID}/ports [post]
+func (h *AppPortHandler) CreateAppPort(c *gin.Context) {
+	var req models.CreateAppPortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	port, err := h.svc.CreateAppPort(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Created(c, port)
+}
+
+// @Summary Update App Port
+// @Description Update a port of an app, its name can't be changed
+// @Tags AppPort
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param portID path string true "Port ID"
+// @Param port body models.UpdateAppPortRequest true "Port"
+// @Success 200 {object} api.Response{data=models.AppPortModel}
+// @Router /api/v1/apps/{appID}/ports/{portID} [put]
+func (h *AppPortHandler) UpdateAppPort(c *gin.Context) {
+	var req models.UpdateAppPortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.PortID = c.Param("portID")
+
+	port, err := h.svc.UpdateAppPort(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, port)
+}
+
+// @Summary Delete App Port
+// @Description Delete a port of an app, it must not be routed to by gateways
+// @Tags AppPort
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param portID path string true "Port ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/ports/{portID} [delete]
+func (h *AppPortHandler) DeleteAppPort(c *gin.Context) {
+	var req models.DeleteAppPortRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteAppPort(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
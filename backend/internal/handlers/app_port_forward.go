@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppPortForwardHandler struct {
+	svc services.AppPortForwardService
+}
+
+func NewAppPortForwardHandler() *AppPortForwardHandler {
+	return &AppPortForwardHandler{
+		svc: services.NewAppPortForwardService(),
+	}
+}
+
+// @Summary Port Forward App Instance
+// @Description Tunnel a websocket to a port of an app instance, binary frames carry the TCP stream of one connection
+// @Tags App
+// @Param appID path string true "App ID"
+// @Param instanceName path string true "Instance name"
+// @Param port query int true "Container port"
+// @Success 101
+// @Router /api/v1/apps/{appID}/instances/{instanceName}/port-forward [get]
+func (h *AppPortForwardHandler) PortForwardAppInstance(c *gin.Context) {
+	var req models.PortForwardAppInstanceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.InstanceName = c.Param("instanceName")
+	req.Request = c.Request
+	req.ResponseWriter = c.Writer
+
+	if err := h.svc.PortForwardAppInstance(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppReleaseHandler struct {
+	svc services.AppReleaseService
+}
+
+func NewAppReleaseHandler() *AppReleaseHandler {
+	return &AppReleaseHandler{
+		svc: services.NewAppReleaseService(),
+	}
+}
+
+// @Summary Get App Release
+// @Description Get the latest canary or blue-green release of an app
+// @Tags AppRelease
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=models.AppReleaseModel}
+// @Router /api/v1/apps/{appID}/release [get]
+func (h *AppReleaseHandler) GetAppRelease(c *gin.Context) {
+	var req models.GetAppReleaseRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	release, err := h.svc.GetAppRelease(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+
+	if release == nil {
+		api.Success(c, nil)
+		return
+	}
+
+	api.Success(c, release)
+}
+
+// @Summary App Release Action
+// @Description Pause, resume, promote or abort the active release of an app
+// @Tags AppRelease
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param action body models.AppReleaseActionRequest true "Release action"
+// @Success 200 {object} api.Response{data=models.AppReleaseModel}
+// @Router /api/v1/apps/{appID}/release/action [post]
+func (h *AppReleaseHandler) AppReleaseAction(c *gin.Context) {
+	var req models.AppReleaseActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	release, err := h.svc.AppReleaseAction(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, release)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppRolloutHandler struct {
+	svc services.AppRolloutService
+}
+
+func NewAppRolloutHandler() *AppRolloutHandler {
+	return &AppRolloutHandler{
+		svc: services.NewAppRolloutService(),
+	}
+}
+
+// @Summary Get App Rollout Strategy
+// @Description Get the rollout strategy of an app
+// @Tags AppRollout
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=models.AppRolloutStrategyModel}
+// @Router /api/v1/apps/{appID}/rollout-strategy [get]
+func (h *AppRolloutHandler) GetAppRolloutStrategy(c *gin.Context) {
+	var req models.GetAppRolloutStrategyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	strategy, err := h.svc.GetAppRolloutStrategy(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+
+	if strategy == nil {
+		api.Success(c, nil)
+		return
+	}
+
+	api.Success(c, strategy)
+}
+
+// @Summary Set App Rollout Strategy
+// @Description Set the rollout strategy of an app
+// @Tags AppRollout
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param rolloutStrategy body models.SetAppRolloutStrategyRequest true "Rollout strategy"
+// @Success 200 {object} api.Response{data=models.AppRolloutStrategyModel}
+// @Router /api/v1/apps/{appID}/rollout-strategy [put]
+func (h *AppRolloutHandler) SetAppRolloutStrategy(c *gin.Context) {
+	var req models.SetAppRolloutStrategyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+
+	strategy, err := h.svc.SetAppRolloutStrategy(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, strategy)
+}
+
+// @Summary Delete App Rollout Strategy
+// @Description Delete the rollout strategy of an app, the Kubernetes defaults are used afterwards
+// @Tags AppRollout
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/rollout-strategy [delete]
+func (h *AppRolloutHandler) DeleteAppRolloutStrategy(c *gin.Context) {
+	var req models.DeleteAppRolloutStrategyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteAppRolloutStrategy(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
+
+// @Summary Get App Rollout Progress
+// @Description Get the rollout progress of an app, with instance counts per edition
+// @Tags AppRollout
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Success 200 {object} api.Response{data=models.AppRolloutProgressModel}
+// @Router /api/v1/apps/{appID}/rollout/progress [get]
+func (h *AppRolloutHandler) GetAppRolloutProgress(c *gin.Context) {
+	var req models.GetAppRolloutProgressRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	progress, err := h.svc.GetAppRolloutProgress(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, progress)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppTerminalSessionHandler struct {
+	svc services.AppTerminalSessionService
+}
+
+func NewAppTerminalSessionHandler() *AppTerminalSessionHandler {
+	return &AppTerminalSessionHandler{
+		svc: services.NewAppTerminalSessionService(),
+	}
+}
+
+// @Summary List App Terminal Sessions
+// @Description List the web terminal sessions opened on the instances of an app, newest first
+// @Tags AppTerminalSession
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param request query models.ListAppTerminalSessionsRequest false "Paging parameters"
+// @Success 200 {object} api.Response{data=models.ListAppTerminalSessionsResponse}
+// @Router /api/v1/apps/{appID}/terminal-sessions [get]
+func (h *AppTerminalSessionHandler) ListAppTerminalSessions(c *gin.Context) {
+	var req models.ListAppTerminalSessionsRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	resp, err := h.svc.ListAppTerminalSessions(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, resp)
+}
+
+// @Summary Download App Terminal Session Recording
+// @Description Download the asciicast recording of a web terminal session for playback
+// @Tags AppTerminalSession
+// @Produce octet-stream
+// @Param appID path string true "App ID"
+// @Param sessionID path string true "Terminal session ID"
+// @Success 200 {file} file
+// @Router /api/v1/apps/{appID}/terminal-sessions/{sessionID}/recording [get]
+func (h *AppTerminalSessionHandler) GetAppTerminalSessionRecording(c *gin.Context) {
+	var req models.GetAppTerminalSessionRecordingRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	path, err := h.svc.GetAppTerminalSessionRecording(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	c.FileAttachment(path, filepath.Base(path))
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type AppVolumeSnapshotHandler struct {
+	svc services.AppVolumeSnapshotService
+}
+
+func NewAppVolumeSnapshotHandler() *AppVolumeSnapshotHandler {
+	return &AppVolumeSnapshotHandler{
+		svc: services.NewAppVolumeSnapshotService(),
+	}
+}
+
+// @Summary List App Volume Snapshots
+// @Description List the snapshots of an app volume, newest first
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Success 200 {object} api.Response{data=[]models.AppVolumeSnapshotModel}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshots [get]
+func (h *AppVolumeSnapshotHandler) ListAppVolumeSnapshots(c *gin.Context) {
+	var req models.ListAppVolumeSnapshotsRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	snapshots, err := h.svc.ListAppVolumeSnapshots(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, snapshots)
+}
+
+// @Summary Create App Volume Snapshot
+// @Description Take a snapshot of an app volume
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Param snapshot body models.CreateAppVolumeSnapshotRequest true "Snapshot"
+// @Success 201 {object} api.Response{data=models.AppVolumeSnapshotModel}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshots [post]
+func (h *AppVolumeSnapshotHandler) CreateAppVolumeSnapshot(c *gin.Context) {
+	var req models.CreateAppVolumeSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.VolumeID = c.Param("volumeID")
+
+	snapshot, err := h.svc.CreateAppVolumeSnapshot(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Created(c, snapshot)
+}
+
+// @Summary Delete App Volume Snapshot
+// @Description Delete a snapshot of an app volume
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Param snapshotName path string true "Snapshot name"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshots/{snapshotName} [delete]
+func (h *AppVolumeSnapshotHandler) DeleteAppVolumeSnapshot(c *gin.Context) {
+	var req models.DeleteAppVolumeSnapshotRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteAppVolumeSnapshot(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
+
+// @Summary Restore App Volume Snapshot
+// @Description Restore a snapshot into a new claim, or in place of the snapshotted claim while the app is stopped
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Param snapshotName path string true "Snapshot name"
+// @Param restore body models.RestoreAppVolumeSnapshotRequest true "Restore options"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshots/{snapshotName}/restore [post]
+func (h *AppVolumeSnapshotHandler) RestoreAppVolumeSnapshot(c *gin.Context) {
+	var req models.RestoreAppVolumeSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.VolumeID = c.Param("volumeID")
+	req.SnapshotName = c.Param("snapshotName")
+
+	if err := h.svc.RestoreAppVolumeSnapshot(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
+
+// @Summary Get App Volume Snapshot Policy
+// @Description Get the scheduled snapshot policy of an app volume
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Success 200 {object} api.Response{data=models.AppVolumeSnapshotPolicyModel}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshot-policy [get]
+func (h *AppVolumeSnapshotHandler) GetAppVolumeSnapshotPolicy(c *gin.Context) {
+	var req models.GetAppVolumeSnapshotPolicyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	policy, err := h.svc.GetAppVolumeSnapshotPolicy(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+
+	if policy == nil {
+		api.Success(c, nil)
+		return
+	}
+
+	api.Success(c, policy)
+}
+
+// @Summary Set App Volume Snapshot Policy
+// @Description Set the scheduled snapshot policy of an app volume
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Param policy body models.SetAppVolumeSnapshotPolicyRequest true "Snapshot policy"
+// @Success 200 {object} api.Response{data=models.AppVolumeSnapshotPolicyModel}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshot-policy [put]
+func (h *AppVolumeSnapshotHandler) SetAppVolumeSnapshotPolicy(c *gin.Context) {
+	var req models.SetAppVolumeSnapshotPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.AppID = c.Param("appID")
+	req.VolumeID = c.Param("volumeID")
+
+	policy, err := h.svc.SetAppVolumeSnapshotPolicy(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, policy)
+}
+
+// @Summary Delete App Volume Snapshot Policy
+// @Description Delete the scheduled snapshot policy of an app volume, existing snapshots are kept
+// @Tags AppVolumeSnapshot
+// @Accept json
+// @Produce json
+// @Param appID path string true "App ID"
+// @Param volumeID path string true "Volume ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/apps/{appID}/volumes/{volumeID}/snapshot-policy [delete]
+func (h *AppVolumeSnapshotHandler) DeleteAppVolumeSnapshotPolicy(c *gin.Context) {
+	var req models.DeleteAppVolumeSnapshotPolicyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteAppVolumeSnapshotPolicy(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
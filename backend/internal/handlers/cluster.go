@@ -273,6 +273,24 @@ func PingClusterKubeConfig(c *gin.Context) {
 	api.Success(c, result)
 }
 
+// @Summary List ingress classes of a cluster
+// @Description Get the ingress classes of the specified cluster, used by the ingress routing backend
+// @Tags Cluster
+// @Param clusterID path string true "Cluster ID"
+// @Success 200 {object} api.Response{data=[]models.ClusterIngressClassModel}
+// @Router /api/v1/clusters/{clusterID}/ingress-classes [get]
+func ListClusterIngressClasses(c *gin.Context) {
+	svc := services.NewClusterService()
+	ingressClasses, err := svc.ListClusterIngressClasses(c.Request.Context(), &models.ListClusterIngressClassesRequest{
+		ClusterID: c.Param("clusterID"),
+	})
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, ingressClasses)
+}
+
 // @Summary List nodes of a cluster
 // @Description Get all nodes of the specified cluster
 // @Tags Cluster
@@ -197,3 +197,30 @@ func DeleteEnv(c *gin.Context) {
 
 	api.NoContent(c)
 }
+
+// @Summary Env Action
+// @Description Start or stop all apps of an env in dependency order, apps a layer depends on are started first and stopped last
+// @Tags Env
+// @Accept json
+// @Produce json
+// @Param envID path string true "Env ID"
+// @Param request body models.EnvActionRequest true "Env Action Request"
+// @Success 200 {object} api.Response{data=models.EnvActionResponse}
+// @Router /api/v1/envs/{envID}/action [post]
+func EnvAction(c *gin.Context) {
+	var req models.EnvActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.EnvID = c.Param("envID")
+
+	s := services.NewEnvService()
+	resp, err := s.EnvAction(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+
+	api.Success(c, resp)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/internal/services"
+)
+
+type ProjectRegistryCredentialHandler struct {
+	svc services.ProjectRegistryCredentialService
+}
+
+func NewProjectRegistryCredentialHandler() *ProjectRegistryCredentialHandler {
+	return &ProjectRegistryCredentialHandler{
+		svc: services.NewProjectRegistryCredentialService(),
+	}
+}
+
+// @Summary List Project Registry Credentials
+// @Description List the private registry credentials of a project, tokens are not returned
+// @Tags ProjectRegistryCredential
+// @Accept json
+// @Produce json
+// @Param projectID path string true "Project ID"
+// @Success 200 {object} api.Response{data=[]models.ProjectRegistryCredentialModel}
+// @Router /api/v1/projects/{projectID}/registry-credentials [get]
+func (h *ProjectRegistryCredentialHandler) ListProjectRegistryCredentials(c *gin.Context) {
+	var req models.ListProjectRegistryCredentialsRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	credentials, err := h.svc.ListProjectRegistryCredentials(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, credentials)
+}
+
+// @Summary Create Project Registry Credential
+// @Description Create a private registry credential, synced as a pull secret into every env of the project
+// @Tags ProjectRegistryCredential
+// @Accept json
+// @Produce json
+// @Param projectID path string true "Project ID"
+// @Param credential body models.CreateProjectRegistryCredentialRequest true "Registry credential"
+// @Success 201 {object} api.Response{data=models.ProjectRegistryCredentialModel}
+// @Router /api/v1/projects/{projectID}/registry-credentials [post]
+func (h *ProjectRegistryCredentialHandler) CreateProjectRegistryCredential(c *gin.Context) {
+	var req models.CreateProjectRegistryCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.ProjectID = c.Param("projectID")
+
+	credential, err := h.svc.CreateProjectRegistryCredential(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Created(c, credential)
+}
+
+// @Summary Update Project Registry Credential
+// @Description Update the username or token of a private registry credential
+// @Tags ProjectRegistryCredential
+// @Accept json
+// @Produce json
+// @Param projectID path string true "Project ID"
+// @Param credentialID path string true "Credential ID"
+// @Param credential body models.UpdateProjectRegistryCredentialRequest true "Registry credential"
+// @Success 200 {object} api.Response{data=models.ProjectRegistryCredentialModel}
+// @Router /api/v1/projects/{projectID}/registry-credentials/{credentialID} [put]
+func (h *ProjectRegistryCredentialHandler) UpdateProjectRegistryCredential(c *gin.Context) {
+	var req models.UpdateProjectRegistryCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.ProjectID = c.Param("projectID")
+	req.CredentialID = c.Param("credentialID")
+
+	credential, err := h.svc.UpdateProjectRegistryCredential(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, credential)
+}
+
+// @Summary Delete Project Registry Credential
+// @Description Delete a private registry credential and its pull secrets
+// @Tags ProjectRegistryCredential
+// @Accept json
+// @Produce json
+// @Param projectID path string true "Project ID"
+// @Param credentialID path string true "Credential ID"
+// @Success 204 {object} api.Response{}
+// @Router /api/v1/projects/{projectID}/registry-credentials/{credentialID} [delete]
+func (h *ProjectRegistryCredentialHandler) DeleteProjectRegistryCredential(c *gin.Context) {
+	var req models.DeleteProjectRegistryCredentialRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.svc.DeleteProjectRegistryCredential(c, &req); err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.NoContent(c)
+}
+
+// @Summary Test Project Registry Credential
+// @Description Log in to the registry with a saved or unsaved credential using the registry v2 auth handshake
+// @Tags ProjectRegistryCredential
+// @Accept json
+// @Produce json
+// @Param projectID path string true "Project ID"
+// @Param credential body models.TestProjectRegistryCredentialRequest true "Registry credential"
+// @Success 200 {object} api.Response{data=models.TestProjectRegistryCredentialResponse}
+// @Router /api/v1/projects/{projectID}/registry-credentials/test [post]
+func (h *ProjectRegistryCredentialHandler) TestProjectRegistryCredential(c *gin.Context) {
+	var req models.TestProjectRegistryCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.Error(c, app.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+	req.ProjectID = c.Param("projectID")
+
+	result, err := h.svc.TestProjectRegistryCredential(c, &req)
+	if err != nil {
+		api.Error(c, err)
+		return
+	}
+	api.Success(c, result)
+}
@@ -2,39 +2,131 @@ package kube
 
 import (
 	"context"
-	"log"
-	"reflect"
+	"encoding/json"
+	"errors"
+	"strings"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/client-go/util/retry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
-func newEmptyObjectFrom(obj client.Object) client.Object {
-	t := reflect.TypeOf(obj).Elem()
-	return reflect.New(t).Interface().(client.Object)
+// FieldManager is the field manager name ketches uses when applying manifests.
+const FieldManager = "ketches"
+
+// ApplyResource applies obj with server-side apply under the ketches field
+// manager, so fields owned by other controllers are left untouched. Pass
+// client.ForceOwnership to take over conflicting fields.
+func ApplyResource(ctx context.Context, cli client.Client, obj client.Object, opts ...client.PatchOption) app.Error {
+	u, err := ToUnstructured(ctx, cli, obj)
+	if err != nil {
+		return err
+	}
+
+	opts = append([]client.PatchOption{client.FieldOwner(FieldManager)}, opts...)
+	patchErr := cli.Patch(ctx, u, client.Apply, opts...)
+	if k8serrors.IsConflict(patchErr) && prepareApplyRetry(ctx, cli, u) {
+		patchErr = cli.Patch(ctx, u, client.Apply, opts...)
+	}
+	if patchErr != nil {
+		if conflictErr := applyConflictError(u, patchErr); conflictErr != nil {
+			logging.Infof(ctx, "apply %s %s/%s conflicts: %v", u.GetKind(), u.GetNamespace(), u.GetName(), patchErr)
+			return conflictErr
+		}
+		logging.Errorf(ctx, "failed to apply %s %s/%s: %v", u.GetKind(), u.GetNamespace(), u.GetName(), patchErr)
+		return app.ErrClusterOperationFailed
+	}
+
+	return nil
 }
 
-func ApplyResource(ctx context.Context, cli client.Client, obj client.Object) app.Error {
-	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		got := newEmptyObjectFrom(obj)
-		err := cli.Get(ctx, client.ObjectKey{Name: obj.GetName(), Namespace: obj.GetNamespace()}, got)
+// prepareApplyRetry resolves the conflicts ketches can settle on its own, it
+// tells whether the apply of obj is worth a retry:
+//   - fields set by the former client-side create and update path move over
+//     to the ketches apply manager, see NeedsApplyTakeover
+//   - replicas scaled by another manager, like an HPA, are left to it
+func prepareApplyRetry(ctx context.Context, cli client.Client, obj *unstructured.Unstructured) bool {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		logging.Errorf(ctx, "failed to get %s %s/%s: %v", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+		return false
+	}
+
+	retry := OmitForeignReplicas(live, obj)
+	if NeedsApplyTakeover(live) {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(live, legacyFieldManagers(), FieldManager)
 		if err != nil {
-			if k8serrors.IsNotFound(err) {
-				obj.SetResourceVersion("")
-				return cli.Create(ctx, obj)
+			logging.Errorf(ctx, "failed to upgrade managed fields of %s %s/%s: %v", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+			return retry
+		}
+		if patch != nil {
+			logging.Infof(ctx, "taking over %s %s/%s from client-side apply", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+			if err := cli.Patch(ctx, live, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				logging.Errorf(ctx, "failed to take over %s %s/%s: %v", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+				return retry
 			}
-			return err
+			retry = true
 		}
-		obj.SetResourceVersion(got.GetResourceVersion())
-		return cli.Update(ctx, obj)
-	}); err != nil {
-		log.Println("failed to apply resource:", err)
-		return app.ErrClusterOperationFailed
 	}
+	return retry
+}
 
-	return nil
+// NeedsApplyTakeover tells whether the live object is owned by ketches but was
+// never applied under its field manager.
+func NeedsApplyTakeover(live client.Object) bool {
+	if live.GetLabels()["ketches.cn/owned"] == "" {
+		return false
+	}
+	for _, entry := range live.GetManagedFields() {
+		if entry.Manager == FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			return false
+		}
+	}
+	return true
+}
+
+// legacyFieldManagers returns the managers of the former client-side create
+// and update path. Its requests carried no field manager, so the API server
+// named them after the binary in the user agent.
+func legacyFieldManagers() sets.Set[string] {
+	binary, _, _ := strings.Cut(rest.DefaultKubernetesUserAgent(), "/")
+	return sets.New("ketches-api", binary)
+}
+
+// OmitForeignReplicas removes spec.replicas from obj when a manager other
+// than ketches owns it in live, for example an HPA scaling the workload. A
+// zero replica count stops the app, it is kept and conflicts instead.
+func OmitForeignReplicas(live, obj *unstructured.Unstructured) bool {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found || replicas == 0 {
+		return false
+	}
+
+	legacy := legacyFieldManagers()
+	for _, entry := range live.GetManagedFields() {
+		if entry.Manager == FieldManager || legacy.Has(entry.Manager) || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if _, ok, _ := unstructured.NestedFieldNoCopy(fields, "f:spec", "f:replicas"); ok {
+			unstructured.RemoveNestedField(obj.Object, "spec", "replicas")
+			return true
+		}
+	}
+	return false
 }
 
 func DeleteResource(ctx context.Context, cli client.Client, obj client.Object) app.Error {
@@ -43,8 +135,94 @@ func DeleteResource(ctx context.Context, cli client.Client, obj client.Object) a
 			// Resource already deleted
 			return nil
 		}
-		log.Println("failed to delete resource:", err)
+		logging.Errorf(ctx, "failed to delete resource: %v", err)
 		return app.ErrClusterOperationFailed
 	}
 	return nil
 }
+
+// ToUnstructured converts a typed manifest into an unstructured object with
+// its apiVersion and kind set, as required by server-side apply.
+func ToUnstructured(ctx context.Context, cli client.Client, obj client.Object) (*unstructured.Unstructured, app.Error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, cli.Scheme())
+	if err != nil {
+		logging.Errorf(ctx, "failed to get GVK for %T: %v", obj, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		logging.Errorf(ctx, "failed to convert %s %s to unstructured: %v", gvk.Kind, obj.GetName(), err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	result := &unstructured.Unstructured{Object: content}
+	result.SetGroupVersionKind(gvk)
+	// Zero values emitted by the converter would otherwise claim ownership
+	unstructured.RemoveNestedField(result.Object, "status")
+	unstructured.RemoveNestedField(result.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(result.Object, "metadata", "resourceVersion")
+	return result, nil
+}
+
+// applyConflictError extracts the field manager conflicts from a rejected
+// server-side apply, it returns nil if err is not such a conflict.
+func applyConflictError(obj *unstructured.Unstructured, err error) app.Error {
+	var statusErr *k8serrors.StatusError
+	if !errors.As(err, &statusErr) || !k8serrors.IsConflict(err) {
+		return nil
+	}
+
+	var conflicts []app.FieldConflict
+	if details := statusErr.ErrStatus.Details; details != nil {
+		for _, cause := range details.Causes {
+			if cause.Type != metav1.CauseTypeFieldManagerConflict {
+				continue
+			}
+			conflicts = append(conflicts, app.FieldConflict{
+				Manager: conflictManager(cause.Message),
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return app.NewApplyConflictError(obj.GetKind(), obj.GetNamespace(), obj.GetName(), conflicts)
+}
+
+// OnlyLegacyConflicts tells whether every field manager conflict of a
+// rejected apply is with a manager of the former client-side path.
+func OnlyLegacyConflicts(err error) bool {
+	var statusErr *k8serrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil {
+		return false
+	}
+	legacy := legacyFieldManagers()
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict && !legacy.Has(conflictManager(cause.Message)) {
+			return false
+		}
+	}
+	return true
+}
+
+// conflictManager parses the manager name from a conflict cause message like
+// `conflict with "kube-controller-manager" using apps/v1`.
+func conflictManager(message string) string {
+	_, rest, ok := strings.Cut(message, `"`)
+	if !ok {
+		return ""
+	}
+	manager, _, ok := strings.Cut(rest, `"`)
+	if !ok {
+		return ""
+	}
+	return manager
+}
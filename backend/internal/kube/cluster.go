@@ -2,7 +2,6 @@ package kube
 
 import (
 	"context"
-	"log"
 	"net/http"
 	"time"
 
@@ -10,6 +9,9 @@ import (
 	"github.com/ketches/ketches/internal/app"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/metrics"
+	"github.com/ketches/ketches/internal/tracing"
 	apiextscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
@@ -19,6 +21,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 var (
@@ -42,7 +45,7 @@ func ClusterStore(ctx context.Context, clusterID string) (storeInterface, app.Er
 	go func() {
 		_, e := clientset.Discovery().ServerVersion()
 		if e != nil {
-			log.Printf("failed to get server version: %v\n", e)
+			logging.Errorf(ctx, "failed to get server version: %v", e)
 			return
 		}
 
@@ -51,7 +54,7 @@ func ClusterStore(ctx context.Context, clusterID string) (storeInterface, app.Er
 
 	select {
 	case <-ch:
-		store := loadStore(clientset)
+		store := loadStore(clusterID, clientset)
 		clusterStoreset[clusterID] = store
 		return store, nil
 	case <-time.After(2 * time.Second):
@@ -73,7 +76,7 @@ func ClusterClientset(ctx context.Context, clusterID string, refresh bool) (kube
 
 	clusterKubeConfig[clusterID] = restConfig
 
-	clientset, err := clientsetFromRestConfig(restConfig)
+	clientset, err := clientsetFromRestConfig(ctx, restConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +97,7 @@ func ClusterRuntimeClient(ctx context.Context, clusterID string) (client.Client,
 
 	clusterKubeConfig[clusterID] = restConfig
 
-	runtimeClient, err := runtimeClientFromRestConfig(restConfig)
+	runtimeClient, err := runtimeClientFromRestConfig(ctx, restConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -109,8 +112,8 @@ func RestConfig(ctx context.Context, clusterID string) (*rest.Config, app.Error)
 	}
 
 	cluster := &entities.Cluster{}
-	if err := db.Instance().First(&cluster, "id = ?", clusterID).Error; err != nil {
-		log.Printf("failed to get cluster %s: %v\n", clusterID, err)
+	if err := db.WithContext(ctx).First(&cluster, "id = ?", clusterID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get cluster %s: %v", clusterID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Cluster not found")
 		}
@@ -122,19 +125,21 @@ func RestConfig(ctx context.Context, clusterID string) (*rest.Config, app.Error)
 		return nil, app.NewError(http.StatusConflict, "KubeConfig is not set for cluster")
 	}
 
-	restConfig, err := restConfigFromKubeConfigBytes([]byte(kubeConfig))
+	restConfig, err := restConfigFromKubeConfigBytes(ctx, []byte(kubeConfig))
 	if err != nil {
 		return nil, err
 	}
+	restConfig.Wrap(metrics.KubeTransport(clusterID))
+	restConfig.Wrap(tracing.KubeTransport(clusterID))
 
 	clusterKubeConfig[clusterID] = restConfig
 	return restConfig, nil
 }
 
-func clientsetFromRestConfig(restConfig *rest.Config) (kubernetes.Interface, app.Error) {
+func clientsetFromRestConfig(ctx context.Context, restConfig *rest.Config) (kubernetes.Interface, app.Error) {
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		log.Printf("failed to create Kubernetes clientset: %v\n", err)
+		logging.Errorf(ctx, "failed to create Kubernetes clientset: %v", err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to create Kubernetes clientset")
 	}
 
@@ -143,58 +148,69 @@ func clientsetFromRestConfig(restConfig *rest.Config) (kubernetes.Interface, app
 
 var runtimeScheme = scheme.Scheme
 
-func runtimeClientFromRestConfig(restConfig *rest.Config) (client.Client, app.Error) {
+func runtimeClientFromRestConfig(ctx context.Context, restConfig *rest.Config) (client.Client, app.Error) {
 	utilruntime.Must(scheme.AddToScheme(runtimeScheme))
 	// Add required schemes
 	utilruntime.Must(apiextscheme.AddToScheme(runtimeScheme))
 	utilruntime.Must(gatewayapisv1.Install(runtimeScheme))
+	utilruntime.Must(gatewayapisv1alpha2.Install(runtimeScheme))
 	utilruntime.Must(helmoperatorv1alpha1.AddToScheme(runtimeScheme))
 	kubeRuntimeClient, err := client.New(restConfig, client.Options{
 		Scheme: runtimeScheme,
 	})
 	if err != nil {
-		log.Printf("failed to create Kubernetes runtime client: %v\n", err)
+		logging.Errorf(ctx, "failed to create Kubernetes runtime client: %v", err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to create Kubernetes runtime client")
 	}
 
 	return kubeRuntimeClient, nil
 }
 
-func DynamicClientFromKubeConfigBytes(kubeConfigBytes []byte) (*dynamic.DynamicClient, app.Error) {
+// RuntimeClientFromKubeConfigBytes returns a runtime client of the cluster of
+// the kubeconfig, for clusters not saved yet.
+func RuntimeClientFromKubeConfigBytes(ctx context.Context, kubeConfigBytes []byte) (client.Client, app.Error) {
+	restConfig, err := restConfigFromKubeConfigBytes(ctx, kubeConfigBytes)
+	if err != nil {
+		return nil, err
+	}
+	return runtimeClientFromRestConfig(ctx, restConfig)
+}
+
+func DynamicClientFromKubeConfigBytes(ctx context.Context, kubeConfigBytes []byte) (*dynamic.DynamicClient, app.Error) {
 	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfigBytes)
 	if err != nil {
-		log.Printf("failed to parse kubeconfig: %v\n", err)
+		logging.Errorf(ctx, "failed to parse kubeconfig: %v", err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to parse kubeconfig")
 	}
 
 	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
-		log.Printf("failed to create dynamic client: %v\n", err)
+		logging.Errorf(ctx, "failed to create dynamic client: %v", err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to create dynamic client")
 	}
 
 	return dynamicClient, nil
 }
 
-func CheckKubeConfigBytes(kubeConfigBytes []byte) bool {
+func CheckKubeConfigBytes(ctx context.Context, kubeConfigBytes []byte) bool {
 	if len(kubeConfigBytes) == 0 {
 		return false
 	}
 
-	restConfig, err := restConfigFromKubeConfigBytes(kubeConfigBytes)
+	restConfig, err := restConfigFromKubeConfigBytes(ctx, kubeConfigBytes)
 	if err != nil {
 		return false
 	}
 
-	clientset, err := clientsetFromRestConfig(restConfig)
+	clientset, err := clientsetFromRestConfig(ctx, restConfig)
 	if err != nil {
-		log.Printf("failed to create discovery client: %v\n", err)
+		logging.Warnf(ctx, "failed to create discovery client: %v", err)
 		return false
 	}
 
 	serverVersion, e := clientset.Discovery().ServerVersion()
 	if e != nil {
-		log.Printf("failed to get server version: %v\n", e)
+		logging.Warnf(ctx, "failed to get server version: %v", e)
 		return false
 	}
 
@@ -205,10 +221,10 @@ func CheckKubeConfigBytes(kubeConfigBytes []byte) bool {
 	return false
 }
 
-func restConfigFromKubeConfigBytes(kubeConfigBytes []byte) (*rest.Config, app.Error) {
+func restConfigFromKubeConfigBytes(ctx context.Context, kubeConfigBytes []byte) (*rest.Config, app.Error) {
 	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfigBytes)
 	if err != nil {
-		log.Printf("failed to parse kubeconfig: %v\n", err)
+		logging.Errorf(ctx, "failed to parse kubeconfig: %v", err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to parse kubeconfig")
 	}
 	return restConfig, nil
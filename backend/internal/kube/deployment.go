@@ -2,10 +2,10 @@ package kube
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
 	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,7 +23,7 @@ func CreateDeployment(ctx context.Context, clusterID string, deployment *appsv1.
 		if k8serrors.IsAlreadyExists(err) {
 			return nil, app.NewError(http.StatusConflict, "Deployment already exists")
 		}
-		log.Printf("failed to create deployment %s/%s: %v", deployment.Namespace, deployment.Name, err)
+		logging.Errorf(ctx, "failed to create deployment %s/%s: %v", deployment.Namespace, deployment.Name, err)
 		return nil, app.ErrClusterOperationFailed
 	}
 	return newDeployment, nil
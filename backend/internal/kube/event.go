@@ -0,0 +1,211 @@
+package kube
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EventObject identifies an object events are reported on.
+type EventObject struct {
+	Kind string
+	Name string
+}
+
+// AppEventObjects returns the objects of an app events are reported on: the
+// workload, its ReplicaSets, pods and PVCs.
+func AppEventObjects(ctx context.Context, clusterID, namespace, appSlug string) (map[EventObject]bool, app.Error) {
+	store, e := ClusterStore(ctx, clusterID)
+	if e != nil {
+		return nil, e
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{
+		"ketches.cn/owned": "true",
+		"ketches.cn/app":   appSlug,
+	})
+	result := map[EventObject]bool{
+		{Kind: "Deployment", Name: appSlug}:  true,
+		{Kind: "StatefulSet", Name: appSlug}: true,
+	}
+
+	replicaSets, err := store.ReplicaSetLister().ReplicaSets(namespace).List(selector)
+	if err != nil {
+		logging.Errorf(ctx, "failed to list replicasets of app %s in namespace %s: %v", appSlug, namespace, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+	for _, rs := range replicaSets {
+		result[EventObject{Kind: "ReplicaSet", Name: rs.Name}] = true
+	}
+
+	pods, err := store.PodLister().Pods(namespace).List(selector)
+	if err != nil {
+		logging.Errorf(ctx, "failed to list pods of app %s in namespace %s: %v", appSlug, namespace, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+	for _, pod := range pods {
+		result[EventObject{Kind: "Pod", Name: pod.Name}] = true
+	}
+
+	claims, err := store.PersistentVolumeClaimLister().PersistentVolumeClaims(namespace).List(selector)
+	if err != nil {
+		logging.Errorf(ctx, "failed to list persistent volume claims of app %s in namespace %s: %v", appSlug, namespace, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+	for _, claim := range claims {
+		result[EventObject{Kind: "PersistentVolumeClaim", Name: claim.Name}] = true
+	}
+
+	return result, nil
+}
+
+// ListEvents returns the events of the namespace reported on the objects,
+// the latest first.
+func ListEvents(ctx context.Context, clusterID, namespace string, objects map[EventObject]bool) ([]*models.AppEventModel, app.Error) {
+	store, e := ClusterStore(ctx, clusterID)
+	if e != nil {
+		return nil, e
+	}
+
+	events, err := store.EventLister().Events(namespace).List(labels.Everything())
+	if err != nil {
+		logging.Errorf(ctx, "failed to list events in namespace %s of cluster %s: %v", namespace, clusterID, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	matched := make([]*corev1.Event, 0, len(events))
+	for _, event := range events {
+		if objects[EventObject{Kind: event.InvolvedObject.Kind, Name: event.InvolvedObject.Name}] {
+			matched = append(matched, event)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *corev1.Event) int {
+		return eventLastSeen(b).Compare(eventLastSeen(a))
+	})
+
+	result := make([]*models.AppEventModel, 0, len(matched))
+	for _, event := range matched {
+		result = append(result, AppEventFromEvent(event))
+	}
+	return result, nil
+}
+
+func AppEventFromEvent(event *corev1.Event) *models.AppEventModel {
+	count := event.Count
+	if event.Series != nil {
+		count = event.Series.Count
+	}
+	if count == 0 {
+		count = 1
+	}
+	source := event.Source.Component
+	if source == "" {
+		source = event.ReportingController
+	}
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = eventLastSeen(event)
+	}
+
+	return &models.AppEventModel{
+		Type:       event.Type,
+		Reason:     event.Reason,
+		Message:    event.Message,
+		ObjectKind: event.InvolvedObject.Kind,
+		ObjectName: event.InvolvedObject.Name,
+		Source:     source,
+		Count:      count,
+		FirstSeen:  firstSeen.Format(time.RFC3339),
+		LastSeen:   eventLastSeen(event).Format(time.RFC3339),
+	}
+}
+
+// eventLastSeen returns when the event was last seen, events recorded through
+// the events.k8s.io API only carry the event time and series.
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// handleAppEventSSE pushes the running info of an app to its SSE clients when
+// an event is reported on one of its objects. Events replayed by the initial
+// list of an informer are skipped, they were reported before.
+func handleAppEventSSE(s storeInterface) cache.ResourceEventHandlerDetailedFuncs {
+	handle := func(obj interface{}) {
+		event, ok := obj.(*corev1.Event)
+		if !ok {
+			return
+		}
+		if appID := appIDOfEventObject(s, event.Namespace, event.InvolvedObject); appID != "" {
+			broadcastPodList(appID)
+		}
+	}
+
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				handle(obj)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			handle(newObj)
+		},
+	}
+}
+
+func appIDOfEventObject(s storeInterface, namespace string, ref corev1.ObjectReference) string {
+	var (
+		meta metav1.Object
+		err  error
+	)
+	switch ref.Kind {
+	case "Pod":
+		meta, err = s.PodLister().Pods(namespace).Get(ref.Name)
+	case "ReplicaSet":
+		meta, err = s.ReplicaSetLister().ReplicaSets(namespace).Get(ref.Name)
+	case "Deployment":
+		meta, err = s.DeploymentLister().Deployments(namespace).Get(ref.Name)
+	case "StatefulSet":
+		meta, err = s.StatefulSetLister().StatefulSets(namespace).Get(ref.Name)
+	case "PersistentVolumeClaim":
+		meta, err = s.PersistentVolumeClaimLister().PersistentVolumeClaims(namespace).Get(ref.Name)
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+
+	if appID := meta.GetLabels()["ketches.cn/id"]; appID != "" {
+		return appID
+	}
+	// Claims of StatefulSet claim templates only carry the selector labels,
+	// take the app ID from a pod of the app.
+	appSlug := meta.GetLabels()["ketches.cn/app"]
+	if appSlug == "" {
+		return ""
+	}
+	pods, err := s.PodLister().Pods(namespace).List(labels.SelectorFromSet(labels.Set{"ketches.cn/app": appSlug}))
+	if err != nil || len(pods) == 0 {
+		return ""
+	}
+	return pods[0].Labels["ketches.cn/id"]
+}
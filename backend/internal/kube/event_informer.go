@@ -0,0 +1,117 @@
+package kube
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// eventInformers caches the events of the namespaces owned by ketches, one
+// informer per namespace. Events are not labeled, a cluster-wide informer
+// would cache the events of every namespace of the cluster.
+//
+// It is the event lister of the store, and the handler of the informer of
+// owned namespaces starting and stopping the event informers.
+type eventInformers struct {
+	clientset kubernetes.Interface
+	handler   cache.ResourceEventHandler
+
+	mu         sync.RWMutex
+	namespaces map[string]*namespaceEventInformer
+}
+
+type namespaceEventInformer struct {
+	lister listerscorev1.EventNamespaceLister
+	stop   chan struct{}
+}
+
+var _ listerscorev1.EventLister = (*eventInformers)(nil)
+
+func newEventInformers(clientset kubernetes.Interface) *eventInformers {
+	return &eventInformers{
+		clientset:  clientset,
+		namespaces: make(map[string]*namespaceEventInformer),
+	}
+}
+
+// OnAdd starts the event informer of an owned namespace.
+func (e *eventInformers) OnAdd(obj interface{}, _ bool) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.namespaces[ns.Name]; ok {
+		return
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(e.clientset, 0, informers.WithNamespace(ns.Name))
+	event := factory.Core().V1().Events()
+	if e.handler != nil {
+		event.Informer().AddEventHandler(e.handler)
+	}
+	stop := make(chan struct{})
+	factory.Start(stop)
+
+	e.namespaces[ns.Name] = &namespaceEventInformer{
+		lister: event.Lister().Events(ns.Name),
+		stop:   stop,
+	}
+}
+
+func (e *eventInformers) OnUpdate(_, newObj interface{}) {
+	e.OnAdd(newObj, false)
+}
+
+// OnDelete stops the event informer of a deleted namespace.
+func (e *eventInformers) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if informer, ok := e.namespaces[ns.Name]; ok {
+		close(informer.stop)
+		delete(e.namespaces, ns.Name)
+	}
+}
+
+// List lists the cached events of all owned namespaces.
+func (e *eventInformers) List(selector labels.Selector) ([]*corev1.Event, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var result []*corev1.Event
+	for _, informer := range e.namespaces {
+		events, err := informer.lister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, events...)
+	}
+	return result, nil
+}
+
+// Events returns the lister of the events of a namespace, it lists nothing
+// for namespaces not owned by ketches.
+func (e *eventInformers) Events(namespace string) listerscorev1.EventNamespaceLister {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if informer, ok := e.namespaces[namespace]; ok {
+		return informer.lister
+	}
+	return listerscorev1.NewEventLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})).Events(namespace)
+}
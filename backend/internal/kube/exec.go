@@ -0,0 +1,98 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// maxExecStderr bounds the stderr kept for the error message of Exec.
+const maxExecStderr = 4096
+
+// NewExecutor returns an executor running the command in a container of the
+// pod, stdout is always attached.
+func NewExecutor(ctx context.Context, clusterID, namespace, podName, containerName string, command []string, stdin, tty bool) (remotecommand.Executor, app.Error) {
+	clientset, err := ClusterClientset(ctx, clusterID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := RestConfig(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	execReq := clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdin:     stdin,
+			Stdout:    true,
+			Stderr:    !tty,
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	executor, e := remotecommand.NewSPDYExecutor(restConfig, http.MethodPost, execReq.URL())
+	if e != nil {
+		logging.Errorf(ctx, "failed to create executor for pod %s in namespace %s of cluster %s: %v", podName, namespace, clusterID, e)
+		return nil, app.ErrClusterOperationFailed
+	}
+	return executor, nil
+}
+
+// Exec runs the command in a container of the pod and waits for it to exit.
+// A non-zero exit code is returned as a bad request carrying the stderr of
+// the command.
+func Exec(ctx context.Context, clusterID, namespace, podName, containerName string, command []string, stdin io.Reader, stdout io.Writer) app.Error {
+	executor, err := NewExecutor(ctx, clusterID, namespace, podName, containerName, command, stdin != nil, false)
+	if err != nil {
+		return err
+	}
+
+	stderr := &limitedBuffer{limit: maxExecStderr}
+	if e := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	}); e != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(e, &exitErr) {
+			message := strings.TrimSpace(stderr.String())
+			if message == "" {
+				message = exitErr.Error()
+			}
+			return app.NewError(http.StatusBadRequest, message)
+		}
+		logging.Errorf(ctx, "failed to exec %q in pod %s in namespace %s of cluster %s: %v", command, podName, namespace, clusterID, e)
+		return app.ErrClusterOperationFailed
+	}
+	return nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and drops the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
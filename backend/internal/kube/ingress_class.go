@@ -0,0 +1,36 @@
+package kube
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListIngressClasses returns the ingress classes of the cluster sorted by
+// name.
+func ListIngressClasses(ctx context.Context, clusterID string) ([]*networkingv1.IngressClass, app.Error) {
+	store, err := ClusterStore(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	ingressClasses, e := store.IngressClassLister().List(labels.Everything())
+	if e != nil {
+		logging.Errorf(ctx, "failed to list ingress classes of cluster %s: %v", clusterID, e)
+		return nil, app.ErrClusterOperationFailed
+	}
+	sort.Slice(ingressClasses, func(i, j int) bool {
+		return ingressClasses[i].Name < ingressClasses[j].Name
+	})
+	return ingressClasses, nil
+}
+
+// IsDefaultIngressClass reports whether Ingresses without a class are
+// assigned the ingress class.
+func IsDefaultIngressClass(ingressClass *networkingv1.IngressClass) bool {
+	return ingressClass.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true"
+}
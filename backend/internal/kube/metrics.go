@@ -0,0 +1,76 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodUsage is the CPU and memory usage of a pod as reported by the
+// metrics.k8s.io API.
+type PodUsage struct {
+	CPU        resource.Quantity
+	Memory     resource.Quantity
+	Containers map[string]corev1.ResourceList
+}
+
+// podMetricsList mirrors the parts of the metrics.k8s.io/v1beta1
+// PodMetricsList used, which keeps the metrics client out of the build.
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Containers []struct {
+			Name  string              `json:"name"`
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// ListPodUsage returns the usage of the pods of an app keyed by pod name, or
+// of all pods managed by Ketches in the namespace if appSlug is empty. It
+// fails when the cluster serves no metrics.
+func ListPodUsage(ctx context.Context, clusterID, namespace, appSlug string) (map[string]*PodUsage, app.Error) {
+	clientset, e := ClusterClientset(ctx, clusterID, false)
+	if e != nil {
+		return nil, e
+	}
+
+	selector := labels.Set{"ketches.cn/owned": "true"}
+	if appSlug != "" {
+		selector["ketches.cn/app"] = appSlug
+	}
+
+	data, err := clientset.Discovery().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", namespace, "pods").
+		Param("labelSelector", labels.SelectorFromSet(selector).String()).
+		DoRaw(ctx)
+	if err != nil {
+		logging.Errorf(ctx, "failed to list pod metrics in namespace %s of cluster %s: %v", namespace, clusterID, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		logging.Errorf(ctx, "failed to decode pod metrics in namespace %s of cluster %s: %v", namespace, clusterID, err)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	result := make(map[string]*PodUsage, len(list.Items))
+	for _, item := range list.Items {
+		usage := &PodUsage{Containers: make(map[string]corev1.ResourceList, len(item.Containers))}
+		for _, container := range item.Containers {
+			usage.Containers[container.Name] = container.Usage
+			usage.CPU.Add(*container.Usage.Cpu())
+			usage.Memory.Add(*container.Usage.Memory())
+		}
+		result[item.Metadata.Name] = usage
+	}
+	return result, nil
+}
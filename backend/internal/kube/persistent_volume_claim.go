@@ -2,10 +2,10 @@ package kube
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,7 +23,7 @@ func CreatePersistentVolumeClaim(ctx context.Context, clusterID string, pvc *cor
 		if k8serrors.IsAlreadyExists(err) {
 			return nil, app.NewError(http.StatusConflict, "PersistentVolumeClaim already exists")
 		}
-		log.Printf("failed to create PersistentVolumeClaim %s/%s: %v", pvc.Namespace, pvc.Name, err)
+		logging.Errorf(ctx, "failed to create PersistentVolumeClaim %s/%s: %v", pvc.Namespace, pvc.Name, err)
 		return nil, app.ErrClusterOperationFailed
 	}
 	return newPVC, nil
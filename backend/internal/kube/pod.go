@@ -2,13 +2,17 @@ package kube
 
 import (
 	"context"
-	"log"
+	"net/http"
 	"time"
 
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func ListPods(ctx context.Context, clusterID, namespace, appSlug string) ([]*corev1.Pod, app.Error) {
@@ -21,7 +25,7 @@ func ListPods(ctx context.Context, clusterID, namespace, appSlug string) ([]*cor
 		"ketches.cn/app": appSlug,
 	}))
 	if err != nil {
-		log.Printf("failed to list pods in cluster %s, namespace %s, app %s: %v\n", clusterID, namespace, appSlug, err)
+		logging.Errorf(ctx, "failed to list pods in cluster %s, namespace %s, app %s: %v", clusterID, namespace, appSlug, err)
 		return nil, app.ErrClusterOperationFailed
 	}
 
@@ -35,13 +39,87 @@ func DeletePod(ctx context.Context, clusterID, namespace, podName string) app.Er
 	}
 
 	if err := clientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{}); err != nil {
-		log.Printf("failed to delete pod %s in namespace %s of cluster %s: %v\n", podName, namespace, clusterID, err)
+		logging.Errorf(ctx, "failed to delete pod %s in namespace %s of cluster %s: %v", podName, namespace, clusterID, err)
 		return app.ErrClusterOperationFailed
 	}
 
 	return nil
 }
 
+// AddEphemeralContainer injects an ephemeral container into a running pod,
+// the pod itself is not restarted.
+func AddEphemeralContainer(ctx context.Context, clusterID, namespace, podName string, container corev1.EphemeralContainer) app.Error {
+	clientset, e := ClusterClientset(ctx, clusterID, false)
+	if e != nil {
+		return e
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return app.NewError(http.StatusNotFound, "Instance not found")
+		}
+		logging.Errorf(ctx, "failed to get pod %s in namespace %s of cluster %s: %v", podName, namespace, clusterID, err)
+		return app.ErrClusterOperationFailed
+	}
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+	if _, err := clientset.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{}); err != nil {
+		logging.Errorf(ctx, "failed to add ephemeral container to pod %s in namespace %s of cluster %s: %v", podName, namespace, clusterID, err)
+		if k8serrors.IsInvalid(err) || k8serrors.IsForbidden(err) {
+			return app.NewError(http.StatusBadRequest, "Failed to add debug container: "+err.Error())
+		}
+		return app.ErrClusterOperationFailed
+	}
+
+	return nil
+}
+
+// WaitEphemeralContainerRunning waits until the ephemeral container of the
+// pod runs, it fails early when the container cannot start.
+func WaitEphemeralContainerRunning(ctx context.Context, clusterID, namespace, podName, containerName string, timeout time.Duration) app.Error {
+	clientset, e := ClusterClientset(ctx, clusterID, false)
+	if e != nil {
+		return e
+	}
+
+	var waitErr app.Error
+	if err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, cs := range pod.Status.EphemeralContainerStatuses {
+			if cs.Name != containerName {
+				continue
+			}
+			switch {
+			case cs.State.Running != nil:
+				return true, nil
+			case cs.State.Terminated != nil:
+				waitErr = app.NewError(http.StatusBadRequest, "Debug container terminated: "+cs.State.Terminated.Reason)
+				return true, nil
+			case cs.State.Waiting != nil && isImagePullFailure(cs.State.Waiting.Reason):
+				waitErr = app.NewError(http.StatusBadRequest, "Debug container image cannot be pulled: "+cs.State.Waiting.Message)
+				return true, nil
+			}
+		}
+		return false, nil
+	}); err != nil {
+		logging.Errorf(ctx, "failed to wait for ephemeral container %s of pod %s: %v", containerName, podName, err)
+		return app.NewError(http.StatusGatewayTimeout, "Timed out waiting for the debug container to start")
+	}
+	return waitErr
+}
+
+func isImagePullFailure(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+		return true
+	}
+	return false
+}
+
 type PodStatus string
 
 const (
@@ -150,11 +228,78 @@ func IsAbnormalPod(pod *corev1.Pod) bool {
 	return false
 }
 
+// MainContainer returns the app's main container of the pod, it is marked by
+// the default container annotation and named after the app slug.
 func MainContainer(appSlug string, pod *corev1.Pod) *corev1.Container {
-	for _, container := range pod.Spec.Containers {
-		if container.Name == appSlug {
-			return &container
+	name := pod.Annotations["kubectl.kubernetes.io/default-container"]
+	if name == "" {
+		name = appSlug
+	}
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i]
 		}
 	}
+	if len(pod.Spec.Containers) > 0 {
+		return &pod.Spec.Containers[0]
+	}
 	return nil
 }
+
+// AppInstanceFromPod converts an app pod to an instance model, the main
+// container is listed first, followed by the sidecars.
+func AppInstanceFromPod(appSlug string, pod *corev1.Pod) *models.AppInstanceModel {
+	mainContainer := MainContainer(appSlug, pod)
+	containers := make([]*models.AppInstanceContainerModel, 0, len(pod.Status.ContainerStatuses))
+	initContainers := make([]*models.AppInstanceContainerModel, 0, len(pod.Status.InitContainerStatuses))
+	for _, container := range pod.Status.ContainerStatuses {
+		model := &models.AppInstanceContainerModel{
+			ContainerName: container.Name,
+			ContainerType: app.AppContainerTypeSidecar,
+			Image:         container.Image,
+			Status:        GetContainerStatus(&container),
+		}
+		if mainContainer != nil && container.Name == mainContainer.Name {
+			model.ContainerType = app.AppContainerTypeMain
+			containers = append([]*models.AppInstanceContainerModel{model}, containers...)
+			continue
+		}
+		containers = append(containers, model)
+	}
+	for _, container := range pod.Status.EphemeralContainerStatuses {
+		containers = append(containers, &models.AppInstanceContainerModel{
+			ContainerName: container.Name,
+			ContainerType: app.AppContainerTypeDebug,
+			Image:         container.Image,
+			Status:        GetContainerStatus(&container),
+		})
+	}
+	for _, container := range pod.Status.InitContainerStatuses {
+		initContainers = append(initContainers, &models.AppInstanceContainerModel{
+			ContainerName: container.Name,
+			ContainerType: app.AppContainerTypeInit,
+			Image:         container.Image,
+			Status:        GetContainerStatus(&container),
+		})
+	}
+
+	result := &models.AppInstanceModel{
+		InstanceName:   pod.Name,
+		Status:         GetPodStatus(pod),
+		CreatedAt:      pod.CreationTimestamp.Time,
+		InstanceIP:     pod.Status.PodIP,
+		Containers:     containers,
+		InitContainers: initContainers,
+		NodeName:       pod.Spec.NodeName,
+		NodeIP:         pod.Status.HostIP,
+		ContainerCount: len(pod.Status.ContainerStatuses),
+		Edition:        pod.Labels["ketches.cn/edition"],
+	}
+	if mainContainer != nil {
+		result.RequestCPU = mainContainer.Resources.Requests.Cpu().String()
+		result.RequestMemory = mainContainer.Resources.Requests.Memory().String()
+		result.LimitCPU = mainContainer.Resources.Limits.Cpu().String()
+		result.LimitMemory = mainContainer.Resources.Limits.Memory().String()
+	}
+	return result
+}
@@ -2,10 +2,10 @@ package kube
 
 import (
 	"context"
-	"log"
-	"sort"
 	"sync"
 
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/metrics"
 	"github.com/ketches/ketches/internal/models"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -59,45 +59,7 @@ func processPodForAppRunningInfoSSE(pod *corev1.Pod) {
 		return
 	}
 
-	mainContainer := MainContainer(appSlug, pod)
-	containers := make([]*models.AppInstanceContainerModel, 0, len(pod.Spec.Containers))
-	initContainers := make([]*models.AppInstanceContainerModel, 0, len(pod.Spec.InitContainers))
-	for _, container := range pod.Status.ContainerStatuses {
-		containers = append(containers, &models.AppInstanceContainerModel{
-			ContainerName: container.Name,
-			Image:         container.Image,
-			Status:        GetContainerStatus(&container),
-		})
-	}
-
-	// let mainContainer always be the first in the list
-	sort.Slice(containers, func(i, j int) bool {
-		return containers[i].ContainerName == mainContainer.Name
-	})
-	for _, container := range pod.Status.InitContainerStatuses {
-		initContainers = append(initContainers, &models.AppInstanceContainerModel{
-			ContainerName: container.Name,
-			Image:         container.Image,
-			Status:        GetContainerStatus(&container),
-		})
-	}
-	instance := &models.AppInstanceModel{
-		InstanceName:   pod.Name,
-		Status:         GetPodStatus(pod),
-		CreatedAt:      pod.CreationTimestamp.Time,
-		InstanceIP:     pod.Status.PodIP,
-		Containers:     containers,
-		InitContainers: initContainers,
-		NodeName:       pod.Spec.NodeName,
-		NodeIP:         pod.Status.HostIP,
-		ContainerCount: len(pod.Status.ContainerStatuses),
-		RequestCPU:     mainContainer.Resources.Requests.Cpu().String(),
-		RequestMemory:  mainContainer.Resources.Requests.Memory().String(),
-		LimitCPU:       mainContainer.Resources.Limits.Cpu().String(),
-		LimitMemory:    mainContainer.Resources.Limits.Memory().String(),
-		Edition:        pod.Labels["ketches.cn/edition"],
-	}
-
+	instance := AppInstanceFromPod(appSlug, pod)
 	GetAppInstanceSSEClients().saveAppInstance(appID, instance)
 	broadcastPodList(appID)
 }
@@ -116,7 +78,7 @@ func broadcastPodList(appID string) {
 		select {
 		case client.MsgChan <- instances:
 		default:
-			log.Printf("app instance sse client channel for app id %s is full, skipping broadcast", appID)
+			logging.Warnf(client.Ctx, "app instance sse client channel for app id %s is full, skipping broadcast", appID)
 		}
 	}
 }
@@ -176,6 +138,7 @@ func (c *AppInstanceSSEClients) Register(ctx context.Context, appID string) *App
 
 	// Add the client to the map
 	c.List[client] = struct{}{}
+	metrics.SSESessions.WithLabelValues("running_info").Inc()
 	return client
 }
 
@@ -187,6 +150,7 @@ func (c *AppInstanceSSEClients) Remove(client *AppInstanceSSEClient) {
 	if _, exists := c.List[client]; exists {
 		close(client.MsgChan) // Close the channel to signal no more messages
 		delete(c.List, client)
+		metrics.SSESessions.WithLabelValues("running_info").Dec()
 	}
 }
 
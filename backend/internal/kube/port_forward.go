@@ -0,0 +1,92 @@
+package kube
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// DialPodPort opens a stream to a port of the pod through the portforward
+// subresource. Each stream has its own connection to the API server, closing
+// the stream closes the connection.
+func DialPodPort(ctx context.Context, clusterID, namespace, podName string, port int) (io.ReadWriteCloser, app.Error) {
+	clientset, err := ClusterClientset(ctx, clusterID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := RestConfig(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, e := spdy.RoundTripperFor(restConfig)
+	if e != nil {
+		logging.Errorf(ctx, "failed to create round tripper for cluster %s: %v", clusterID, e)
+		return nil, app.ErrClusterOperationFailed
+	}
+	url := clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+	conn, _, e := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if e != nil {
+		logging.Errorf(ctx, "failed to dial port forward of pod %s in namespace %s of cluster %s: %v", podName, namespace, clusterID, e)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(port))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, e := conn.CreateStream(headers)
+	if e != nil {
+		conn.Close()
+		logging.Errorf(ctx, "failed to create error stream of pod %s port %d: %v", podName, port, e)
+		return nil, app.ErrClusterOperationFailed
+	}
+	// Nothing is written to the error stream
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, e := conn.CreateStream(headers)
+	if e != nil {
+		conn.Close()
+		logging.Errorf(ctx, "failed to create data stream of pod %s port %d: %v", podName, port, e)
+		return nil, app.ErrClusterOperationFailed
+	}
+
+	// The kubelet reports failures to reach the port on the error stream,
+	// e.g. when nothing listens on it.
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		if err == nil && len(message) > 0 {
+			logging.Errorf(ctx, "port forward of pod %s port %d failed: %s", podName, port, message)
+			conn.Close()
+		}
+	}()
+
+	return &podPortStream{Stream: dataStream, conn: conn}, nil
+}
+
+type podPortStream struct {
+	httpstream.Stream
+	conn httpstream.Connection
+}
+
+func (s *podPortStream) Close() error {
+	s.Stream.Close()
+	return s.conn.Close()
+}
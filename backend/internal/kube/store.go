@@ -3,12 +3,14 @@ package kube
 import (
 	"sync"
 
+	"github.com/ketches/ketches/internal/metrics"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	appsv1 "k8s.io/client-go/listers/apps/v1"
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	listersnetworkingv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -24,6 +26,8 @@ type storeInterface interface {
 
 	// Kubernetes resource listers
 	NodeLister() listerscorev1.NodeLister
+	EventLister() listerscorev1.EventLister
+	IngressClassLister() listersnetworkingv1.IngressClassLister
 }
 
 type store struct {
@@ -35,7 +39,9 @@ type store struct {
 	configMapLister             listerscorev1.ConfigMapLister
 	persistentVolumeClaimLister listerscorev1.PersistentVolumeClaimLister
 
-	nodeLister listerscorev1.NodeLister
+	nodeLister         listerscorev1.NodeLister
+	eventLister        listerscorev1.EventLister
+	ingressClassLister listersnetworkingv1.IngressClassLister
 }
 
 func (s *store) DeploymentLister() appsv1.DeploymentLister {
@@ -70,7 +76,15 @@ func (s *store) NodeLister() listerscorev1.NodeLister {
 	return s.nodeLister
 }
 
-func loadStore(clientset kubernetes.Interface) storeInterface {
+func (s *store) EventLister() listerscorev1.EventLister {
+	return s.eventLister
+}
+
+func (s *store) IngressClassLister() listersnetworkingv1.IngressClassLister {
+	return s.ingressClassLister
+}
+
+func loadStore(clusterID string, clientset kubernetes.Interface) storeInterface {
 	ketchesOwnedResourceInformerFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithTweakListOptions(func(options *metav1.ListOptions) {
 		options.LabelSelector = "ketches.cn/owned=true"
 	}))
@@ -92,31 +106,39 @@ func loadStore(clientset kubernetes.Interface) storeInterface {
 	configMapInformer := configMap.Informer()
 	persistentVolumeClaim := ketchesOwnedResourceInformerFactory.Core().V1().PersistentVolumeClaims()
 	persistentVolumeClaimInformer := persistentVolumeClaim.Informer()
+	namespaceInformer := ketchesOwnedResourceInformerFactory.Core().V1().Namespaces().Informer()
 
 	node := kubeInformerFactory.Core().V1().Nodes()
 	nodeInformer := node.Informer()
+	ingressClass := kubeInformerFactory.Networking().V1().IngressClasses()
+	ingressClassInformer := ingressClass.Informer()
 
 	ketchesOwnedResourceInformerFactory.Start(wait.NeverStop)
 	kubeInformerFactory.Start(wait.NeverStop)
 
-	sharedInformers := []cache.SharedInformer{
-		deploymentInformer,
-		replicaSetInformer,
-		statefulSetInformer,
-		podInformer,
-		serviceInformer,
-		configMapInformer,
-		persistentVolumeClaimInformer,
-
-		nodeInformer,
+	sharedInformers := map[string]cache.SharedInformer{
+		"deployments":            deploymentInformer,
+		"replicasets":            replicaSetInformer,
+		"statefulsets":           statefulSetInformer,
+		"pods":                   podInformer,
+		"services":               serviceInformer,
+		"configmaps":             configMapInformer,
+		"persistentvolumeclaims": persistentVolumeClaimInformer,
+		"namespaces":             namespaceInformer,
+
+		"nodes":          nodeInformer,
+		"ingressclasses": ingressClassInformer,
 	}
 	var wg sync.WaitGroup
 	wg.Add(len(sharedInformers))
-	for _, si := range sharedInformers {
+	for resource, si := range sharedInformers {
+		synced := metrics.InformerSynced.WithLabelValues(clusterID, resource)
+		synced.Set(0)
 		go func(si cache.SharedInformer) {
 			if !cache.WaitForCacheSync(wait.NeverStop, si.HasSynced) {
 				panic("timed out waiting for caches to sync")
 			}
+			synced.Set(1)
 			wg.Done()
 		}(si)
 	}
@@ -134,8 +156,12 @@ func loadStore(clientset kubernetes.Interface) storeInterface {
 	persistentVolumeClaimLister := persistentVolumeClaim.Lister()
 
 	nodeLister := node.Lister()
+	ingressClassLister := ingressClass.Lister()
+
+	// Events are not labeled, they are cached per owned namespace
+	events := newEventInformers(clientset)
 
-	return &store{
+	result := &store{
 		deploymentLister:            deploymentLister,
 		replicaSetLister:            replicaSetLister,
 		statefulSetLister:           statefulSetLister,
@@ -144,6 +170,11 @@ func loadStore(clientset kubernetes.Interface) storeInterface {
 		configMapLister:             configMapLister,
 		persistentVolumeClaimLister: persistentVolumeClaimLister,
 
-		nodeLister: nodeLister,
+		nodeLister:         nodeLister,
+		eventLister:        events,
+		ingressClassLister: ingressClassLister,
 	}
+	events.handler = handleAppEventSSE(result)
+	namespaceInformer.AddEventHandler(events)
+	return result
 }
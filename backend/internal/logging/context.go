@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ketches/ketches/internal/api"
+)
+
+type contextKey struct{}
+
+// With returns a context whose records carry the attributes, e.g.
+// With(ctx, "cluster_id", clusterID).
+func With(ctx context.Context, args ...any) context.Context {
+	attrs := append(attrsFromContext(ctx), argsToAttrs(args)...)
+	return context.WithValue(ctx, contextKey{}, attrs)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs[:len(attrs):len(attrs)]
+}
+
+func argsToAttrs(args []any) []slog.Attr {
+	var attrs []slog.Attr
+	r := slog.Record{}
+	r.Add(args...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
+
+// contextHandler adds the request scope of the context to records.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		for _, field := range []struct {
+			key   string
+			value string
+		}{
+			{"request_id", api.RequestID(ctx)},
+			{"user_id", api.UserID(ctx)},
+			{"project_id", api.ProjectID(ctx)},
+			{"env_id", api.EnvID(ctx)},
+			{"app_id", api.AppID(ctx)},
+		} {
+			if field.value != "" {
+				r.AddAttrs(slog.String(field.key, field.value))
+			}
+		}
+		r.AddAttrs(attrsFromContext(ctx)...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,90 @@
+// Package logging sets up the structured logger of the API server. Records
+// logged with a context carry the request ID, user, project, env and app of
+// the request, and secrets are redacted from all records.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/pkg/redact"
+)
+
+// Setup installs the default logger, text in dev run mode and JSON otherwise,
+// at the level of APP_LOG_LEVEL. Output of the standard log package goes
+// through it as well.
+func Setup() {
+	slog.SetDefault(slog.New(NewHandler(os.Stderr, app.GetEnv("APP_RUNMODE", "dev") != "dev", parseLevel(app.GetEnv("APP_LOG_LEVEL", "info")))))
+}
+
+// NewHandler returns the handler writing records to w.
+func NewHandler(w io.Writer, json bool, level slog.Leveler) slog.Handler {
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}
+	var handler slog.Handler
+	if json {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	return &contextHandler{Handler: handler}
+}
+
+func parseLevel(s string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(s))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// redactAttr is the central filter masking secrets, by key and inside
+// messages and string values.
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if redact.Key(a.Key) {
+		return slog.String(a.Key, redact.Mask)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, redact.String(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, redact.String(err.Error()))
+		}
+		if s, ok := a.Value.Any().(fmt.Stringer); ok {
+			return slog.String(a.Key, redact.String(s.String()))
+		}
+	}
+	return a
+}
+
+func Debugf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelDebug, format, args...)
+}
+
+func Infof(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelInfo, format, args...)
+}
+
+func Warnf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelWarn, format, args...)
+}
+
+func Errorf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelError, format, args...)
+}
+
+func logf(ctx context.Context, level slog.Level, format string, args ...any) {
+	logger := slog.Default()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	logger.Log(ctx, level, strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"net/http"
+
+	"k8s.io/client-go/transport"
+)
+
+// KubeTransport wraps the transport of the clients of a cluster to count
+// requests failing to connect or answered with a server error.
+func KubeTransport(clusterID string) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &kubeRoundTripper{clusterID: clusterID, next: rt}
+	}
+}
+
+type kubeRoundTripper struct {
+	clusterID string
+	next      http.RoundTripper
+}
+
+func (rt *kubeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	// Requests canceled by the caller are not cluster errors.
+	failed := err != nil && req.Context().Err() == nil
+	if failed || err == nil && resp.StatusCode >= http.StatusInternalServerError {
+		KubeClientErrors.WithLabelValues(rt.clusterID).Inc()
+	}
+	return resp, err
+}
@@ -0,0 +1,101 @@
+// Package metrics exposes the Prometheus metrics of the API server.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ketches"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests counts HTTP requests by route template, so requests of
+	// different resources share one series.
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "code"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by method and route template, streaming requests last as long as the stream.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// SSESessions tracks open server-sent event streams by stream, e.g.
+	// "running_info" or "logs".
+	SSESessions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sse_sessions",
+		Help:      "Number of open server-sent event streams.",
+	}, []string{"stream"})
+
+	// WebSocketSessions tracks open websocket sessions by kind, e.g.
+	// "terminal" or "port_forward".
+	WebSocketSessions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "websocket_sessions",
+		Help:      "Number of open websocket sessions.",
+	}, []string{"kind"})
+
+	KubeClientErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kube_client_errors_total",
+		Help:      "Number of Kubernetes API requests that failed to connect or got a server error, by cluster.",
+	}, []string{"cluster"})
+
+	InformerSynced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kube_informer_synced",
+		Help:      "Whether the informer cache of a resource of a cluster has synced, 1 once synced.",
+	}, []string{"cluster", "resource"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Latency of database statements by operation.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	AppDeploys = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_deploys_total",
+		Help:      "Number of app deployments by cluster and result, which is success or failure.",
+	}, []string{"cluster", "result"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		SSESessions,
+		WebSocketSessions,
+		KubeClientErrors,
+		InformerSynced,
+		DBQueryDuration,
+		AppDeploys,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveDeploy counts a deployment of an app to the cluster.
+func ObserveDeploy(clusterID string, failed bool) {
+	result := "success"
+	if failed {
+		result = "failure"
+	}
+	AppDeploys.WithLabelValues(clusterID, result).Inc()
+}
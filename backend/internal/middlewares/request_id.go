@@ -1,14 +1,30 @@
 package middlewares
 
 import (
+	"regexp"
+
 	"github.com/gin-gonic/gin"
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/pkg/uuid"
 )
 
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID limits the request IDs taken from clients or proxies, so they
+// can not inject into logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware sets the request ID, taken from the X-Request-ID header
+// of the request if valid, and the project, env and app IDs of the route.
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		api.SetRequestID(c, uuid.New())
+		requestID := c.GetHeader(requestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New()
+		}
+		api.SetRequestID(c, requestID)
+		c.Header(requestIDHeader, requestID)
+		api.SetResourceIDs(c)
 		c.Next()
 	}
 }
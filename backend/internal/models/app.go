@@ -123,14 +123,36 @@ type DeleteAppRequest struct {
 }
 
 type AppActionRequest struct {
-	AppID  string        `json:"-" uri:"appID"`
-	Action app.AppAction `json:"action" binding:"required"`
+	AppID        string        `json:"-" uri:"appID"`
+	Action       app.AppAction `json:"action" binding:"required"`
+	DryRun       bool          `json:"-" form:"dryRun"`        // Preview the changes instead of applying them
+	PruneVolumes bool          `json:"pruneVolumes,omitempty"` // Delete PVCs of volumes removed from the app
+	Force        bool          `json:"force,omitempty"`        // Take over fields managed by other controllers, admin only
+}
+
+type AppManifestDiffModel struct {
+	Kind          string   `json:"kind"`
+	Name          string   `json:"name"`
+	Namespace     string   `json:"namespace,omitempty"`
+	Action        string   `json:"action"`                  // e.g., "create", "update", "unchanged", "prune", "failed"
+	ChangedFields []string `json:"changedFields,omitempty"` // Field paths that differ from the live object
+	Message       string   `json:"message,omitempty"`
+}
+
+type AppActionPreviewResponse struct {
+	AppID   string                  `json:"appID"`
+	Slug    string                  `json:"slug"`
+	Action  string                  `json:"action"`
+	Edition string                  `json:"edition,omitempty"`
+	Objects []*AppManifestDiffModel `json:"objects"`
 }
 
 type AppInstanceContainerModel struct {
-	ContainerName string `json:"containerName"`
-	Image         string `json:"image"`
-	Status        string `json:"status"`
+	ContainerName string              `json:"containerName"`
+	ContainerType string              `json:"containerType"` // e.g., "main", "sidecar", "init", "debug"
+	Image         string              `json:"image"`
+	Status        string              `json:"status"`
+	Usage         *ResourceUsageModel `json:"usage,omitempty"` // Actual usage, omitted without metrics
 }
 
 type AppInstanceModel struct {
@@ -149,6 +171,7 @@ type AppInstanceModel struct {
 	LimitCPU        string                       `json:"limitCPU,omitempty"`      // e.g., "1", "2", "4"
 	LimitMemory     string                       `json:"limitMemory,omitempty"`   // e.g., "512Mi", "1Gi", "2Gi"
 	Edition         string                       `json:"revision,omitempty"`
+	Usage           *ResourceUsageModel          `json:"usage,omitempty"` // Actual usage of all containers, omitted without metrics
 }
 
 type ListAppInstancesRequest struct {
@@ -175,6 +198,7 @@ type GetAppRunningInfoResponse struct {
 	ActualEdition  string              `json:"actualEdition"`  // Edition of the currently running app
 	Status         string              `json:"status"`         // e.g., "running", "stopped", "starting", "stopping"
 	Instances      []*AppInstanceModel `json:"instances"`
+	Events         []*AppEventModel    `json:"events"` // Latest events of the app, explaining abnormal instances
 }
 
 type TerminateAppInstanceRequest struct {
@@ -193,6 +217,7 @@ type ViewAppContainerLogsRequest struct {
 	SinceSeconds   int64               `form:"sinceSeconds"`   // Fetch logs since this many seconds ago
 	SinceTime      time.Time           `form:"sinceTime"`      // Fetch logs since this time (RFC3339 format)
 	Limit          int64               `form:"limit"`          // Number of log lines to return
+	LimitBytes     int64               `form:"limitBytes"`     // Maximum bytes of logs to return
 	ShowTimestamps bool                `form:"showTimestamps"` // Whether to include timestamps in logs
 	Previous       bool                `form:"previous"`       // Whether to fetch logs from the previous instance of the container
 }
@@ -203,4 +228,21 @@ type ExecAppContainerTerminalRequest struct {
 	AppID          string              `uri:"appID"`
 	InstanceName   string              `uri:"instanceName"`
 	ContainerName  string              `uri:"containerName"`
+	Command        string              `form:"command"` // Shell or command to run, e.g. "bash", defaults to the first shell found
+	Cols           uint16              `form:"cols"`    // Initial terminal width
+	Rows           uint16              `form:"rows"`    // Initial terminal height
+}
+
+type DebugAppInstanceRequest struct {
+	AppID           string `json:"-" uri:"appID"`
+	InstanceName    string `json:"-" uri:"instanceName"`
+	Image           string `json:"image,omitempty"`           // Debug image, defaults to APP_DEBUG_IMAGE
+	TargetContainer string `json:"targetContainer,omitempty"` // Container whose process namespace is shared, defaults to the main container
+}
+
+type DebugAppInstanceResponse struct {
+	InstanceName    string `json:"instanceName"`
+	ContainerName   string `json:"containerName"` // Connect the exec terminal to this container
+	Image           string `json:"image"`
+	TargetContainer string `json:"targetContainer"`
 }
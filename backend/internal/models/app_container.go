@@ -0,0 +1,69 @@
+package models
+
+type AppContainerEnvVarModel struct {
+	Key   string `json:"key" binding:"required"`
+	Value string `json:"value"`
+}
+
+// AppContainerVolumeMountModel mounts an app volume or config file, referenced
+// by its slug, into a sidecar or init container.
+type AppContainerVolumeMountModel struct {
+	VolumeSlug string `json:"volumeSlug" binding:"required"`
+	MountPath  string `json:"mountPath" binding:"required"`
+	SubPath    string `json:"subPath,omitempty"`
+	ReadOnly   bool   `json:"readOnly,omitempty"`
+}
+
+type AppContainerModel struct {
+	ContainerID      string                          `json:"containerID"`
+	AppID            string                          `json:"appID"`
+	Slug             string                          `json:"slug"`
+	ContainerType    string                          `json:"containerType"` // sidecar, init
+	ContainerImage   string                          `json:"containerImage"`
+	ContainerCommand string                          `json:"containerCommand,omitempty"`
+	RequestCPU       int32                           `json:"requestCPU,omitempty"`    // in milliCPU
+	RequestMemory    int32                           `json:"requestMemory,omitempty"` // in MiB
+	LimitCPU         int32                           `json:"limitCPU,omitempty"`      // in milliCPU
+	LimitMemory      int32                           `json:"limitMemory,omitempty"`   // in MiB
+	EnvVars          []*AppContainerEnvVarModel      `json:"envVars,omitempty"`
+	VolumeMounts     []*AppContainerVolumeMountModel `json:"volumeMounts,omitempty"`
+	SortOrder        int                             `json:"sortOrder"`
+}
+
+type ListAppContainersRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type CreateAppContainerRequest struct {
+	AppID            string                          `json:"-" uri:"appID"`
+	Slug             string                          `json:"slug" binding:"required,slug"`
+	ContainerType    string                          `json:"containerType" binding:"required,oneof=sidecar init"`
+	ContainerImage   string                          `json:"containerImage" binding:"required"`
+	ContainerCommand string                          `json:"containerCommand,omitempty"`
+	RequestCPU       int32                           `json:"requestCPU,omitempty" binding:"min=0"`
+	RequestMemory    int32                           `json:"requestMemory,omitempty" binding:"min=0"`
+	LimitCPU         int32                           `json:"limitCPU,omitempty" binding:"min=0"`
+	LimitMemory      int32                           `json:"limitMemory,omitempty" binding:"min=0"`
+	EnvVars          []*AppContainerEnvVarModel      `json:"envVars,omitempty" binding:"dive"`
+	VolumeMounts     []*AppContainerVolumeMountModel `json:"volumeMounts,omitempty" binding:"dive"`
+	SortOrder        int                             `json:"sortOrder,omitempty"`
+}
+
+type UpdateAppContainerRequest struct {
+	AppID            string                          `json:"-" uri:"appID"`
+	ContainerID      string                          `json:"-" uri:"containerID"`
+	ContainerImage   string                          `json:"containerImage" binding:"required"`
+	ContainerCommand string                          `json:"containerCommand,omitempty"`
+	RequestCPU       int32                           `json:"requestCPU,omitempty" binding:"min=0"`
+	RequestMemory    int32                           `json:"requestMemory,omitempty" binding:"min=0"`
+	LimitCPU         int32                           `json:"limitCPU,omitempty" binding:"min=0"`
+	LimitMemory      int32                           `json:"limitMemory,omitempty" binding:"min=0"`
+	EnvVars          []*AppContainerEnvVarModel      `json:"envVars,omitempty" binding:"dive"`
+	VolumeMounts     []*AppContainerVolumeMountModel `json:"volumeMounts,omitempty" binding:"dive"`
+	SortOrder        int                             `json:"sortOrder,omitempty"`
+}
+
+type DeleteAppContainerRequest struct {
+	AppID       string `json:"-" uri:"appID" binding:"required"`
+	ContainerID string `json:"-" uri:"containerID" binding:"required"`
+}
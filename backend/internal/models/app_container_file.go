@@ -0,0 +1,36 @@
+package models
+
+import "net/http"
+
+type AppContainerFileModel struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"` // e.g., "file", "directory", "symlink", "other"
+	Size       int64  `json:"size"`
+	Mode       string `json:"mode"`       // Octal permission bits, e.g. "644"
+	ModifiedAt string `json:"modifiedAt"` // ISO 8601 format
+}
+
+type ListAppContainerFilesRequest struct {
+	AppID         string `uri:"appID"`
+	InstanceName  string `uri:"instanceName"`
+	ContainerName string `uri:"containerName"`
+	Path          string `form:"path" binding:"required"` // Absolute path of the directory
+}
+
+type DownloadAppContainerFilesRequest struct {
+	Request        *http.Request       `json:"-" form:"-" uri:"-"`
+	ResponseWriter http.ResponseWriter `json:"-" form:"-" uri:"-"`
+	AppID          string              `uri:"appID"`
+	InstanceName   string              `uri:"instanceName"`
+	ContainerName  string              `uri:"containerName"`
+	Path           string              `form:"path" binding:"required"` // Absolute path of the file or directory, downloaded as a tar archive
+}
+
+type UploadAppContainerFilesRequest struct {
+	Request        *http.Request       `json:"-" form:"-" uri:"-"`
+	ResponseWriter http.ResponseWriter `json:"-" form:"-" uri:"-"`
+	AppID          string              `uri:"appID"`
+	InstanceName   string              `uri:"instanceName"`
+	ContainerName  string              `uri:"containerName"`
+	Path           string              `form:"path" binding:"required"` // Absolute path of the directory the tar archive is extracted to
+}
@@ -0,0 +1,68 @@
+package models
+
+import "github.com/ketches/ketches/internal/app"
+
+type AppDependencyModel struct {
+	DependencyID   string   `json:"dependencyID"`
+	AppID          string   `json:"appID"`
+	DependsOnAppID string   `json:"dependsOnAppID"`
+	Slug           string   `json:"slug"` // Slug of the app depended on
+	DisplayName    string   `json:"displayName"`
+	EnvID          string   `json:"envID"`
+	EnvSlug        string   `json:"envSlug"`
+	EnvPrefix      string   `json:"envPrefix"`
+	Host           string   `json:"host"`            // Service DNS name of the app depended on
+	Ports          []int32  `json:"ports,omitempty"` // Service ports of the app depended on
+	EnvVars        []string `json:"envVars"`         // Keys of the env vars injected into the app
+}
+
+type ListAppDependenciesRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type CreateAppDependencyRequest struct {
+	AppID          string `json:"-" uri:"appID"`
+	DependsOnAppID string `json:"dependsOnAppID" binding:"required"`
+	EnvPrefix      string `json:"envPrefix,omitempty" binding:"omitempty,max=64"` // Defaults to the upper snake case slug of the app depended on
+}
+
+type DeleteAppDependencyRequest struct {
+	AppID        string `uri:"appID" binding:"required"`
+	DependencyID string `uri:"dependencyID" binding:"required"`
+}
+
+type GetEnvDependencyGraphRequest struct {
+	EnvID string `uri:"envID" binding:"required"`
+}
+
+type AppDependencyGraphNode struct {
+	AppID       string `json:"appID"`
+	Slug        string `json:"slug"`
+	DisplayName string `json:"displayName"`
+	EnvID       string `json:"envID"`
+	EnvSlug     string `json:"envSlug"`
+	External    bool   `json:"external,omitempty"` // App of another env depended on by apps of the env
+	Layer       int    `json:"layer"`              // Start order of the app in the env, apps of a layer only depend on apps of lower layers
+}
+
+type AppDependencyGraphEdge struct {
+	AppID          string `json:"appID"`
+	DependsOnAppID string `json:"dependsOnAppID"`
+}
+
+type AppDependencyGraphModel struct {
+	EnvID string                    `json:"envID"`
+	Nodes []*AppDependencyGraphNode `json:"nodes"`
+	Edges []*AppDependencyGraphEdge `json:"edges"`
+}
+
+type EnvActionRequest struct {
+	EnvID  string        `json:"-" uri:"envID"`
+	Action app.EnvAction `json:"action" binding:"required,oneof=start stop"`
+}
+
+type EnvActionResponse struct {
+	EnvID  string      `json:"envID"`
+	Action string      `json:"action"`
+	Layers [][]*AppRef `json:"layers"` // Apps in the order they are started or stopped, layer by layer
+}
@@ -0,0 +1,19 @@
+package models
+
+type AppEventModel struct {
+	Type       string `json:"type"` // e.g., "Normal", "Warning"
+	Reason     string `json:"reason"`
+	Message    string `json:"message"`
+	ObjectKind string `json:"objectKind"` // Kind of the object the event is reported on, e.g. "Pod"
+	ObjectName string `json:"objectName"`
+	Source     string `json:"source,omitempty"`
+	Count      int32  `json:"count"`
+	FirstSeen  string `json:"firstSeen"` // ISO 8601 format
+	LastSeen   string `json:"lastSeen"`  // ISO 8601 format
+}
+
+type ListAppEventsRequest struct {
+	AppID        string `uri:"appID"`
+	InstanceName string `form:"instanceName"`                                  // Only events of this instance
+	Type         string `form:"type" binding:"omitempty,oneof=Normal Warning"` // Only events of this type
+}
@@ -1,15 +1,83 @@
 package models
 
+// AppGatewayRuleModel is a rule of the HTTP route of a gateway. Requests
+// matching any of its matches, or the gateway path if none, are redirected or
+// routed to its backends, the app itself if none.
+type AppGatewayRuleModel struct {
+	Matches         []*AppGatewayMatchModel        `json:"matches,omitempty" binding:"omitempty,dive"`
+	RequestHeaders  *AppGatewayHeaderModifierModel `json:"requestHeaders,omitempty"`
+	ResponseHeaders *AppGatewayHeaderModifierModel `json:"responseHeaders,omitempty"`
+	Rewrite         *AppGatewayRewriteModel        `json:"rewrite,omitempty"`
+	Redirect        *AppGatewayRedirectModel       `json:"redirect,omitempty"`
+	Mirror          *AppGatewayMirrorModel         `json:"mirror,omitempty"`
+	Backends        []*AppGatewayBackendModel      `json:"backends,omitempty" binding:"omitempty,dive"`
+}
+
+type AppGatewayMatchModel struct {
+	PathType    string                       `json:"pathType,omitempty" binding:"omitempty,oneof=Exact PathPrefix RegularExpression"` // Defaults to PathPrefix
+	Path        string                       `json:"path,omitempty"`
+	Method      string                       `json:"method,omitempty" binding:"omitempty,oneof=GET HEAD POST PUT DELETE CONNECT OPTIONS TRACE PATCH"`
+	Headers     []*AppGatewayValueMatchModel `json:"headers,omitempty" binding:"omitempty,dive"`
+	QueryParams []*AppGatewayValueMatchModel `json:"queryParams,omitempty" binding:"omitempty,dive"`
+}
+
+type AppGatewayValueMatchModel struct {
+	Type  string `json:"type,omitempty" binding:"omitempty,oneof=Exact RegularExpression"` // Defaults to Exact
+	Name  string `json:"name" binding:"required"`
+	Value string `json:"value" binding:"required"`
+}
+
+type AppGatewayHeaderModifierModel struct {
+	Set    []*AppGatewayHeaderModel `json:"set,omitempty" binding:"omitempty,dive"`
+	Add    []*AppGatewayHeaderModel `json:"add,omitempty" binding:"omitempty,dive"`
+	Remove []string                 `json:"remove,omitempty"`
+}
+
+type AppGatewayHeaderModel struct {
+	Name  string `json:"name" binding:"required"`
+	Value string `json:"value"`
+}
+
+type AppGatewayRewriteModel struct {
+	Hostname string `json:"hostname,omitempty"`
+	PathType string `json:"pathType,omitempty" binding:"omitempty,oneof=ReplaceFullPath ReplacePrefixMatch"`
+	Path     string `json:"path,omitempty"`
+}
+
+type AppGatewayRedirectModel struct {
+	Scheme     string `json:"scheme,omitempty" binding:"omitempty,oneof=http https"`
+	Hostname   string `json:"hostname,omitempty"`
+	Port       int32  `json:"port,omitempty" binding:"omitempty,min=1,max=65535"`
+	PathType   string `json:"pathType,omitempty" binding:"omitempty,oneof=ReplaceFullPath ReplacePrefixMatch"`
+	Path       string `json:"path,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty" binding:"omitempty,oneof=301 302"` // Defaults to 302
+}
+
+type AppGatewayMirrorModel struct {
+	AppID   string `json:"appID,omitempty"`                                    // App of the same env, the app itself if empty
+	Port    int32  `json:"port,omitempty" binding:"omitempty,min=1,max=65535"` // Service port, required for other apps
+	Percent *int32 `json:"percent,omitempty" binding:"omitempty,min=0,max=100"`
+}
+
+type AppGatewayBackendModel struct {
+	AppID  string `json:"appID,omitempty"`                                    // App of the same env, the app itself if empty
+	Port   int32  `json:"port,omitempty" binding:"omitempty,min=1,max=65535"` // Service port, required for other apps
+	Weight *int32 `json:"weight,omitempty" binding:"omitempty,min=0,max=1000000"`
+}
+
 type AppGatewayModel struct {
 	AppID       string `json:"appID"`
 	GatewayID   string `json:"gatewayID"`
 	Port        int32  `json:"port"`
+	PortName    string `json:"portName,omitempty"` // Name of the app port routed to
 	Protocol    string `json:"protocol"`
 	Domain      string `json:"domain,omitempty"`
 	Path        string `json:"path,omitempty"`
 	CertID      string `json:"certID,omitempty"`
 	GatewayPort int32  `json:"gatewayPort" binding:"required,min=1,max=65535"`
 	Exposed     bool   `json:"exposed"`
+
+	Rules []*AppGatewayRuleModel `json:"rules,omitempty"` // HTTP route rules, http and https gateways only
 }
 
 type ListAppGatewaysRequest struct {
@@ -18,25 +86,31 @@ type ListAppGatewaysRequest struct {
 
 type CreateAppGatewayRequest struct {
 	AppID       string `json:"-" uri:"appID"`
-	Port        int32  `json:"port" binding:"required,min=1,max=65535"`
+	Port        int32  `json:"port" binding:"omitempty,min=1,max=65535"`
+	PortName    string `json:"portName,omitempty"` // Name of the app port to route to, takes precedence over port
 	Protocol    string `json:"protocol" binding:"required,oneof=http https tcp udp"`
 	Domain      string `json:"domain,omitempty"`
 	Path        string `json:"path,omitempty"`
 	CertID      string `json:"certID,omitempty"`
 	GatewayPort int32  `json:"gatewayPort"`
 	Exposed     bool   `json:"exposed"`
+
+	Rules []*AppGatewayRuleModel `json:"rules,omitempty" binding:"omitempty,dive"` // HTTP route rules, http and https gateways only
 }
 
 type UpdateAppGatewayRequest struct {
 	AppID       string `json:"-" uri:"appID"`
 	GatewayID   string `json:"-" uri:"gatewayID"`
-	Port        int32  `json:"port" binding:"required,min=1,max=65535"`
+	Port        int32  `json:"port" binding:"omitempty,min=1,max=65535"`
+	PortName    string `json:"portName,omitempty"` // Name of the app port to route to, takes precedence over port
 	Protocol    string `json:"protocol" binding:"required,oneof=http https tcp udp"`
 	Domain      string `json:"domain,omitempty"`
 	Path        string `json:"path,omitempty"`
 	CertID      string `json:"certID"`
 	GatewayPort int32  `json:"gatewayPort"`
 	Exposed     bool   `json:"exposed"`
+
+	Rules []*AppGatewayRuleModel `json:"rules,omitempty" binding:"omitempty,dive"` // HTTP route rules, http and https gateways only
 }
 
 type ToggleAppGatewayExposedRequest struct {
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+type AppLogsRequest struct {
+	Request        *http.Request       `json:"-" form:"-"`
+	ResponseWriter http.ResponseWriter `json:"-" form:"-"`
+	AppID          string              `uri:"appID"`
+	ContainerName  string              `form:"containerName"`  // Container of each instance, defaults to the main container
+	Follow         bool                `form:"follow"`         // Whether to stream logs, including instances started later
+	TailLines      int64               `form:"tailLines"`      // Number of log lines to return from the end of each instance
+	SinceSeconds   int64               `form:"sinceSeconds"`   // Fetch logs since this many seconds ago
+	SinceTime      time.Time           `form:"sinceTime"`      // Fetch logs since this time (RFC3339 format)
+	LimitBytes     int64               `form:"limitBytes"`     // Maximum bytes of logs to return of each instance
+	Filter         string              `form:"filter"`         // Only lines containing this text
+	Regex          bool                `form:"regex"`          // Whether the filter is a regular expression
+	ShowTimestamps bool                `form:"showTimestamps"` // Whether to include timestamps in logs
+}
@@ -0,0 +1,27 @@
+package models
+
+type AppNetworkPolicyModel struct {
+	PolicyID      string            `json:"policyID"`
+	AppID         string            `json:"appID"`
+	AllowGateway  bool              `json:"allowGateway"`
+	AllowFromApps []*AppNetworkPeer `json:"allowFromApps"`
+}
+
+type AppNetworkPeer struct {
+	AppID string `json:"appID"`
+	Slug  string `json:"slug"`
+}
+
+type GetAppNetworkPolicyRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type SetAppNetworkPolicyRequest struct {
+	AppID         string   `json:"-" uri:"appID"`
+	AllowGateway  bool     `json:"allowGateway"`
+	AllowFromApps []string `json:"allowFromApps,omitempty" binding:"dive,required"` // IDs of apps in the same env
+}
+
+type DeleteAppNetworkPolicyRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
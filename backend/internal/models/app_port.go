@@ -0,0 +1,38 @@
+package models
+
+type AppPortModel struct {
+	PortID        string `json:"portID"`
+	AppID         string `json:"appID"`
+	Name          string `json:"name"`
+	ContainerPort int32  `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+	ServicePort   int32  `json:"servicePort"`
+	AppProtocol   string `json:"appProtocol,omitempty"`
+}
+
+type ListAppPortsRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type CreateAppPortRequest struct {
+	AppID         string `json:"-" uri:"appID"`
+	Name          string `json:"name" binding:"required,max=15"`
+	ContainerPort int32  `json:"containerPort" binding:"required,min=1,max=65535"`
+	Protocol      string `json:"protocol" binding:"omitempty,oneof=TCP UDP SCTP"` // Defaults to TCP
+	ServicePort   int32  `json:"servicePort" binding:"omitempty,min=1,max=65535"` // Defaults to the container port
+	AppProtocol   string `json:"appProtocol,omitempty" binding:"omitempty,max=64"`
+}
+
+type UpdateAppPortRequest struct {
+	AppID         string `json:"-" uri:"appID"`
+	PortID        string `json:"-" uri:"portID"`
+	ContainerPort int32  `json:"containerPort" binding:"required,min=1,max=65535"`
+	Protocol      string `json:"protocol" binding:"omitempty,oneof=TCP UDP SCTP"`
+	ServicePort   int32  `json:"servicePort" binding:"omitempty,min=1,max=65535"`
+	AppProtocol   string `json:"appProtocol,omitempty" binding:"omitempty,max=64"`
+}
+
+type DeleteAppPortRequest struct {
+	AppID  string `uri:"appID" binding:"required"`
+	PortID string `uri:"portID" binding:"required"`
+}
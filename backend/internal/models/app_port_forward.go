@@ -0,0 +1,11 @@
+package models
+
+import "net/http"
+
+type PortForwardAppInstanceRequest struct {
+	Request        *http.Request       `json:"-" form:"-"`
+	ResponseWriter http.ResponseWriter `json:"-" form:"-"`
+	AppID          string              `uri:"appID"`
+	InstanceName   string              `uri:"instanceName"`
+	Port           int                 `form:"port" binding:"required,min=1,max=65535"` // Container port to forward to
+}
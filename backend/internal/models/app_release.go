@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type AppReleaseModel struct {
+	ReleaseID            string     `json:"releaseID"`
+	AppID                string     `json:"appID"`
+	ReleaseType          string     `json:"releaseType"` // e.g., "canary", "blueGreen"
+	StableEdition        string     `json:"stableEdition"`
+	CanaryEdition        string     `json:"canaryEdition"`
+	Weight               int32      `json:"weight"`                // Percentage of gateway traffic routed to the canary edition
+	Step                 int        `json:"step"`                  // Index of the current canary step, -1 before the first
+	CanarySteps          []int32    `json:"canarySteps,omitempty"` // Traffic percentages of canary steps
+	State                string     `json:"state"`                 // e.g., "progressing", "paused", "switched", "promoting", "promoted", "aborted"
+	StepStartedAt        time.Time  `json:"stepStartedAt"`
+	HealthySince         *time.Time `json:"healthySince,omitempty"`
+	HealthyPeriodSeconds int32      `json:"healthyPeriodSeconds"`
+	Promotable           bool       `json:"promotable"` // Whether the canary edition has been healthy for the healthy period
+	Message              string     `json:"message,omitempty"`
+}
+
+type GetAppReleaseRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type AppReleaseActionRequest struct {
+	AppID  string `json:"-" uri:"appID"`
+	Action string `json:"action" binding:"required,oneof=pause resume promote abort"`
+}
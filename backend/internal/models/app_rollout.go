@@ -0,0 +1,60 @@
+package models
+
+type AppRolloutStrategyModel struct {
+	StrategyID              string  `json:"strategyID"`
+	AppID                   string  `json:"appID"`
+	StrategyType            string  `json:"strategyType"`             // e.g., "RollingUpdate", "Recreate", "OnDelete"
+	MaxSurge                string  `json:"maxSurge,omitempty"`       // e.g., "25%", "1"
+	MaxUnavailable          string  `json:"maxUnavailable,omitempty"` // e.g., "25%", "0"
+	Partition               int32   `json:"partition,omitempty"`
+	MinReadySeconds         int32   `json:"minReadySeconds,omitempty"`
+	ProgressDeadlineSeconds int32   `json:"progressDeadlineSeconds,omitempty"`
+	ReleaseType             string  `json:"releaseType,omitempty"` // e.g., "canary", "blueGreen"
+	CanarySteps             []int32 `json:"canarySteps,omitempty"` // Traffic percentages of canary steps, e.g., [10, 50]
+	HealthyPeriodSeconds    int32   `json:"healthyPeriodSeconds,omitempty"`
+}
+
+type GetAppRolloutStrategyRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type SetAppRolloutStrategyRequest struct {
+	AppID                   string  `json:"-" uri:"appID"`
+	StrategyType            string  `json:"strategyType" binding:"required,oneof=RollingUpdate Recreate OnDelete"`
+	MaxSurge                string  `json:"maxSurge,omitempty"`
+	MaxUnavailable          string  `json:"maxUnavailable,omitempty"`
+	Partition               int32   `json:"partition,omitempty" binding:"min=0"`
+	MinReadySeconds         int32   `json:"minReadySeconds,omitempty" binding:"min=0"`
+	ProgressDeadlineSeconds int32   `json:"progressDeadlineSeconds,omitempty" binding:"min=0"`
+	ReleaseType             string  `json:"releaseType,omitempty" binding:"omitempty,oneof=canary blueGreen"`
+	CanarySteps             []int32 `json:"canarySteps,omitempty" binding:"omitempty,max=10,dive,min=1,max=100"`
+	HealthyPeriodSeconds    int32   `json:"healthyPeriodSeconds,omitempty" binding:"min=0"`
+}
+
+type DeleteAppRolloutStrategyRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type GetAppRolloutProgressRequest struct {
+	AppID string `uri:"appID" binding:"required"`
+}
+
+type AppRolloutEditionModel struct {
+	Edition       string `json:"edition"`
+	Replicas      int32  `json:"replicas"`      // Number of instances running this edition
+	ReadyReplicas int32  `json:"readyReplicas"` // Number of ready instances running this edition
+}
+
+type AppRolloutProgressModel struct {
+	AppID             string                    `json:"appID"`
+	Slug              string                    `json:"slug"`
+	DesiredEdition    string                    `json:"desiredEdition"`
+	ActualEdition     string                    `json:"actualEdition,omitempty"`
+	DesiredReplicas   int32                     `json:"desiredReplicas"`
+	UpdatedReplicas   int32                     `json:"updatedReplicas"`
+	ReadyReplicas     int32                     `json:"readyReplicas"`
+	AvailableReplicas int32                     `json:"availableReplicas"`
+	State             string                    `json:"state"` // e.g., "progressing", "complete", "failed"
+	Message           string                    `json:"message,omitempty"`
+	Editions          []*AppRolloutEditionModel `json:"editions"`
+}
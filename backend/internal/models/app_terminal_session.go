@@ -0,0 +1,29 @@
+package models
+
+type AppTerminalSessionModel struct {
+	SessionID     string `json:"sessionID"`
+	AppID         string `json:"appID"`
+	InstanceName  string `json:"instanceName"`
+	ContainerName string `json:"containerName"`
+	Command       string `json:"command,omitempty"`
+	UserID        string `json:"userID"`
+	StartedAt     string `json:"startedAt"`
+	EndedAt       string `json:"endedAt,omitempty"`
+	Recorded      bool   `json:"recorded"` // Whether an asciicast recording can be downloaded
+}
+
+type ListAppTerminalSessionsRequest struct {
+	AppID    string `uri:"appID" binding:"required"`
+	PageNo   int    `form:"pageNo,default=1"`
+	PageSize int    `form:"pageSize,default=10"`
+}
+
+type ListAppTerminalSessionsResponse struct {
+	Total   int64                      `json:"total"`
+	Records []*AppTerminalSessionModel `json:"records"`
+}
+
+type GetAppTerminalSessionRecordingRequest struct {
+	AppID     string `uri:"appID" binding:"required"`
+	SessionID string `uri:"sessionID" binding:"required"`
+}
@@ -1,16 +1,23 @@
 package models
 
 type AppVolumeModel struct {
-	VolumeID     string   `json:"volumeID" gorm:"column:id"`
-	AppID        string   `json:"appID"`
-	Slug         string   `json:"slug"`
-	MountPath    string   `json:"mountPath"`
-	SubPath      string   `json:"subPath,omitempty"` // Optional sub-path within the mount
-	VolumeType   string   `json:"volumeType"`
-	Capacity     int      `json:"capacity"`
-	AccessModes  []string `json:"accessModes"`
-	StorageClass string   `json:"storageClass"`
-	VolumeMode   string   `json:"volumeMode"`
+	VolumeID       string   `json:"volumeID" gorm:"column:id"`
+	AppID          string   `json:"appID"`
+	Slug           string   `json:"slug"`
+	MountPath      string   `json:"mountPath"`
+	SubPath        string   `json:"subPath,omitempty"` // Optional sub-path within the mount
+	VolumeType     string   `json:"volumeType"`        // e.g., "emptyDir", "pvc", "hostPath", "nfs", "existingClaim", "shared"
+	Capacity       int      `json:"capacity"`
+	AccessModes    []string `json:"accessModes"`
+	StorageClass   string   `json:"storageClass"`
+	VolumeMode     string   `json:"volumeMode"`
+	ReadOnly       bool     `json:"readOnly,omitempty"`
+	EmptyDirMedium string   `json:"emptyDirMedium,omitempty"`
+	HostPath       string   `json:"hostPath,omitempty"`
+	HostPathType   string   `json:"hostPathType,omitempty"`
+	NFSServer      string   `json:"nfsServer,omitempty"`
+	NFSPath        string   `json:"nfsPath,omitempty"`
+	ClaimName      string   `json:"claimName,omitempty"`
 }
 
 type ListAppVolumesRequest struct {
@@ -23,15 +30,22 @@ type ListAppVolumesResponse struct {
 }
 
 type CreateAppVolumeRequest struct {
-	AppID        string   `json:"-" uri:"appID"`
-	Slug         string   `json:"slug" binding:"required"`
-	MountPath    string   `json:"mountPath" binding:"required"`
-	SubPath      string   `json:"subPath"`
-	Capacity     int      `json:"capacity" binding:"required"`
-	VolumeType   string   `json:"volumeType" binding:"required"`
-	AccessModes  []string `json:"accessModes" binding:"required"`
-	StorageClass string   `json:"storageClass"`
-	VolumeMode   string   `json:"volumeMode" binding:"required,oneof=Filesystem Block"`
+	AppID          string   `json:"-" uri:"appID"`
+	Slug           string   `json:"slug" binding:"required"`
+	MountPath      string   `json:"mountPath" binding:"required"`
+	SubPath        string   `json:"subPath"`
+	Capacity       int      `json:"capacity" binding:"min=0"` // Claim size of pvc and shared volumes, size limit of emptyDir volumes, in MiB
+	VolumeType     string   `json:"volumeType" binding:"required,oneof=emptyDir pvc hostPath nfs existingClaim shared"`
+	AccessModes    []string `json:"accessModes"`
+	StorageClass   string   `json:"storageClass"`
+	VolumeMode     string   `json:"volumeMode" binding:"omitempty,oneof=Filesystem Block"`
+	ReadOnly       bool     `json:"readOnly"`
+	EmptyDirMedium string   `json:"emptyDirMedium" binding:"omitempty,oneof=Memory"`
+	HostPath       string   `json:"hostPath"`
+	HostPathType   string   `json:"hostPathType"`
+	NFSServer      string   `json:"nfsServer"`
+	NFSPath        string   `json:"nfsPath"`
+	ClaimName      string   `json:"claimName"` // Name of the pre-existing claim, or of the shared project volume
 }
 
 type UpdateAppVolumeRequest struct {
@@ -39,6 +53,7 @@ type UpdateAppVolumeRequest struct {
 	VolumeID  string `json:"-" uri:"volumeID"`
 	MountPath string `json:"mountPath" binding:"required"`
 	SubPath   string `json:"subPath"`
+	ReadOnly  bool   `json:"readOnly"`
 }
 
 type DeleteAppVolumesRequest struct {
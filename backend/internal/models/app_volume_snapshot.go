@@ -0,0 +1,69 @@
+package models
+
+type AppVolumeSnapshotModel struct {
+	Name          string `json:"name"`
+	VolumeID      string `json:"volumeID"`
+	ClaimName     string `json:"claimName"`
+	SnapshotClass string `json:"snapshotClass,omitempty"`
+	Scheduled     bool   `json:"scheduled"` // Taken by the snapshot policy, pruned by its retention
+	ReadyToUse    bool   `json:"readyToUse"`
+	RestoreSize   string `json:"restoreSize,omitempty"`
+	Error         string `json:"error,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+type ListAppVolumeSnapshotsRequest struct {
+	AppID    string `uri:"appID" binding:"required"`
+	VolumeID string `uri:"volumeID" binding:"required"`
+}
+
+type CreateAppVolumeSnapshotRequest struct {
+	AppID         string `json:"-" uri:"appID"`
+	VolumeID      string `json:"-" uri:"volumeID"`
+	Replica       int    `json:"replica" binding:"min=0"` // Replica ordinal of StatefulSet apps, each replica has its own claim
+	SnapshotClass string `json:"snapshotClass,omitempty"`
+}
+
+type DeleteAppVolumeSnapshotRequest struct {
+	AppID        string `uri:"appID" binding:"required"`
+	VolumeID     string `uri:"volumeID" binding:"required"`
+	SnapshotName string `uri:"snapshotName" binding:"required"`
+}
+
+type RestoreAppVolumeSnapshotRequest struct {
+	AppID        string `json:"-" uri:"appID"`
+	VolumeID     string `json:"-" uri:"volumeID"`
+	SnapshotName string `json:"-" uri:"snapshotName"`
+	InPlace      bool   `json:"inPlace"`             // Replace the claim the snapshot was taken from, the app must be stopped
+	ClaimName    string `json:"claimName,omitempty"` // Name of the new claim, required unless inPlace
+}
+
+type AppVolumeSnapshotPolicyModel struct {
+	PolicyID        string `json:"policyID"`
+	AppID           string `json:"appID"`
+	VolumeID        string `json:"volumeID"`
+	SnapshotClass   string `json:"snapshotClass,omitempty"`
+	IntervalMinutes int    `json:"intervalMinutes"`
+	Retention       int    `json:"retention"`
+	Enabled         bool   `json:"enabled"`
+	LastSnapshotAt  string `json:"lastSnapshotAt,omitempty"`
+}
+
+type GetAppVolumeSnapshotPolicyRequest struct {
+	AppID    string `uri:"appID" binding:"required"`
+	VolumeID string `uri:"volumeID" binding:"required"`
+}
+
+type SetAppVolumeSnapshotPolicyRequest struct {
+	AppID           string `json:"-" uri:"appID"`
+	VolumeID        string `json:"-" uri:"volumeID"`
+	SnapshotClass   string `json:"snapshotClass,omitempty"`
+	IntervalMinutes int    `json:"intervalMinutes" binding:"required,min=15"`
+	Retention       int    `json:"retention" binding:"required,min=1,max=100"`
+	Enabled         bool   `json:"enabled"`
+}
+
+type DeleteAppVolumeSnapshotPolicyRequest struct {
+	AppID    string `uri:"appID" binding:"required"`
+	VolumeID string `uri:"volumeID" binding:"required"`
+}
@@ -3,17 +3,19 @@ package models
 import "github.com/ketches/ketches/internal/api"
 
 type ClusterModel struct {
-	ClusterID      string `json:"clusterID"`
-	Slug           string `json:"slug"`
-	DisplayName    string `json:"displayName,omitempty"`
-	KubeConfig     string `json:"kubeConfig,omitempty"`
-	Description    string `json:"description,omitempty"`
-	GatewayIP      string `json:"gatewayIP,omitempty"`
-	ReadyNodeCount int    `json:"readyNodeCount,omitempty"`
-	NodeCount      int    `json:"nodeCount,omitempty"`
-	ServerVersion  string `json:"serverVersion,omitempty"`
-	Connectable    bool   `json:"connectable"`
-	Enabled        bool   `json:"enabled"`
+	ClusterID        string `json:"clusterID"`
+	Slug             string `json:"slug"`
+	DisplayName      string `json:"displayName,omitempty"`
+	KubeConfig       string `json:"kubeConfig,omitempty"`
+	Description      string `json:"description,omitempty"`
+	GatewayIP        string `json:"gatewayIP,omitempty"`
+	RoutingBackend   string `json:"routingBackend"`             // e.g., "gatewayAPI", "ingress"
+	IngressClassName string `json:"ingressClassName,omitempty"` // Empty for the cluster default
+	ReadyNodeCount   int    `json:"readyNodeCount,omitempty"`
+	NodeCount        int    `json:"nodeCount,omitempty"`
+	ServerVersion    string `json:"serverVersion,omitempty"`
+	Connectable      bool   `json:"connectable"`
+	Enabled          bool   `json:"enabled"`
 }
 
 type ListClustersRequest struct {
@@ -40,18 +42,22 @@ type GetClusterRequest struct {
 }
 
 type CreateClusterRequest struct {
-	Slug        string `json:"slug" binding:"required,slug"`
-	DisplayName string `json:"displayName" binding:"required"`
-	KubeConfig  string `json:"kubeConfig" binding:"required"`
-	GatewayIP   string `json:"gatewayIP"`
-	Description string `json:"description"`
+	Slug             string `json:"slug" binding:"required,slug"`
+	DisplayName      string `json:"displayName" binding:"required"`
+	KubeConfig       string `json:"kubeConfig" binding:"required"`
+	GatewayIP        string `json:"gatewayIP"`
+	Description      string `json:"description"`
+	RoutingBackend   string `json:"routingBackend,omitempty" binding:"omitempty,oneof=gatewayAPI ingress"` // Defaults to gatewayAPI
+	IngressClassName string `json:"ingressClassName,omitempty"`
 }
 
 type UpdateClusterRequest struct {
-	ClusterID   string `json:"-" uri:"clusterID"`
-	DisplayName string `json:"displayName" binding:"required"`
-	KubeConfig  string `json:"kubeConfig" binding:"required"`
-	Description string `json:"description,omitempty"`
+	ClusterID        string `json:"-" uri:"clusterID"`
+	DisplayName      string `json:"displayName" binding:"required"`
+	KubeConfig       string `json:"kubeConfig" binding:"required"`
+	Description      string `json:"description,omitempty"`
+	RoutingBackend   string `json:"routingBackend,omitempty" binding:"omitempty,oneof=gatewayAPI ingress"` // Unchanged if empty
+	IngressClassName string `json:"ingressClassName,omitempty"`
 }
 
 type DeleteClusterRequest struct {
@@ -70,6 +76,16 @@ type PingClusterKubeConfigRequest struct {
 	KubeConfig string `json:"kubeConfig" binding:"required"`
 }
 
+type ListClusterIngressClassesRequest struct {
+	ClusterID string `uri:"clusterID" binding:"required"`
+}
+
+type ClusterIngressClassModel struct {
+	Name       string `json:"name"`
+	Controller string `json:"controller"`
+	IsDefault  bool   `json:"isDefault"`
+}
+
 type ListClusterNodesRequest struct {
 	ClusterID string `uri:"clusterID" binding:"required"`
 }
@@ -10,6 +10,8 @@ type EnvModel struct {
 	ProjectID   string `json:"projectID,omitempty"`
 	ClusterID   string `json:"clusterID,omitempty"`
 	CreatedAt   string `json:"createdAt,omitempty"`
+
+	NetworkPolicyMode string `json:"networkPolicyMode,omitempty"` // open, isolate or deny-all-ingress
 }
 
 type ListEnvsRequest struct {
@@ -47,12 +49,16 @@ type CreateEnvRequest struct {
 	Slug        string `json:"slug" binding:"required,slug"`
 	DisplayName string `json:"displayName" binding:"required"`
 	Description string `json:"description,omitempty"`
+
+	NetworkPolicyMode string `json:"networkPolicyMode,omitempty" binding:"omitempty,oneof=open isolate deny-all-ingress"` // Defaults to open
 }
 
 type UpdateEnvRequest struct {
 	EnvID       string `uri:"envID"`
 	DisplayName string `json:"displayName" binding:"required"`
 	Description string `json:"description,omitempty"`
+
+	NetworkPolicyMode string `json:"networkPolicyMode,omitempty" binding:"omitempty,oneof=open isolate deny-all-ingress"` // Unchanged when empty
 }
 
 type DeleteEnvRequest struct {
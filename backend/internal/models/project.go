@@ -21,10 +21,19 @@ type ListProjectResponse struct {
 }
 
 type ProjectStatisticsModel struct {
-	TotalEnvs        int64 `json:"totalEnvs"`
-	TotalApps        int64 `json:"totalApps"`
-	TotalAppGateways int64 `json:"totalAppGateways"`
-	TotalMembers     int64 `json:"totalMembers"`
+	TotalEnvs        int64                    `json:"totalEnvs"`
+	TotalApps        int64                    `json:"totalApps"`
+	TotalAppGateways int64                    `json:"totalAppGateways"`
+	TotalMembers     int64                    `json:"totalMembers"`
+	Usage            *ResourceUsageModel      `json:"usage,omitempty"`     // Actual usage of all envs, omitted without metrics
+	EnvUsages        []*EnvResourceUsageModel `json:"envUsages,omitempty"` // Actual usage of each env on clusters with metrics
+}
+
+type EnvResourceUsageModel struct {
+	EnvID       string              `json:"envID"`
+	Slug        string              `json:"slug"`
+	DisplayName string              `json:"displayName"`
+	Usage       *ResourceUsageModel `json:"usage"`
 }
 
 type GetProjectStatisticsRequest struct {
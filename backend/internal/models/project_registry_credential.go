@@ -0,0 +1,45 @@
+package models
+
+type ProjectRegistryCredentialModel struct {
+	CredentialID string `json:"credentialID"`
+	ProjectID    string `json:"projectID"`
+	Host         string `json:"host"`
+	Username     string `json:"username"`
+	SecretName   string `json:"secretName"` // Pull secret synced into the env namespaces of the project
+}
+
+type ListProjectRegistryCredentialsRequest struct {
+	ProjectID string `uri:"projectID" binding:"required"`
+}
+
+type CreateProjectRegistryCredentialRequest struct {
+	ProjectID string `json:"-" uri:"projectID"`
+	Host      string `json:"host" binding:"required"` // e.g., 'ghcr.io', 'registry.example.com:5000'
+	Username  string `json:"username" binding:"required"`
+	Token     string `json:"token" binding:"required"`
+}
+
+type UpdateProjectRegistryCredentialRequest struct {
+	ProjectID    string `json:"-" uri:"projectID"`
+	CredentialID string `json:"-" uri:"credentialID"`
+	Username     string `json:"username" binding:"required"`
+	Token        string `json:"token,omitempty"` // Keeps the current token if empty
+}
+
+type DeleteProjectRegistryCredentialRequest struct {
+	ProjectID    string `uri:"projectID" binding:"required"`
+	CredentialID string `uri:"credentialID" binding:"required"`
+}
+
+type TestProjectRegistryCredentialRequest struct {
+	ProjectID    string `json:"-" uri:"projectID"`
+	CredentialID string `json:"credentialID,omitempty"` // Tests a saved credential, host, username and token are ignored
+	Host         string `json:"host,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Token        string `json:"token,omitempty"`
+}
+
+type TestProjectRegistryCredentialResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
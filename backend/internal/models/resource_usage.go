@@ -0,0 +1,10 @@
+package models
+
+// ResourceUsageModel is the actual CPU and memory usage reported by the
+// metrics.k8s.io API.
+type ResourceUsageModel struct {
+	CPU         string `json:"cpu"`         // e.g., "250m"
+	Memory      string `json:"memory"`      // e.g., "128Mi"
+	CPUMilli    int64  `json:"cpuMilli"`    // CPU usage in millicores
+	MemoryBytes int64  `json:"memoryBytes"` // Memory usage in bytes
+}
@@ -59,6 +59,7 @@ func registerClusterRoute(r *APIV1Route) {
 	adminOnly.PUT("/:clusterID/enable", handlers.EnableCluster)
 	adminOnly.PUT("/:clusterID/disable", handlers.DisableCluster)
 	adminOnly.POST("/ping", handlers.PingClusterKubeConfig)
+	adminOnly.GET("/:clusterID/ingress-classes", handlers.ListClusterIngressClasses)
 	adminOnly.GET("/:clusterID/nodes", handlers.ListClusterNodes)
 	adminOnly.GET("/:clusterID/nodes/:nodeName", handlers.GetClusterNode)
 	adminOnly.GET("/:clusterID/extensions/feature-enabled", handlers.CheckClusterExtensionFeatureEnabled)
@@ -100,6 +101,7 @@ func registerProjectRoute(r *APIV1Route) {
 	projectMember.GET("/members/addable", handlers.ListAddableProjectMembers)
 	projectMember.GET("/envs", handlers.ListEnvs)
 	projectMember.GET("/envs/refs", handlers.AllEnvRefs)
+	projectMember.GET("/registry-credentials", handlers.NewProjectRegistryCredentialHandler().ListProjectRegistryCredentials)
 
 	// Routes that require project owner role
 	projectOwner := projects.Group("/:projectID", middlewares.ProjectOwnerOnly())
@@ -109,6 +111,12 @@ func registerProjectRoute(r *APIV1Route) {
 	projectOwner.PUT("/members/:userID", handlers.UpdateProjectMember)
 	projectOwner.DELETE("/members", handlers.RemoveProjectMember)
 
+	registryCredentialHandler := handlers.NewProjectRegistryCredentialHandler()
+	projectOwner.POST("/registry-credentials", registryCredentialHandler.CreateProjectRegistryCredential)
+	projectOwner.PUT("/registry-credentials/:credentialID", registryCredentialHandler.UpdateProjectRegistryCredential)
+	projectOwner.DELETE("/registry-credentials/:credentialID", registryCredentialHandler.DeleteProjectRegistryCredential)
+	projectOwner.POST("/registry-credentials/test", registryCredentialHandler.TestProjectRegistryCredential)
+
 	// Routes that require project developer or above role
 	projectDeveloper := projects.Group("/:projectID", middlewares.ProjectDeveloperOrAbove())
 	projectDeveloper.POST("/envs", handlers.CreateEnv)
@@ -123,6 +131,7 @@ func registerEnvRoute(r *APIV1Route) {
 	projectMember.GET("/ref", handlers.GetEnvRef)
 	projectMember.GET("/apps", handlers.ListApps)
 	projectMember.GET("/apps/refs", handlers.AllAppRefs)
+	projectMember.GET("/dependency-graph", handlers.NewAppDependencyHandler().GetEnvDependencyGraph)
 
 	// Routes that require project owner role
 	projectOwner := envs.Group("", middlewares.ProjectOwnerOnly())
@@ -132,6 +141,7 @@ func registerEnvRoute(r *APIV1Route) {
 	// Routes that require project developer or above role
 	projectDeveloper := envs.Group("", middlewares.ProjectDeveloperOrAbove())
 	projectDeveloper.POST("/apps", handlers.CreateApp)
+	projectDeveloper.POST("/action", handlers.EnvAction)
 }
 
 func registerAppRoute(r *APIV1Route) {
@@ -142,12 +152,22 @@ func registerAppRoute(r *APIV1Route) {
 	projectMember.GET("", handlers.GetApp)
 	projectMember.GET("/ref", handlers.GetAppRef)
 	projectMember.GET("/instances", handlers.ListAppInstances)
-	projectMember.GET("/running/info", handlers.GetAppRunningInfo, middlewares.RequestIDMiddleware())
+	projectMember.GET("/events", handlers.NewAppEventHandler().ListAppEvents)
+	projectMember.GET("/running/info", handlers.GetAppRunningInfo)
 	projectMember.GET("/env-vars", handlers.ListAppEnvVars)
 	projectMember.GET("/volumes", handlers.ListAppVolumes)
 	projectMember.GET("/config-files", handlers.ListAppConfigFiles)
+	projectMember.GET("/ports", handlers.NewAppPortHandler().ListAppPorts)
 	projectMember.GET("/gateways", handlers.NewAppGatewayHandler().ListAppGateways)
 	projectMember.GET("/probes", handlers.NewAppProbeHandler().ListAppProbes)
+	projectMember.GET("/containers", handlers.NewAppContainerHandler().ListAppContainers)
+	projectMember.GET("/rollout-strategy", handlers.NewAppRolloutHandler().GetAppRolloutStrategy)
+	projectMember.GET("/rollout/progress", handlers.NewAppRolloutHandler().GetAppRolloutProgress)
+	projectMember.GET("/release", handlers.NewAppReleaseHandler().GetAppRelease)
+	projectMember.GET("/network-policy", handlers.NewAppNetworkPolicyHandler().GetAppNetworkPolicy)
+	projectMember.GET("/dependencies", handlers.NewAppDependencyHandler().ListAppDependencies)
+	projectMember.GET("/volumes/:volumeID/snapshots", handlers.NewAppVolumeSnapshotHandler().ListAppVolumeSnapshots)
+	projectMember.GET("/volumes/:volumeID/snapshot-policy", handlers.NewAppVolumeSnapshotHandler().GetAppVolumeSnapshotPolicy)
 
 	// Routes that require developer or owner role (read-write)
 	projectDeveloper := apps.Group("", middlewares.ProjectDeveloperOrAbove())
@@ -160,10 +180,33 @@ func registerAppRoute(r *APIV1Route) {
 	projectDeveloper.PUT("/scheduling-rule", handlers.SetAppSchedulingRule)
 	projectDeveloper.DELETE("/scheduling-rule", handlers.DeleteAppSchedulingRule)
 
+	appRolloutHandler := handlers.NewAppRolloutHandler()
+	projectDeveloper.PUT("/rollout-strategy", appRolloutHandler.SetAppRolloutStrategy)
+	projectDeveloper.DELETE("/rollout-strategy", appRolloutHandler.DeleteAppRolloutStrategy)
+	projectDeveloper.POST("/release/action", handlers.NewAppReleaseHandler().AppReleaseAction)
+
+	appNetworkPolicyHandler := handlers.NewAppNetworkPolicyHandler()
+	projectDeveloper.PUT("/network-policy", appNetworkPolicyHandler.SetAppNetworkPolicy)
+	projectDeveloper.DELETE("/network-policy", appNetworkPolicyHandler.DeleteAppNetworkPolicy)
+
+	appDependencyHandler := handlers.NewAppDependencyHandler()
+	projectDeveloper.POST("/dependencies", appDependencyHandler.CreateAppDependency)
+	projectDeveloper.DELETE("/dependencies/:dependencyID", appDependencyHandler.DeleteAppDependency)
+
 	projectDeveloper.POST("/action", handlers.AppAction)
 	projectDeveloper.DELETE("/instances", handlers.TerminateAppInstance)
+	appLogHandler := handlers.NewAppLogHandler()
+	projectDeveloper.GET("/logs", appLogHandler.StreamAppLogs)
+	projectDeveloper.GET("/logs/download", appLogHandler.DownloadAppLogs)
 	projectDeveloper.GET("/instances/:instanceName/containers/:containerName/logs", handlers.ViewAppContainerLogs)
 	projectDeveloper.GET("/instances/:instanceName/containers/:containerName/exec", handlers.ExecAppContainerTerminal)
+	projectDeveloper.POST("/instances/:instanceName/debug", handlers.DebugAppInstance)
+
+	appContainerFileHandler := handlers.NewAppContainerFileHandler()
+	projectDeveloper.GET("/instances/:instanceName/containers/:containerName/files", appContainerFileHandler.DownloadAppContainerFiles)
+	projectDeveloper.POST("/instances/:instanceName/containers/:containerName/files", appContainerFileHandler.UploadAppContainerFiles)
+	projectDeveloper.GET("/instances/:instanceName/containers/:containerName/files/list", appContainerFileHandler.ListAppContainerFiles)
+	projectDeveloper.GET("/instances/:instanceName/port-forward", handlers.NewAppPortForwardHandler().PortForwardAppInstance)
 
 	projectDeveloper.POST("/env-vars", handlers.CreateAppEnvVar)
 	projectDeveloper.PUT("/env-vars/:envVarID", handlers.UpdateAppEnvVar)
@@ -173,10 +216,22 @@ func registerAppRoute(r *APIV1Route) {
 	projectDeveloper.PUT("/volumes/:volumeID", handlers.UpdateAppVolume)
 	projectDeveloper.DELETE("/volumes", handlers.DeleteAppVolumes)
 
+	appVolumeSnapshotHandler := handlers.NewAppVolumeSnapshotHandler()
+	projectDeveloper.POST("/volumes/:volumeID/snapshots", appVolumeSnapshotHandler.CreateAppVolumeSnapshot)
+	projectDeveloper.DELETE("/volumes/:volumeID/snapshots/:snapshotName", appVolumeSnapshotHandler.DeleteAppVolumeSnapshot)
+	projectDeveloper.POST("/volumes/:volumeID/snapshots/:snapshotName/restore", appVolumeSnapshotHandler.RestoreAppVolumeSnapshot)
+	projectDeveloper.PUT("/volumes/:volumeID/snapshot-policy", appVolumeSnapshotHandler.SetAppVolumeSnapshotPolicy)
+	projectDeveloper.DELETE("/volumes/:volumeID/snapshot-policy", appVolumeSnapshotHandler.DeleteAppVolumeSnapshotPolicy)
+
 	projectDeveloper.POST("/config-files", handlers.CreateAppConfigFile)
 	projectDeveloper.PUT("/config-files/:configFileID", handlers.UpdateAppConfigFile)
 	projectDeveloper.DELETE("/config-files", handlers.DeleteAppConfigFiles)
 
+	appPortHandler := handlers.NewAppPortHandler()
+	projectDeveloper.POST("/ports", appPortHandler.CreateAppPort)
+	projectDeveloper.PUT("/ports/:portID", appPortHandler.UpdateAppPort)
+	projectDeveloper.DELETE("/ports/:portID", appPortHandler.DeleteAppPort)
+
 	appGatewayHandler := handlers.NewAppGatewayHandler()
 	projectDeveloper.POST("/gateways", appGatewayHandler.CreateAppGateway)
 	projectDeveloper.PUT("/gateways/:gatewayID", appGatewayHandler.UpdateAppGateway)
@@ -188,4 +243,15 @@ func registerAppRoute(r *APIV1Route) {
 	projectDeveloper.PUT("/probes/:probeID", appProbeHandler.UpdateAppProbe)
 	projectDeveloper.PUT("/probes/:probeID/toggle", appProbeHandler.ToggleAppProbe)
 	projectDeveloper.DELETE("/probes/:probeID", appProbeHandler.DeleteAppProbe)
+
+	appContainerHandler := handlers.NewAppContainerHandler()
+	projectDeveloper.POST("/containers", appContainerHandler.CreateAppContainer)
+	projectDeveloper.PUT("/containers/:containerID", appContainerHandler.UpdateAppContainer)
+	projectDeveloper.DELETE("/containers/:containerID", appContainerHandler.DeleteAppContainer)
+
+	// Routes that require project owner role (audit)
+	projectOwner := apps.Group("", middlewares.ProjectOwnerOnly())
+	appTerminalSessionHandler := handlers.NewAppTerminalSessionHandler()
+	projectOwner.GET("/terminal-sessions", appTerminalSessionHandler.ListAppTerminalSessions)
+	projectOwner.GET("/terminal-sessions/:sessionID/recording", appTerminalSessionHandler.GetAppTerminalSessionRecording)
 }
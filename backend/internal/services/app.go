@@ -1,12 +1,10 @@
 package services
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"sort"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,8 +16,12 @@ import (
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
 	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/metrics"
 	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/logstream"
 	"github.com/ketches/ketches/pkg/utils"
+	"github.com/ketches/ketches/pkg/uuid"
 	"github.com/ketches/ketches/pkg/websocket"
 	"github.com/spf13/cast"
 	"gorm.io/gorm"
@@ -39,9 +41,11 @@ type AppService interface {
 	SetAppCommand(ctx context.Context, req *models.SetAppCommandRequest) (*models.AppModel, app.Error)
 	SetAppResource(ctx context.Context, req *models.SetAppResourceRequest) (*models.AppModel, app.Error)
 	AppAction(ctx context.Context, req *models.AppActionRequest) (*models.AppModel, app.Error)
+	PreviewAppAction(ctx context.Context, req *models.AppActionRequest) (*models.AppActionPreviewResponse, app.Error)
 	ListAppInstances(ctx context.Context, req *models.ListAppInstancesRequest) (*models.ListAppInstancesResponse, app.Error)
 	GetAppRunningInfo(ctx context.Context, req *models.GetAppRunningInfoRequest) app.Error
 	TerminateAppInstance(ctx context.Context, req *models.TerminateAppInstanceRequest) app.Error
+	DebugAppInstance(ctx context.Context, req *models.DebugAppInstanceRequest) (*models.DebugAppInstanceResponse, app.Error)
 	ViewAppContainerLogs(ctx context.Context, req *models.ViewAppContainerLogsRequest) app.Error
 	ExecAppContainerTerminal(ctx context.Context, req *models.ExecAppContainerTerminalRequest) app.Error
 }
@@ -59,7 +63,7 @@ func NewAppService() AppService {
 }
 
 func (s *appService) ListApps(ctx context.Context, req *models.ListAppsRequest) (*models.ListAppsResponse, app.Error) {
-	query := db.Instance().Model(&entities.App{}).Where("env_id = ?", req.EnvID)
+	query := db.WithContext(ctx).Model(&entities.App{}).Where("env_id = ?", req.EnvID)
 
 	if req.Query != "" {
 		query = db.CaseInsensitiveLike(query, req.Query, "slug", "display_name")
@@ -67,13 +71,13 @@ func (s *appService) ListApps(ctx context.Context, req *models.ListAppsRequest)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
-		log.Printf("failed to count apps: %v", err)
+		logging.Errorf(ctx, "failed to count apps: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	apps := []*entities.App{}
 	if err := req.PagedSQL(query).Find(&apps).Error; err != nil {
-		log.Printf("failed to list apps: %v", err)
+		logging.Errorf(ctx, "failed to list apps: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -107,8 +111,8 @@ func (s *appService) ListApps(ctx context.Context, req *models.ListAppsRequest)
 
 func (s *appService) AllAppRefs(ctx context.Context, req *models.AllAppRefsRequest) ([]*models.AppRef, app.Error) {
 	refs := []*models.AppRef{}
-	if err := db.Instance().Model(&entities.App{}).Where("env_id = ?", req.EnvID).Find(&refs).Error; err != nil {
-		log.Printf("failed to list app refs: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.App{}).Where("env_id = ?", req.EnvID).Find(&refs).Error; err != nil {
+		logging.Errorf(ctx, "failed to list app refs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -148,8 +152,8 @@ func (s *appService) CreateApp(ctx context.Context, req *models.CreateAppRequest
 		},
 	}
 
-	if err := db.Instance().Create(appEntity).Error; err != nil {
-		log.Printf("failed to create app: %v", err)
+	if err := db.WithContext(ctx).Create(appEntity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app: %v", err)
 
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusConflict, "App with this slug already exists in the env")
@@ -225,7 +229,7 @@ func (s *appService) GetApp(ctx context.Context, req *models.GetAppRequest) (*mo
 
 func (s *appService) GetAppRef(ctx context.Context, req *models.GetAppRefRequest) (*models.AppRef, app.Error) {
 	result := &models.AppRef{}
-	if err := db.Instance().Model(&entities.App{}).First(result, "id = ?", req.AppID).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&entities.App{}).First(result, "id = ?", req.AppID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "App not found")
 		}
@@ -257,7 +261,7 @@ func (s *appService) UpdateApp(ctx context.Context, req *models.UpdateAppRequest
 	appEntity.DisplayName = req.DisplayName
 	appEntity.Description = req.Description
 
-	if err := db.Instance().Model(appEntity).Select(
+	if err := db.WithContext(ctx).Model(appEntity).Select(
 		"DisplayName", "Description", "UpdatedBy",
 	).Updates(entities.App{
 		DisplayName: appEntity.DisplayName,
@@ -266,7 +270,7 @@ func (s *appService) UpdateApp(ctx context.Context, req *models.UpdateAppRequest
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app: %v", err)
+		logging.Errorf(ctx, "failed to update app: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -312,7 +316,7 @@ func (s *appService) UpdateAppImage(ctx context.Context, req *models.UpdateAppIm
 		ProjectID:        appEntity.ProjectID,
 	}
 
-	if err := db.Instance().Model(appEntity).Select(
+	if err := db.WithContext(ctx).Model(appEntity).Select(
 		"ContainerImage", "RegistryUsername", "RegistryPassword", "Edition", "UpdatedBy",
 	).Updates(entities.App{
 		ContainerImage:   result.ContainerImage,
@@ -323,7 +327,7 @@ func (s *appService) UpdateAppImage(ctx context.Context, req *models.UpdateAppIm
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app image: %v", err)
+		logging.Errorf(ctx, "failed to update app image: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -351,7 +355,7 @@ func (s *appService) SetAppCommand(ctx context.Context, req *models.SetAppComman
 		ProjectID:        appEntity.ProjectID,
 	}
 
-	if err := db.Instance().Model(appEntity).
+	if err := db.WithContext(ctx).Model(appEntity).
 		Select("ContainerCommand", "Edition", "UpdatedBy").
 		Updates(entities.App{
 			ContainerCommand: result.ContainerCommand,
@@ -360,7 +364,7 @@ func (s *appService) SetAppCommand(ctx context.Context, req *models.SetAppComman
 				UpdatedBy: api.UserID(ctx),
 			},
 		}).Error; err != nil {
-		log.Printf("failed to update app command: %v", err)
+		logging.Errorf(ctx, "failed to update app command: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -396,7 +400,7 @@ func (s *appService) SetAppResource(ctx context.Context, req *models.SetAppResou
 		ProjectID:     appEntity.ProjectID,
 	}
 
-	if err := db.Instance().Model(appEntity).
+	if err := db.WithContext(ctx).Model(appEntity).
 		Select("Replicas", "RequestCPU", "RequestMemory", "LimitCPU", "LimitMemory", "Edition", "UpdatedBy").
 		Updates(entities.App{
 			Replicas:      result.Replicas,
@@ -409,7 +413,7 @@ func (s *appService) SetAppResource(ctx context.Context, req *models.SetAppResou
 				UpdatedBy: api.UserID(ctx),
 			},
 		}).Error; err != nil {
-		log.Printf("failed to update app resources: %v", err)
+		logging.Errorf(ctx, "failed to update app resources: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -422,12 +426,30 @@ func (s *appService) AppAction(ctx context.Context, req *models.AppActionRequest
 		return nil, err
 	}
 
+	if req.Force && !api.IsAdmin(ctx) {
+		return nil, app.NewError(http.StatusForbidden, "Only admin can force apply app resources")
+	}
+
 	switch req.Action {
-	case app.AppActionDeploy, app.AppActionStart, app.AppActionUpdate, app.AppActionDebugOff:
-		err = s.deployApp(ctx, appEntity, nil)
+	case app.AppActionDeploy, app.AppActionUpdate:
+		options := &core.AppDeployOption{
+			PruneVolumes: req.PruneVolumes,
+			ForceApply:   req.Force,
+		}
+		var released bool
+		if released, err = startAppRelease(ctx, appEntity, options); err == nil && !released {
+			err = s.deployApp(ctx, appEntity, options)
+		}
+	case app.AppActionStart, app.AppActionDebugOff:
+		err = s.deployApp(ctx, appEntity, &core.AppDeployOption{
+			PruneVolumes: req.PruneVolumes,
+			ForceApply:   req.Force,
+		})
 	case app.AppActionStop:
 		err = s.deployApp(ctx, appEntity, &core.AppDeployOption{
 			ZeroReplicas: true,
+			PruneVolumes: req.PruneVolumes,
+			ForceApply:   req.Force,
 		})
 	case app.AppActionRollback:
 		// TODO: Implement rollback logic
@@ -435,7 +457,9 @@ func (s *appService) AppAction(ctx context.Context, req *models.AppActionRequest
 		err = s.redeployApp(ctx, appEntity)
 	case app.AppActionDebug:
 		err = s.deployApp(ctx, appEntity, &core.AppDeployOption{
-			DebugMode: true,
+			DebugMode:    true,
+			PruneVolumes: req.PruneVolumes,
+			ForceApply:   req.Force,
 		})
 	case app.AppActionDelete:
 		err = s.deleteApp(ctx, appEntity)
@@ -456,6 +480,56 @@ func (s *appService) AppAction(ctx context.Context, req *models.AppActionRequest
 	return result, nil
 }
 
+func (s *appService) PreviewAppAction(ctx context.Context, req *models.AppActionRequest) (*models.AppActionPreviewResponse, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Force && !api.IsAdmin(ctx) {
+		return nil, app.NewError(http.StatusForbidden, "Only admin can force apply app resources")
+	}
+
+	options := &core.AppDeployOption{
+		PruneVolumes: req.PruneVolumes,
+		ForceApply:   req.Force,
+	}
+	switch req.Action {
+	case app.AppActionDeploy, app.AppActionStart, app.AppActionUpdate, app.AppActionDebugOff:
+	case app.AppActionStop:
+		options.ZeroReplicas = true
+	case app.AppActionDebug:
+		options.DebugMode = true
+	default:
+		return nil, app.NewError(http.StatusBadRequest, "App action does not support dry run")
+	}
+
+	cli, err := kube.ClusterRuntimeClient(ctx, appEntity.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	appMetadata, err := core.NewAppMetadataBuilderFromAppEntity(ctx, appEntity).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := appMetadata.Preview(ctx, cli, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.AppActionPreviewResponse{
+		AppID:   appEntity.ID,
+		Slug:    appEntity.Slug,
+		Action:  req.Action,
+		Edition: appEntity.Edition,
+		Objects: diffs,
+	}
+
+	return result, nil
+}
+
 func (s *appService) ListAppInstances(ctx context.Context, req *models.ListAppInstancesRequest) (*models.ListAppInstancesResponse, app.Error) {
 	appEntity, err := orm.GetAppByID(ctx, req.AppID)
 	if err != nil {
@@ -474,48 +548,18 @@ func (s *appService) ListAppInstances(ctx context.Context, req *models.ListAppIn
 		Instances: make([]*models.AppInstanceModel, 0, len(pods)),
 	}
 	for _, pod := range pods {
-		mainContainer := kube.MainContainer(appEntity.Slug, pod)
-		containers := make([]*models.AppInstanceContainerModel, 0, len(pod.Spec.Containers))
-		initContainers := make([]*models.AppInstanceContainerModel, 0, len(pod.Spec.InitContainers))
-		for _, container := range pod.Status.ContainerStatuses {
-			containers = append(containers, &models.AppInstanceContainerModel{
-				ContainerName: container.Name,
-				Image:         container.Image,
-				Status:        kube.GetContainerStatus(&container),
-			})
-		}
-		// let mainContainer always be the first in the list
-		sort.Slice(containers, func(i, j int) bool {
-			return containers[i].ContainerName == mainContainer.Name
-		})
-		for _, container := range pod.Status.InitContainerStatuses {
-			initContainers = append(initContainers, &models.AppInstanceContainerModel{
-				ContainerName: container.Name,
-				Image:         container.Image,
-				Status:        kube.GetContainerStatus(&container),
-			})
-		}
-		instance := &models.AppInstanceModel{
-			InstanceName:    pod.Name,
-			Status:          kube.GetPodStatus(pod),
-			RunningDuration: utils.HumanizeTime(pod.CreationTimestamp.Time),
-			InstanceIP:      pod.Status.PodIP,
-			Containers:      containers,
-			InitContainers:  initContainers,
-			NodeName:        pod.Spec.NodeName,
-			NodeIP:          pod.Status.HostIP,
-			ContainerCount:  len(pod.Status.ContainerStatuses),
-			RequestCPU:      mainContainer.Resources.Requests.Cpu().String(),
-			RequestMemory:   mainContainer.Resources.Requests.Memory().String(),
-			LimitCPU:        mainContainer.Resources.Limits.Cpu().String(),
-			LimitMemory:     mainContainer.Resources.Limits.Memory().String(),
-			Edition:         pod.Labels["ketches.cn/edition"],
-		}
+		instance := kube.AppInstanceFromPod(appEntity.Slug, pod)
+		instance.RunningDuration = utils.HumanizeTime(instance.CreatedAt)
 		result.Instances = append(result.Instances, instance)
 	}
+	core.SetAppInstancesUsage(ctx, appEntity, result.Instances)
 	return result, nil
 }
 
+// appUsageRefreshInterval is how often the running info stream refreshes the
+// usage of instances, metrics-server scrapes every 15s by default.
+const appUsageRefreshInterval = 15 * time.Second
+
 func (s *appService) GetAppRunningInfo(ctx context.Context, req *models.GetAppRunningInfoRequest) app.Error {
 	appEntity, err := orm.GetAppByID(ctx, req.AppID)
 	if err != nil {
@@ -542,7 +586,35 @@ func (s *appService) GetAppRunningInfo(ctx context.Context, req *models.GetAppRu
 	instances := sseClients.GetAppInstances(appEntity.ID)
 	client.MsgChan <- instances
 
+	send := func(instances []*models.AppInstanceModel) {
+		for _, instance := range instances {
+			instance.RunningDuration = utils.HumanizeTime(instance.CreatedAt)
+		}
+		core.SetAppInstancesUsage(ctx, appEntity, instances)
+
+		runningStatus := core.GetAppStatusFromInstances(ctx, instances)
+		runningInfo := &models.GetAppRunningInfoResponse{
+			AppID:          appEntity.ID,
+			Slug:           appEntity.Slug,
+			Status:         runningStatus.Status,
+			ActualReplicas: runningStatus.ActualReplicas,
+			ActualEdition:  runningStatus.ActualEdition,
+			Instances:      instances,
+			Events:         appRunningEvents(ctx, appEntity),
+		}
+		data, err := json.Marshal(runningInfo)
+		if err != nil {
+			logging.Errorf(ctx, "failed to marshal running info: %v", err)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", data) // [data: ] is required for SSE
+		flusher.Flush()
+	}
+
 	go func() {
+		// Usage changes without instance changes, refresh it periodically.
+		usageRefresh := time.NewTicker(appUsageRefreshInterval)
+		defer usageRefresh.Stop()
 		for {
 			select {
 			case instances, ok := <-client.MsgChan:
@@ -551,27 +623,11 @@ func (s *appService) GetAppRunningInfo(ctx context.Context, req *models.GetAppRu
 					sseClients.Remove(client)
 					return
 				}
-
-				for _, instance := range instances {
-					instance.RunningDuration = utils.HumanizeTime(instance.CreatedAt)
-				}
-
-				runningStatus := core.GetAppStatusFromInstances(ctx, instances)
-				runningInfo := &models.GetAppRunningInfoResponse{
-					AppID:          appEntity.ID,
-					Slug:           appEntity.Slug,
-					Status:         runningStatus.Status,
-					ActualReplicas: runningStatus.ActualReplicas,
-					ActualEdition:  runningStatus.ActualEdition,
-					Instances:      instances,
-				}
-				data, err := json.Marshal(runningInfo)
-				if err != nil {
-					log.Printf("failed to marshal running info: %v", err)
-					continue
+				send(instances)
+			case <-usageRefresh.C:
+				if core.MetricsAvailable(ctx, appEntity.ClusterID) {
+					send(sseClients.GetAppInstances(appEntity.ID))
 				}
-				_, _ = fmt.Fprintf(w, "data: %s\n\n", data) // [data: ] is required for SSE
-				flusher.Flush()
 			case <-ctx.Done():
 				// Context cancelled, clean up
 				sseClients.Remove(client)
@@ -601,23 +657,87 @@ func (s *appService) TerminateAppInstance(ctx context.Context, req *models.Termi
 	return nil
 }
 
-func (s *appService) ViewAppContainerLogs(ctx context.Context, req *models.ViewAppContainerLogsRequest) app.Error {
+// appInstancePod returns the pod of an app instance, pods of other apps in the
+// namespace are not found.
+func appInstancePod(ctx context.Context, appEntity *entities.App, instanceName string) (*corev1.Pod, app.Error) {
+	pods, err := kube.ListPods(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		if pod.Name == instanceName {
+			return pod, nil
+		}
+	}
+	return nil, app.NewError(http.StatusNotFound, "Instance not found")
+}
+
+// debugContainerStartTimeout bounds the wait for the debug image to be pulled
+// and started.
+const debugContainerStartTimeout = 2 * time.Minute
+
+// DebugAppInstance injects an ephemeral debug container into a running
+// instance, sharing the process namespace of the target container. The
+// workload is left untouched, the terminal connects to the returned container.
+func (s *appService) DebugAppInstance(ctx context.Context, req *models.DebugAppInstanceRequest) (*models.DebugAppInstanceResponse, app.Error) {
 	appEntity, err := orm.GetAppByID(ctx, req.AppID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	w := req.ResponseWriter
-	r := req.Request
-	flusher, ok := w.(http.Flusher)
-	if !ok {
-		return app.NewError(http.StatusInternalServerError, "Streaming unsupported")
+	pod, err := appInstancePod(ctx, appEntity, req.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, app.NewError(http.StatusBadRequest, "Only running instances can be debugged")
 	}
 
-	// Set headers for SSE
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
+	target := req.TargetContainer
+	if target == "" {
+		if main := kube.MainContainer(appEntity.Slug, pod); main != nil {
+			target = main.Name
+		}
+	} else if !slices.ContainsFunc(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == target }) {
+		return nil, app.NewError(http.StatusBadRequest, "Target container not found in instance")
+	}
+
+	image := req.Image
+	if image == "" {
+		image = app.DebugImage()
+	}
+
+	container := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     "debugger-" + uuid.New()[:5],
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	}
+	if err := kube.AddEphemeralContainer(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, pod.Name, container); err != nil {
+		return nil, err
+	}
+	if err := kube.WaitEphemeralContainerRunning(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, pod.Name, container.Name, debugContainerStartTimeout); err != nil {
+		return nil, err
+	}
+
+	return &models.DebugAppInstanceResponse{
+		InstanceName:    pod.Name,
+		ContainerName:   container.Name,
+		Image:           image,
+		TargetContainer: target,
+	}, nil
+}
+
+func (s *appService) ViewAppContainerLogs(ctx context.Context, req *models.ViewAppContainerLogsRequest) app.Error {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return err
+	}
 
 	if req.TailLines <= 0 {
 		req.TailLines = 1000 // Default to last 1000 lines if not specified
@@ -628,45 +748,35 @@ func (s *appService) ViewAppContainerLogs(ctx context.Context, req *models.ViewA
 		return err
 	}
 
-	logsReq := clientset.CoreV1().Pods(appEntity.ClusterNamespace).GetLogs(req.InstanceName, &corev1.PodLogOptions{
-		Container:  req.ContainerName,  // container name
-		Follow:     req.Follow,         // follow logs
-		TailLines:  &req.TailLines,     // fetch last N lines
-		Timestamps: req.ShowTimestamps, // show timestamps
-		Previous:   req.Previous,       // fetch previous logs
-		// SinceSeconds: &req.SinceSeconds,
-		// SinceTime: &metav1.Time{
-		// 	Time: req.SinceTime, // fetch logs since a specific time
-		// }, // fetch logs since a specific time
-		// LimitBytes: utils.Ptr(req.Limit), // limit log size
-	})
-
-	stream, e := logsReq.Stream(r.Context())
+	w, e := logstream.NewSSEWriter(req.ResponseWriter)
+	if e != nil {
+		return app.NewError(http.StatusInternalServerError, "Streaming unsupported")
+	}
+
+	logOptions := podLogOptions(req.Follow, req.TailLines, req.SinceSeconds, req.SinceTime, req.LimitBytes, req.ShowTimestamps)
+	logOptions.Container = req.ContainerName
+	logOptions.Previous = req.Previous
+	logsReq := clientset.CoreV1().Pods(appEntity.ClusterNamespace).GetLogs(req.InstanceName, logOptions)
+
+	streamCtx := req.Request.Context()
+	stream, e := logsReq.Stream(streamCtx)
 	if e != nil {
-		log.Println("Error streaming pod logs:", e)
+		logging.Errorf(ctx, "Error streaming pod logs: %v", e)
 		if req.Previous && strings.HasSuffix(e.Error(), "not found") {
-			fmt.Fprintf(w, "data: %s\n\n", "No previous logs found for this container")
-			flusher.Flush()
+			w.Data("No previous logs found for this container")
+			w.End(nil)
 			return nil
 		}
 		return app.NewError(http.StatusInternalServerError, "Failed to stream pod logs")
 	}
-	defer stream.Close()
 
-	scaner := bufio.NewScanner(stream)
-
-	for {
-		select {
-		case <-r.Context().Done():
-			return nil
-		default:
-			if scaner.Scan() {
-				txt := scaner.Text()
-				fmt.Fprintf(w, "data: %s\n\n", txt) // [data: ] is required for SSE
-				flusher.Flush()
-			}
-		}
+	sessions := metrics.SSESessions.WithLabelValues("logs")
+	sessions.Inc()
+	defer sessions.Dec()
+	if e := logstream.ServeSSE(streamCtx, w, stream, logstream.Options{MaxLineSize: maxLogLineSize}); e != nil {
+		logging.Errorf(ctx, "failed to write logs of pod %s in namespace %s: %v", req.InstanceName, appEntity.ClusterNamespace, e)
 	}
+	return nil
 }
 
 func (s *appService) ExecAppContainerTerminal(ctx context.Context, req *models.ExecAppContainerTerminalRequest) app.Error {
@@ -675,65 +785,80 @@ func (s *appService) ExecAppContainerTerminal(ctx context.Context, req *models.E
 		return err
 	}
 
-	w := req.ResponseWriter
-	r := req.Request
-	conn, err := websocket.NewConn(w, r)
+	command, err := terminalCommand(req.Command)
 	if err != nil {
-		log.Printf("Error upgrading connection to WebSocket: %v", err)
-		return app.NewError(http.StatusInternalServerError, "Failed to upgrade connection to WebSocket")
+		return err
 	}
-	defer conn.Close()
-
-	clientset, err := kube.ClusterClientset(ctx, appEntity.ClusterID, false)
+	executor, err := kube.NewExecutor(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, req.InstanceName, req.ContainerName, command, true, true)
 	if err != nil {
 		return err
 	}
 
-	restConfig, err := kube.RestConfig(ctx, appEntity.ClusterID)
+	conn, err := websocket.NewConn(req.ResponseWriter, req.Request)
 	if err != nil {
-		return err
+		logging.Errorf(ctx, "Error upgrading connection to WebSocket: %v", err)
+		return app.NewError(http.StatusInternalServerError, "Failed to upgrade connection to WebSocket")
 	}
+	sessions := metrics.WebSocketSessions.WithLabelValues("terminal")
+	sessions.Inc()
+	defer sessions.Dec()
+	terminal := websocket.NewTerminal(conn, app.TerminalIdleTimeout())
 
-	execReq := clientset.CoreV1().RESTClient().
-		Post().
-		Resource("pods").
-		Name(req.InstanceName).
-		Namespace(appEntity.ClusterNamespace).
-		SubResource("exec").
-		Param("container", req.ContainerName).
-		Param("stdin", "true").
-		Param("stdout", "true").
-		Param("stderr", "true").
-		Param("tty", "true").
-		Param("command", "sh").
-		Param("command", "-c").
-		Param("command", "clear; exec $(command -v bash || command -v ash || command -v sh)")
-
-	// 创建SPDY executor
-	executor, e := remotecommand.NewSPDYExecutor(restConfig, "POST", execReq.URL())
-	if e != nil {
-		log.Printf("Error creating SPDY Executor: %v", e)
-		return app.NewError(http.StatusInternalServerError, "Failed to create SPDY Executor")
+	cols, rows := req.Cols, req.Rows
+	if cols == 0 || rows == 0 {
+		cols, rows = 80, 24
+	}
+	session := startAppTerminalSession(ctx, appEntity, req, command, cols, rows)
+	defer session.finish(ctx)
+	if recorder := session.terminalRecorder(); recorder != nil {
+		terminal.SetRecorder(recorder)
 	}
+	terminal.Resize(cols, rows)
+
+	// The stream ends when the command exits or the terminal is closed by the
+	// client or the idle timeout.
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-terminal.Done():
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
 
 	// 创建远程命令的流选项
 	streamOptions := remotecommand.StreamOptions{
-		Stdin:  websocket.NewReader(conn),
-		Stdout: websocket.NewWriter(conn),
-		Stderr: websocket.NewWriter(conn),
-		Tty:    true,
+		Stdin:             terminal,
+		Stdout:            terminal,
+		Stderr:            terminal,
+		Tty:               true,
+		TerminalSizeQueue: terminal,
 	}
 
 	// 执行远程命令
-	if e := executor.StreamWithContext(context.Background(), streamOptions); e != nil {
-		log.Printf("Error streaming to Pod: %v", e)
-		return app.NewError(http.StatusInternalServerError, "Failed to stream to Pod")
+	if e := executor.StreamWithContext(streamCtx, streamOptions); e != nil && streamCtx.Err() == nil {
+		logging.Errorf(ctx, "Error streaming to Pod: %v", e)
+		terminal.Close("Failed to stream to Pod: " + e.Error())
+		return nil
 	}
 
+	terminal.Close("exited")
 	return nil
 }
 
-func (s *appService) deployApp(ctx context.Context, appEntity *entities.App, options *core.AppDeployOption) app.Error {
+func (s *appService) deployApp(ctx context.Context, appEntity *entities.App, options *core.AppDeployOption) (err app.Error) {
+	defer func() {
+		metrics.ObserveDeploy(appEntity.ClusterID, err != nil)
+	}()
+
+	// Stopped and debugged apps run in place, their release is aborted first
+	if options != nil && (options.ZeroReplicas || options.DebugMode) {
+		if err := abortActiveAppRelease(ctx, appEntity, "Aborted as the app was stopped or debugged"); err != nil {
+			return err
+		}
+	}
+
 	cli, err := kube.ClusterRuntimeClient(ctx, appEntity.ClusterID)
 	if err != nil {
 		return err
@@ -766,12 +891,12 @@ func (s *appService) undeployApp(ctx context.Context, appEntity *entities.App) a
 		return err
 	}
 
-	if err := db.Instance().Model(appEntity).Updates(entities.App{
+	if err := db.WithContext(ctx).Model(appEntity).Updates(entities.App{
 		AuditBase: entities.AuditBase{
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app deploy info: %v", err)
+		logging.Errorf(ctx, "failed to update app deploy info: %v", err)
 		return app.ErrDatabaseOperationFailed
 	}
 
@@ -784,44 +909,106 @@ func (s appService) redeployApp(ctx context.Context, appEntity *entities.App) ap
 		return err
 	}
 
+	// The undeploy removed both workloads of an active release, the app is
+	// deployed in place again
+	release, err := orm.GetActiveAppRelease(ctx, appEntity.ID)
+	if err != nil {
+		return err
+	}
+	if release != nil {
+		if err := updateAppRelease(ctx, release, map[string]any{
+			"state":   app.ReleaseStateAborted,
+			"weight":  int32(0),
+			"message": "Aborted as the app was redeployed",
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The deploy outlives the request, it must not be canceled with it.
+	deployCtx := context.WithoutCancel(ctx)
 	go func() {
 		// wait for resources to be deleted
 		time.Sleep(5 * time.Second)
-		s.deployApp(ctx, appEntity, nil)
+		s.deployApp(deployCtx, appEntity, nil)
 	}()
 
 	return nil
 }
 
 func (s *appService) deleteApp(ctx context.Context, appEntity *entities.App) app.Error {
+	var dependents int64
+	if err := db.WithContext(ctx).Model(&entities.AppDependency{}).Where("depends_on_app_id = ?", appEntity.ID).Count(&dependents).Error; err != nil {
+		logging.Errorf(ctx, "failed to count dependents of app %s: %v", appEntity.ID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+	if dependents > 0 {
+		return app.NewError(http.StatusConflict, "Cannot delete app other apps depend on")
+	}
+
 	// Step 1. undeploy the app
 	if err := s.undeployApp(ctx, appEntity); err != nil {
 		return err
 	}
 
 	// Step 2. delete app in database
-	if err := db.Transaction(func(tx *gorm.DB) error {
+	if err := db.Transaction(ctx, func(tx *gorm.DB) error {
 		if err := tx.Delete(appEntity).Error; err != nil {
-			log.Printf("failed to delete app %s: %v", appEntity.ID, err)
+			logging.Errorf(ctx, "failed to delete app %s: %v", appEntity.ID, err)
 			return err
 		}
 
 		if err := tx.Delete(&entities.AppEnvVar{}, "app_id = ?", appEntity.ID).Error; err != nil {
-			log.Printf("failed to delete app env vars for app %s: %v", appEntity.ID, err)
+			logging.Errorf(ctx, "failed to delete app env vars for app %s: %v", appEntity.ID, err)
 			return err
 		}
 
 		if err := tx.Delete(&entities.AppVolume{}, "app_id = ?", appEntity.ID).Error; err != nil {
-			log.Printf("failed to delete app volumes for app %s: %v", appEntity.ID, err)
+			logging.Errorf(ctx, "failed to delete app volumes for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppPort{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete app ports for app %s: %v", appEntity.ID, err)
 			return err
 		}
 
 		if err := tx.Delete(&entities.AppGateway{}, "app_id = ?", appEntity.ID).Error; err != nil {
-			log.Printf("failed to delete app gateways for app %s: %v", appEntity.ID, err)
+			logging.Errorf(ctx, "failed to delete app gateways for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppContainer{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete app containers for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppRolloutStrategy{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete app rollout strategy for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppRelease{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete app release for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppDependency{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete app dependencies for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppNetworkPolicy{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete app network policy for app %s: %v", appEntity.ID, err)
+			return err
+		}
+
+		if err := tx.Delete(&entities.AppVolumeSnapshotPolicy{}, "app_id = ?", appEntity.ID).Error; err != nil {
+			logging.Errorf(ctx, "failed to delete volume snapshot policies for app %s: %v", appEntity.ID, err)
 			return err
 		}
 
-		log.Printf("app %s deleted successfully", appEntity.ID)
+		logging.Infof(ctx, "app %s deleted successfully", appEntity.ID)
 		return nil
 	}); err != nil {
 		return app.ErrDatabaseOperationFailed
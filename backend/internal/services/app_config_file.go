@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/ketches/ketches/internal/api"
@@ -10,6 +9,7 @@ import (
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/ketches/ketches/pkg/uuid"
 )
@@ -37,11 +37,11 @@ func NewAppConfigFileService() AppConfigFileService {
 func (s *appConfigFileService) ListAppConfigFiles(ctx context.Context, req *models.ListAppConfigFilesRequest) ([]*models.AppConfigFileModel, app.Error) {
 	var result []*models.AppConfigFileModel
 	var total int64
-	if err := db.Instance().Model(&entities.AppConfigFile{}).
+	if err := db.WithContext(ctx).Model(&entities.AppConfigFile{}).
 		Where("app_id = ?", req.AppID).
 		Count(&total).
 		Find(&result).Error; err != nil {
-		log.Printf("failed to list config files for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to list config files for app %s: %v", req.AppID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -73,8 +73,8 @@ func (s *appConfigFileService) CreateAppConfigFile(ctx context.Context, req *mod
 		},
 	}
 
-	if err := db.Instance().Create(entity).Error; err != nil {
-		log.Printf("failed to create app config file for app %s: %v", req.AppID, err)
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app config file for app %s: %v", req.AppID, err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusBadRequest, "config file slug or mount path already exists")
 		}
@@ -82,7 +82,7 @@ func (s *appConfigFileService) CreateAppConfigFile(ctx context.Context, req *mod
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after creating config file for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after creating config file for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppConfigFileModel{
@@ -107,8 +107,8 @@ func (s *appConfigFileService) UpdateAppConfigFile(ctx context.Context, req *mod
 	}
 
 	var entity entities.AppConfigFile
-	if err := db.Instance().First(&entity, "id = ?", req.ConfigFileID).Error; err != nil {
-		log.Printf("failed to find app config file %s: %v", req.ConfigFileID, err)
+	if err := db.WithContext(ctx).First(&entity, "id = ?", req.ConfigFileID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find app config file %s: %v", req.ConfigFileID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "config file not found")
 		}
@@ -119,7 +119,7 @@ func (s *appConfigFileService) UpdateAppConfigFile(ctx context.Context, req *mod
 	entity.MountPath = req.MountPath
 	entity.FileMode = req.FileMode
 
-	if err := db.Instance().Model(&entity).Select("FileName", "Content", "MountPath", "SubPath", "FileMode", "UpdatedBy").Updates(&entities.AppConfigFile{
+	if err := db.WithContext(ctx).Model(&entity).Select("FileName", "Content", "MountPath", "SubPath", "FileMode", "UpdatedBy").Updates(&entities.AppConfigFile{
 		Content:   req.Content,
 		MountPath: req.MountPath,
 		FileMode:  req.FileMode,
@@ -127,7 +127,7 @@ func (s *appConfigFileService) UpdateAppConfigFile(ctx context.Context, req *mod
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app config file %s: %v", req.ConfigFileID, err)
+		logging.Errorf(ctx, "failed to update app config file %s: %v", req.ConfigFileID, err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusBadRequest, "mount path already exists")
 		}
@@ -135,7 +135,7 @@ func (s *appConfigFileService) UpdateAppConfigFile(ctx context.Context, req *mod
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after updating config file for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after updating config file for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppConfigFileModel{
@@ -153,13 +153,22 @@ func (s *appConfigFileService) DeleteAppConfigFiles(ctx context.Context, req *mo
 		return nil
 	}
 
-	if err := db.Instance().Delete(&entities.AppConfigFile{}, req.ConfigFileIDs).Error; err != nil {
-		log.Printf("failed to delete app config files: %v", err)
+	var slugs []string
+	if err := db.WithContext(ctx).Model(&entities.AppConfigFile{}).Where("id IN ? AND app_id = ?", req.ConfigFileIDs, req.AppID).Pluck("slug", &slugs).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app config files: %v", err)
+		return app.ErrDatabaseOperationFailed
+	}
+	if err := checkAppContainerVolumeMountsUnused(ctx, req.AppID, slugs); err != nil {
+		return err
+	}
+
+	if err := db.WithContext(ctx).Delete(&entities.AppConfigFile{}, req.ConfigFileIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app config files: %v", err)
 		return app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after deleting config file for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after deleting config file for app %s: %v", req.AppID, err)
 	}
 
 	return nil
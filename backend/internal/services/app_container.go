@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/uuid"
+)
+
+type AppContainerService interface {
+	ListAppContainers(ctx context.Context, req *models.ListAppContainersRequest) ([]*models.AppContainerModel, app.Error)
+	CreateAppContainer(ctx context.Context, req *models.CreateAppContainerRequest) (*models.AppContainerModel, app.Error)
+	UpdateAppContainer(ctx context.Context, req *models.UpdateAppContainerRequest) (*models.AppContainerModel, app.Error)
+	DeleteAppContainer(ctx context.Context, req *models.DeleteAppContainerRequest) app.Error
+}
+
+type appContainerService struct {
+	Service
+}
+
+var appContainerServiceInstance = &appContainerService{
+	Service: LoadService(),
+}
+
+func NewAppContainerService() AppContainerService {
+	return appContainerServiceInstance
+}
+
+func (s *appContainerService) ListAppContainers(ctx context.Context, req *models.ListAppContainersRequest) ([]*models.AppContainerModel, app.Error) {
+	containers, err := orm.AllAppContainers(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.AppContainerModel, 0, len(containers))
+	for _, container := range containers {
+		model, err := appContainerModelFromEntity(ctx, container)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, model)
+	}
+
+	return result, nil
+}
+
+func (s *appContainerService) CreateAppContainer(ctx context.Context, req *models.CreateAppContainerRequest) (*models.AppContainerModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Slug == appEntity.Slug {
+		return nil, app.NewError(http.StatusBadRequest, "container slug is reserved for the main container")
+	}
+
+	if err := validateAppContainerVolumeMounts(ctx, req.AppID, req.VolumeMounts); err != nil {
+		return nil, err
+	}
+
+	envVars, volumeMounts, err := encodeAppContainerSpec(ctx, req.EnvVars, req.VolumeMounts)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := &entities.AppContainer{
+		UUIDBase:         entities.UUIDBase{ID: uuid.New()},
+		AppID:            req.AppID,
+		Slug:             req.Slug,
+		ContainerType:    req.ContainerType,
+		ContainerImage:   req.ContainerImage,
+		ContainerCommand: req.ContainerCommand,
+		RequestCPU:       req.RequestCPU,
+		RequestMemory:    req.RequestMemory,
+		LimitCPU:         req.LimitCPU,
+		LimitMemory:      req.LimitMemory,
+		EnvVars:          envVars,
+		VolumeMounts:     volumeMounts,
+		SortOrder:        req.SortOrder,
+		AuditBase: entities.AuditBase{
+			CreatedBy: api.UserID(ctx),
+			UpdatedBy: api.UserID(ctx),
+		},
+	}
+
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app container for app %s: %v", req.AppID, err)
+		if db.IsErrDuplicatedKey(err) {
+			return nil, app.NewError(http.StatusConflict, "container slug already exists")
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after creating container for app %s: %v", req.AppID, err)
+	}
+
+	return appContainerModelFromEntity(ctx, entity)
+}
+
+func (s *appContainerService) UpdateAppContainer(ctx context.Context, req *models.UpdateAppContainerRequest) (*models.AppContainerModel, app.Error) {
+	var entity entities.AppContainer
+	if err := db.WithContext(ctx).First(&entity, "id = ? AND app_id = ?", req.ContainerID, req.AppID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find app container %s: %v", req.ContainerID, err)
+		if db.IsErrRecordNotFound(err) {
+			return nil, app.NewError(http.StatusNotFound, "container not found")
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if err := validateAppContainerVolumeMounts(ctx, req.AppID, req.VolumeMounts); err != nil {
+		return nil, err
+	}
+
+	envVars, volumeMounts, err := encodeAppContainerSpec(ctx, req.EnvVars, req.VolumeMounts)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.ContainerImage = req.ContainerImage
+	entity.ContainerCommand = req.ContainerCommand
+	entity.RequestCPU = req.RequestCPU
+	entity.RequestMemory = req.RequestMemory
+	entity.LimitCPU = req.LimitCPU
+	entity.LimitMemory = req.LimitMemory
+	entity.EnvVars = envVars
+	entity.VolumeMounts = volumeMounts
+	entity.SortOrder = req.SortOrder
+	entity.AuditBase.UpdatedBy = api.UserID(ctx)
+
+	if err := db.WithContext(ctx).Model(&entity).Select("ContainerImage", "ContainerCommand", "RequestCPU", "RequestMemory", "LimitCPU", "LimitMemory",
+		"EnvVars", "VolumeMounts", "SortOrder", "UpdatedBy").
+		Updates(&entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to update app container %s: %v", req.ContainerID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after updating container for app %s: %v", req.AppID, err)
+	}
+
+	return appContainerModelFromEntity(ctx, &entity)
+}
+
+func (s *appContainerService) DeleteAppContainer(ctx context.Context, req *models.DeleteAppContainerRequest) app.Error {
+	if err := db.WithContext(ctx).Delete(&entities.AppContainer{}, "id = ? AND app_id = ?", req.ContainerID, req.AppID).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app container for app %s: %v", req.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after deleting container for app %s: %v", req.AppID, err)
+	}
+
+	return nil
+}
+
+// validateAppContainerVolumeMounts checks that every mount refers to a volume
+// or config file defined on the app, those are the only pod volumes.
+func validateAppContainerVolumeMounts(ctx context.Context, appID string, mounts []*models.AppContainerVolumeMountModel) app.Error {
+	if len(mounts) == 0 {
+		return nil
+	}
+
+	volumes, err := orm.AllAppVolumes(appID)
+	if err != nil {
+		return err
+	}
+	configFiles, err := orm.AllAppConfigFiles(ctx, appID)
+	if err != nil {
+		return err
+	}
+
+	// A slug naming both a volume and a config file can't be resolved to a
+	// single pod volume
+	slugs := make(map[string]int, len(volumes)+len(configFiles))
+	for _, volume := range volumes {
+		slugs[volume.Slug]++
+	}
+	for _, configFile := range configFiles {
+		slugs[configFile.Slug]++
+	}
+
+	mountPaths := make(map[string]struct{}, len(mounts))
+	for _, mount := range mounts {
+		if n := slugs[mount.VolumeSlug]; n == 0 {
+			return app.NewError(http.StatusBadRequest, "volume or config file not found: "+mount.VolumeSlug)
+		} else if n > 1 {
+			return app.NewError(http.StatusConflict, "both a volume and a config file are named "+mount.VolumeSlug+", rename one of them to mount it")
+		}
+		if _, ok := mountPaths[mount.MountPath]; ok {
+			return app.NewError(http.StatusBadRequest, "mount path is mounted more than once: "+mount.MountPath)
+		}
+		mountPaths[mount.MountPath] = struct{}{}
+	}
+
+	return nil
+}
+
+// checkAppContainerVolumeMountsUnused refuses to remove volumes or config
+// files that containers of the app still mount.
+func checkAppContainerVolumeMountsUnused(ctx context.Context, appID string, slugs []string) app.Error {
+	if len(slugs) == 0 {
+		return nil
+	}
+
+	containers, err := orm.AllAppContainers(ctx, appID)
+	if err != nil {
+		return err
+	}
+	for _, container := range containers {
+		model, err := appContainerModelFromEntity(ctx, container)
+		if err != nil {
+			return err
+		}
+		for _, mount := range model.VolumeMounts {
+			if slices.Contains(slugs, mount.VolumeSlug) {
+				return app.NewError(http.StatusConflict, fmt.Sprintf("%s is mounted by container %s, remove the mount first", mount.VolumeSlug, container.Slug))
+			}
+		}
+	}
+
+	return nil
+}
+
+func encodeAppContainerSpec(ctx context.Context, envVars []*models.AppContainerEnvVarModel, mounts []*models.AppContainerVolumeMountModel) (string, string, app.Error) {
+	var encodedEnvVars, encodedMounts string
+	if len(envVars) > 0 {
+		b, err := json.Marshal(envVars)
+		if err != nil {
+			logging.Errorf(ctx, "failed to marshal app container env vars: %v", err)
+			return "", "", app.NewError(http.StatusBadRequest, "invalid container env vars")
+		}
+		encodedEnvVars = string(b)
+	}
+	if len(mounts) > 0 {
+		b, err := json.Marshal(mounts)
+		if err != nil {
+			logging.Errorf(ctx, "failed to marshal app container volume mounts: %v", err)
+			return "", "", app.NewError(http.StatusBadRequest, "invalid container volume mounts")
+		}
+		encodedMounts = string(b)
+	}
+	return encodedEnvVars, encodedMounts, nil
+}
+
+func appContainerModelFromEntity(ctx context.Context, entity *entities.AppContainer) (*models.AppContainerModel, app.Error) {
+	result := &models.AppContainerModel{
+		ContainerID:      entity.ID,
+		AppID:            entity.AppID,
+		Slug:             entity.Slug,
+		ContainerType:    entity.ContainerType,
+		ContainerImage:   entity.ContainerImage,
+		ContainerCommand: entity.ContainerCommand,
+		RequestCPU:       entity.RequestCPU,
+		RequestMemory:    entity.RequestMemory,
+		LimitCPU:         entity.LimitCPU,
+		LimitMemory:      entity.LimitMemory,
+		SortOrder:        entity.SortOrder,
+	}
+	if entity.EnvVars != "" {
+		if err := json.Unmarshal([]byte(entity.EnvVars), &result.EnvVars); err != nil {
+			logging.Errorf(ctx, "failed to unmarshal env vars of app container %s: %v", entity.ID, err)
+			return nil, app.NewError(http.StatusInternalServerError, "failed to parse container env vars")
+		}
+	}
+	if entity.VolumeMounts != "" {
+		if err := json.Unmarshal([]byte(entity.VolumeMounts), &result.VolumeMounts); err != nil {
+			logging.Errorf(ctx, "failed to unmarshal volume mounts of app container %s: %v", entity.ID, err)
+			return nil, app.NewError(http.StatusInternalServerError, "failed to parse container volume mounts")
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AppContainerFileService copies files in and out of app containers as tar
+// archives, like kubectl cp. The containers must have tar, listing
+// directories also needs stat.
+type AppContainerFileService interface {
+	ListAppContainerFiles(ctx context.Context, req *models.ListAppContainerFilesRequest) ([]*models.AppContainerFileModel, app.Error)
+	DownloadAppContainerFiles(ctx context.Context, req *models.DownloadAppContainerFilesRequest) app.Error
+	UploadAppContainerFiles(ctx context.Context, req *models.UploadAppContainerFilesRequest) app.Error
+}
+
+type appContainerFileService struct {
+	Service
+}
+
+var appContainerFileServiceInstance = &appContainerFileService{
+	Service: LoadService(),
+}
+
+func NewAppContainerFileService() AppContainerFileService {
+	return appContainerFileServiceInstance
+}
+
+// listFilesScript prints one line per entry of the directory $1, hidden
+// entries included, the name comes last as it may contain the separator.
+const listFilesScript = `cd -- "$1" || exit 1
+set --
+for f in * .[!.]* ..?*; do
+	if [ -e "$f" ] || [ -L "$f" ]; then set -- "$@" "$f"; fi
+done
+[ $# -eq 0 ] || exec stat -c '%F|%s|%a|%Y|%n' -- "$@"`
+
+func (s *appContainerFileService) ListAppContainerFiles(ctx context.Context, req *models.ListAppContainerFilesRequest) ([]*models.AppContainerFileModel, app.Error) {
+	appEntity, err := containerFileTarget(ctx, req.AppID, req.InstanceName, req.ContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := containerFilePath(req.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+	if err := kube.Exec(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, req.InstanceName, req.ContainerName,
+		[]string{"sh", "-c", listFilesScript, "sh", dir}, nil, &stdout); err != nil {
+		return nil, err
+	}
+
+	result := []*models.AppContainerFileModel{}
+	for line := range strings.SplitSeq(stdout.String(), "\n") {
+		fields := strings.SplitN(line, "|", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		modifiedAt, _ := strconv.ParseInt(fields[3], 10, 64)
+		result = append(result, &models.AppContainerFileModel{
+			Name:       fields[4],
+			Type:       containerFileType(fields[0]),
+			Size:       size,
+			Mode:       fields[2],
+			ModifiedAt: time.Unix(modifiedAt, 0).Format(time.RFC3339),
+		})
+	}
+	return result, nil
+}
+
+func (s *appContainerFileService) DownloadAppContainerFiles(ctx context.Context, req *models.DownloadAppContainerFilesRequest) app.Error {
+	appEntity, err := containerFileTarget(ctx, req.AppID, req.InstanceName, req.ContainerName)
+	if err != nil {
+		return err
+	}
+
+	p, err := containerFilePath(req.Path)
+	if err != nil {
+		return err
+	}
+	dir, name := path.Dir(p), "./"+path.Base(p)
+	fileName := path.Base(p) + ".tar"
+	if p == "/" {
+		dir, name, fileName = "/", ".", "root.tar"
+	}
+
+	w := &fileDownloadWriter{
+		w:        req.ResponseWriter,
+		fileName: fileName,
+		limit:    app.FileTransferMaxSize(),
+	}
+	if err := kube.Exec(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, req.InstanceName, req.ContainerName,
+		[]string{"tar", "cf", "-", "-C", dir, name}, nil, w); err != nil {
+		if w.written == 0 {
+			if w.tooLarge {
+				return fileTooLargeError(w.limit)
+			}
+			return err
+		}
+		// The archive is already partially sent, the connection is aborted so
+		// that the client sees a failed download, not a truncated archive.
+		logging.Errorf(ctx, "failed to download %s from container %s of instance %s: %v", p, req.ContainerName, req.InstanceName, err)
+		abortResponse(ctx, req.ResponseWriter)
+	}
+	return nil
+}
+
+// abortResponse closes the connection of a response whose body is partially
+// sent, without terminating the chunked body.
+func abortResponse(ctx context.Context, w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		logging.Errorf(ctx, "failed to abort the response: %v", err)
+		return
+	}
+	conn.Close()
+}
+
+func (s *appContainerFileService) UploadAppContainerFiles(ctx context.Context, req *models.UploadAppContainerFilesRequest) app.Error {
+	appEntity, err := containerFileTarget(ctx, req.AppID, req.InstanceName, req.ContainerName)
+	if err != nil {
+		return err
+	}
+
+	dir, err := containerFilePath(req.Path)
+	if err != nil {
+		return err
+	}
+
+	limit := app.FileTransferMaxSize()
+	if req.Request.ContentLength > limit {
+		return fileTooLargeError(limit)
+	}
+	body := http.MaxBytesReader(req.ResponseWriter, req.Request.Body, limit)
+	defer body.Close()
+
+	stdin := &fileUploadReader{r: body}
+	if err := kube.Exec(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, req.InstanceName, req.ContainerName,
+		[]string{"tar", "xf", "-", "-C", dir}, stdin, io.Discard); err != nil {
+		if stdin.tooLarge {
+			return fileTooLargeError(limit)
+		}
+		return err
+	}
+	if stdin.tooLarge {
+		return fileTooLargeError(limit)
+	}
+	return nil
+}
+
+// containerFileTarget loads the app and checks the container belongs to one
+// of its instances.
+func containerFileTarget(ctx context.Context, appID, instanceName, containerName string) (*entities.App, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := appInstancePod(ctx, appEntity, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, app.NewError(http.StatusBadRequest, "Files can only be copied from running instances")
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return appEntity, nil
+		}
+	}
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name == containerName {
+			return appEntity, nil
+		}
+	}
+	return nil, app.NewError(http.StatusNotFound, "Container not found in instance")
+}
+
+// containerFilePath cleans an absolute path in a container.
+func containerFilePath(p string) (string, app.Error) {
+	if !strings.HasPrefix(p, "/") {
+		return "", app.NewError(http.StatusBadRequest, "Path must be absolute")
+	}
+	return path.Clean(p), nil
+}
+
+func containerFileType(statType string) string {
+	switch {
+	case statType == "directory":
+		return "directory"
+	case statType == "symbolic link":
+		return "symlink"
+	case strings.HasSuffix(statType, "file"):
+		return "file"
+	default:
+		return "other"
+	}
+}
+
+func fileTooLargeError(limit int64) app.Error {
+	return app.NewError(http.StatusRequestEntityTooLarge, fmt.Sprintf("File transfers are limited to %d MiB", limit>>20))
+}
+
+// fileDownloadWriter sends the response headers with the first bytes of the
+// archive, errors before it are still sent as JSON. Writes beyond the limit
+// fail and abort the stream.
+type fileDownloadWriter struct {
+	w        http.ResponseWriter
+	fileName string
+	limit    int64
+	written  int64
+	tooLarge bool
+}
+
+var errFileTooLarge = errors.New("file transfer size limit exceeded")
+
+func (w *fileDownloadWriter) Write(p []byte) (int, error) {
+	if w.written+int64(len(p)) > w.limit {
+		w.tooLarge = true
+		return 0, errFileTooLarge
+	}
+	if w.written == 0 {
+		w.w.Header().Set("Content-Type", "application/x-tar")
+		w.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.fileName))
+		w.w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.w.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// fileUploadReader records whether the upload hit the size limit of the
+// request body.
+type fileUploadReader struct {
+	r        io.Reader
+	tooLarge bool
+}
+
+func (r *fileUploadReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		r.tooLarge = true
+	}
+	return n, err
+}
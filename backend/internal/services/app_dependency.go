@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/depgraph"
+)
+
+var envPrefixRgx = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+type AppDependencyService interface {
+	ListAppDependencies(ctx context.Context, req *models.ListAppDependenciesRequest) ([]*models.AppDependencyModel, app.Error)
+	CreateAppDependency(ctx context.Context, req *models.CreateAppDependencyRequest) (*models.AppDependencyModel, app.Error)
+	DeleteAppDependency(ctx context.Context, req *models.DeleteAppDependencyRequest) app.Error
+	GetEnvDependencyGraph(ctx context.Context, req *models.GetEnvDependencyGraphRequest) (*models.AppDependencyGraphModel, app.Error)
+}
+
+type appDependencyService struct {
+	Service
+}
+
+var appDependencyServiceInstance = &appDependencyService{
+	Service: LoadService(),
+}
+
+func NewAppDependencyService() AppDependencyService {
+	return appDependencyServiceInstance
+}
+
+func (s *appDependencyService) ListAppDependencies(ctx context.Context, req *models.ListAppDependenciesRequest) ([]*models.AppDependencyModel, app.Error) {
+	dependencies, err := orm.AllAppDependencies(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.AppDependencyModel, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		model, err := appDependencyModelFromEntity(ctx, dependency)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, model)
+	}
+
+	return result, nil
+}
+
+func (s *appDependencyService) CreateAppDependency(ctx context.Context, req *models.CreateAppDependencyRequest) (*models.AppDependencyModel, app.Error) {
+	if req.DependsOnAppID == req.AppID {
+		return nil, app.NewError(http.StatusBadRequest, "An app can't depend on itself")
+	}
+	if req.EnvPrefix != "" && !envPrefixRgx.MatchString(req.EnvPrefix) {
+		return nil, app.NewError(http.StatusBadRequest, "Env prefix must consist of upper case letters, digits and underscores")
+	}
+
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	dependsOn, err := orm.GetAppByID(ctx, req.DependsOnAppID)
+	if err != nil {
+		return nil, err
+	}
+	if dependsOn.ProjectID != appEntity.ProjectID {
+		return nil, app.NewError(http.StatusBadRequest, "Apps can only depend on apps of the same project")
+	}
+	if dependsOn.ClusterID != appEntity.ClusterID {
+		return nil, app.NewError(http.StatusBadRequest, "Apps can only depend on apps in the same cluster")
+	}
+	if dependsOn.EnvID != appEntity.EnvID {
+		env, err := orm.GetEnvByID(ctx, dependsOn.EnvID)
+		if err != nil {
+			return nil, err
+		}
+		if envRejectsOtherEnvs(env) {
+			return nil, app.NewError(http.StatusBadRequest, "Env "+env.Slug+" rejects traffic from other envs, apps can only depend on its apps from the same env")
+		}
+	}
+
+	deps, err := projectAppDependencies(ctx, appEntity.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if depgraph.Reaches(deps, dependsOn.ID, appEntity.ID) {
+		return nil, app.NewError(http.StatusBadRequest, "App "+dependsOn.Slug+" already depends on "+appEntity.Slug+", dependencies can't form a cycle")
+	}
+
+	entity := &entities.AppDependency{
+		AppID:          appEntity.ID,
+		DependsOnAppID: dependsOn.ID,
+		EnvPrefix:      req.EnvPrefix,
+		AuditBase: entities.AuditBase{
+			CreatedBy: api.UserID(ctx),
+			UpdatedBy: api.UserID(ctx),
+		},
+	}
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create dependency of app %s on app %s: %v", appEntity.ID, dependsOn.ID, err)
+		if db.IsErrDuplicatedKey(err) {
+			return nil, app.NewError(http.StatusConflict, "App already depends on "+dependsOn.Slug)
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, appEntity.ID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after creating dependency for app %s: %v", appEntity.ID, err)
+	}
+
+	return appDependencyModelFromEntity(ctx, entity)
+}
+
+func (s *appDependencyService) DeleteAppDependency(ctx context.Context, req *models.DeleteAppDependencyRequest) app.Error {
+	result := db.WithContext(ctx).Where("id = ? AND app_id = ?", req.DependencyID, req.AppID).Delete(&entities.AppDependency{})
+	if result.Error != nil {
+		logging.Errorf(ctx, "failed to delete dependency %s of app %s: %v", req.DependencyID, req.AppID, result.Error)
+		return app.ErrDatabaseOperationFailed
+	}
+	if result.RowsAffected == 0 {
+		return app.NewError(http.StatusNotFound, "App dependency not found")
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after deleting dependency for app %s: %v", req.AppID, err)
+	}
+
+	return nil
+}
+
+func (s *appDependencyService) GetEnvDependencyGraph(ctx context.Context, req *models.GetEnvDependencyGraphRequest) (*models.AppDependencyGraphModel, app.Error) {
+	apps, layers, dependencies, err := envAppLayers(ctx, req.EnvID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.AppDependencyGraphModel{
+		EnvID: req.EnvID,
+		Nodes: make([]*models.AppDependencyGraphNode, 0, len(apps)),
+		Edges: make([]*models.AppDependencyGraphEdge, 0, len(dependencies)),
+	}
+	for i, layer := range layers {
+		for _, appID := range layer {
+			result.Nodes = append(result.Nodes, appDependencyGraphNode(apps[appID], i))
+		}
+	}
+
+	external := make(map[string]bool)
+	for _, dependency := range dependencies {
+		result.Edges = append(result.Edges, &models.AppDependencyGraphEdge{
+			AppID:          dependency.AppID,
+			DependsOnAppID: dependency.DependsOnAppID,
+		})
+		if _, ok := apps[dependency.DependsOnAppID]; ok || external[dependency.DependsOnAppID] {
+			continue
+		}
+		external[dependency.DependsOnAppID] = true
+
+		dependsOn, err := orm.GetAppByID(ctx, dependency.DependsOnAppID)
+		if err != nil {
+			return nil, err
+		}
+		node := appDependencyGraphNode(dependsOn, -1)
+		node.External = true
+		result.Nodes = append(result.Nodes, node)
+	}
+
+	return result, nil
+}
+
+func appDependencyGraphNode(appEntity *entities.App, layer int) *models.AppDependencyGraphNode {
+	return &models.AppDependencyGraphNode{
+		AppID:       appEntity.ID,
+		Slug:        appEntity.Slug,
+		DisplayName: appEntity.DisplayName,
+		EnvID:       appEntity.EnvID,
+		EnvSlug:     appEntity.EnvSlug,
+		Layer:       layer,
+	}
+}
+
+// envAppLayers returns the apps of the env keyed by ID, their IDs grouped in
+// start order and the dependencies of the apps.
+func envAppLayers(ctx context.Context, envID string) (map[string]*entities.App, [][]string, []*entities.AppDependency, app.Error) {
+	var appEntities []*entities.App
+	if err := db.WithContext(ctx).Find(&appEntities, "env_id = ?", envID).Error; err != nil {
+		logging.Errorf(ctx, "failed to list apps of env %s: %v", envID, err)
+		return nil, nil, nil, app.ErrDatabaseOperationFailed
+	}
+
+	apps := make(map[string]*entities.App, len(appEntities))
+	appIDs := make([]string, 0, len(appEntities))
+	for _, appEntity := range appEntities {
+		apps[appEntity.ID] = appEntity
+		appIDs = append(appIDs, appEntity.ID)
+	}
+
+	dependencies, err := orm.AllAppDependencies(ctx, appIDs...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	deps := make(map[string][]string, len(dependencies))
+	for _, dependency := range dependencies {
+		deps[dependency.AppID] = append(deps[dependency.AppID], dependency.DependsOnAppID)
+	}
+	layers, layerErr := depgraph.Layers(appIDs, deps)
+	if layerErr != nil {
+		var cycleErr *depgraph.CycleError
+		if errors.As(layerErr, &cycleErr) {
+			logging.Errorf(ctx, "apps of env %s can't be ordered: %v", envID, layerErr)
+			return nil, nil, nil, app.NewError(http.StatusConflict, "Dependencies of the apps form a cycle")
+		}
+		return nil, nil, nil, app.NewError(http.StatusInternalServerError, layerErr.Error())
+	}
+
+	return apps, layers, dependencies, nil
+}
+
+// envRejectsOtherEnvs reports whether the network policy mode of the env
+// rejects traffic from the apps of other envs.
+func envRejectsOtherEnvs(env *entities.Env) bool {
+	return env.NetworkPolicyMode != "" && env.NetworkPolicyMode != app.EnvNetworkPolicyModeOpen
+}
+
+// crossEnvDependent returns the slug of an app of another env depending on an
+// app of the env, empty if there is none.
+func crossEnvDependent(ctx context.Context, envID string) (string, app.Error) {
+	var slugs []string
+	if err := db.WithContext(ctx).Model(&entities.AppDependency{}).
+		Joins("JOIN apps ON apps.id = app_dependencies.app_id").
+		Joins("JOIN apps AS depends_on ON depends_on.id = app_dependencies.depends_on_app_id").
+		Where("depends_on.env_id = ? AND apps.env_id <> ?", envID, envID).
+		Limit(1).Pluck("apps.slug", &slugs).Error; err != nil {
+		logging.Errorf(ctx, "failed to find apps of other envs depending on env %s: %v", envID, err)
+		return "", app.ErrDatabaseOperationFailed
+	}
+	if len(slugs) == 0 {
+		return "", nil
+	}
+	return slugs[0], nil
+}
+
+// projectAppDependencies returns the apps each app of the project depends on,
+// keyed by app ID.
+func projectAppDependencies(ctx context.Context, projectID string) (map[string][]string, app.Error) {
+	var appIDs []string
+	if err := db.WithContext(ctx).Model(&entities.App{}).Where("project_id = ?", projectID).Pluck("id", &appIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to list apps of project %s: %v", projectID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	dependencies, err := orm.AllAppDependencies(ctx, appIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, len(dependencies))
+	for _, dependency := range dependencies {
+		result[dependency.AppID] = append(result[dependency.AppID], dependency.DependsOnAppID)
+	}
+	return result, nil
+}
+
+func appDependencyModelFromEntity(ctx context.Context, entity *entities.AppDependency) (*models.AppDependencyModel, app.Error) {
+	dependsOn, err := orm.GetAppByID(ctx, entity.DependsOnAppID)
+	if err != nil {
+		return nil, err
+	}
+	ports, err := orm.AllAppPorts(ctx, dependsOn.ID)
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := orm.AllAppGateways(dependsOn.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependency := core.AppMetadataDependency{
+		AppSlug:   dependsOn.Slug,
+		Namespace: dependsOn.ClusterNamespace,
+		EnvPrefix: entity.EnvPrefix,
+		Ports:     core.ServicePorts(ports, gateways),
+	}
+	result := &models.AppDependencyModel{
+		DependencyID:   entity.ID,
+		AppID:          entity.AppID,
+		DependsOnAppID: dependsOn.ID,
+		Slug:           dependsOn.Slug,
+		DisplayName:    dependsOn.DisplayName,
+		EnvID:          dependsOn.EnvID,
+		EnvSlug:        dependsOn.EnvSlug,
+		EnvPrefix:      entity.EnvPrefix,
+		Host:           core.ServiceHost(dependsOn.Slug, dependsOn.ClusterNamespace),
+		Ports:          dependency.Ports,
+	}
+	if result.EnvPrefix == "" {
+		result.EnvPrefix = core.DependencyEnvPrefix(dependsOn.Slug)
+	}
+	for _, envVar := range dependency.EnvVars() {
+		result.EnvVars = append(result.EnvVars, envVar.Key)
+	}
+
+	return result, nil
+}
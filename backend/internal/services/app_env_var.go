@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/ketches/ketches/internal/api"
@@ -10,6 +9,7 @@ import (
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/ketches/ketches/pkg/uuid"
 )
@@ -37,11 +37,11 @@ func NewAppEnvVarService() AppEnvVarService {
 func (s *appEnvVarService) ListAppEnvVars(ctx context.Context, req *models.ListAppEnvVarsRequest) ([]*models.AppEnvVarModel, app.Error) {
 	var result []*models.AppEnvVarModel
 	var total int64
-	if err := db.Instance().Model(&entities.AppEnvVar{}).
+	if err := db.WithContext(ctx).Model(&entities.AppEnvVar{}).
 		Where("app_id = ?", req.AppID).
 		Count(&total).
 		Find(&result).Error; err != nil {
-		log.Printf("failed to list env vars for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to list env vars for app %s: %v", req.AppID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -60,8 +60,8 @@ func (s *appEnvVarService) CreateAppEnvVar(ctx context.Context, req *models.Crea
 			UpdatedBy: api.UserID(ctx),
 		},
 	}
-	if err := db.Instance().Create(entity).Error; err != nil {
-		log.Printf("failed to create app env var for app %s: %v", req.AppID, err)
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app env var for app %s: %v", req.AppID, err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusBadRequest, "env var key already exists")
 		}
@@ -69,7 +69,7 @@ func (s *appEnvVarService) CreateAppEnvVar(ctx context.Context, req *models.Crea
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after creating env var for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after creating env var for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppEnvVarModel{
@@ -82,8 +82,8 @@ func (s *appEnvVarService) CreateAppEnvVar(ctx context.Context, req *models.Crea
 
 func (s *appEnvVarService) UpdateAppEnvVar(ctx context.Context, req *models.UpdateAppEnvVarRequest) (*models.AppEnvVarModel, app.Error) {
 	var entity entities.AppEnvVar
-	if err := db.Instance().First(&entity, "id = ?", req.EnvVarID).Error; err != nil {
-		log.Printf("failed to find app env var %s: %v", req.EnvVarID, err)
+	if err := db.WithContext(ctx).First(&entity, "id = ?", req.EnvVarID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find app env var %s: %v", req.EnvVarID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "env var not found")
 		}
@@ -91,18 +91,18 @@ func (s *appEnvVarService) UpdateAppEnvVar(ctx context.Context, req *models.Upda
 	}
 
 	entity.Value = req.Value
-	if err := db.Instance().Model(&entity).Select("Value", "UpdatedBy").Updates(&entities.AppEnvVar{
+	if err := db.WithContext(ctx).Model(&entity).Select("Value", "UpdatedBy").Updates(&entities.AppEnvVar{
 		Value: req.Value,
 		AuditBase: entities.AuditBase{
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app env var %s: %v", req.EnvVarID, err)
+		logging.Errorf(ctx, "failed to update app env var %s: %v", req.EnvVarID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after updating env var for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after updating env var for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppEnvVarModel{
@@ -118,13 +118,13 @@ func (s *appEnvVarService) DeleteAppEnvVars(ctx context.Context, req *models.Del
 		return nil
 	}
 
-	if err := db.Instance().Delete(&entities.AppEnvVar{}, req.EnvVarIDs).Error; err != nil {
-		log.Printf("failed to delete app env vars: %v", err)
+	if err := db.WithContext(ctx).Delete(&entities.AppEnvVar{}, req.EnvVarIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app env vars: %v", err)
 		return app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after deleting env var for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after deleting env var for app %s: %v", req.AppID, err)
 	}
 
 	return nil
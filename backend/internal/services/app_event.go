@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// runningInfoEventLimit bounds the events pushed with the running info.
+const runningInfoEventLimit = 20
+
+type AppEventService interface {
+	ListAppEvents(ctx context.Context, req *models.ListAppEventsRequest) ([]*models.AppEventModel, app.Error)
+}
+
+type appEventService struct {
+	Service
+}
+
+var appEventServiceInstance = &appEventService{
+	Service: LoadService(),
+}
+
+func NewAppEventService() AppEventService {
+	return appEventServiceInstance
+}
+
+func (s *appEventService) ListAppEvents(ctx context.Context, req *models.ListAppEventsRequest) ([]*models.AppEventModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects map[kube.EventObject]bool
+	if req.InstanceName != "" {
+		objects = map[kube.EventObject]bool{{Kind: "Pod", Name: req.InstanceName}: true}
+	} else {
+		objects, err = kube.AppEventObjects(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+		if err != nil {
+			return nil, err
+		}
+		for _, route := range appRouteEventObjects(ctx, appEntity) {
+			objects[route] = true
+		}
+	}
+
+	events, err := kube.ListEvents(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, objects)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type == "" {
+		return events, nil
+	}
+
+	result := make([]*models.AppEventModel, 0, len(events))
+	for _, event := range events {
+		if event.Type == req.Type {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// appRouteEventObjects returns the gateway routes of the app, routes are not
+// cached so they are left out when the API cannot be listed.
+func appRouteEventObjects(ctx context.Context, appEntity *entities.App) []kube.EventObject {
+	cli, err := kube.ClusterRuntimeClient(ctx, appEntity.ClusterID)
+	if err != nil {
+		return nil
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(appEntity.ClusterNamespace),
+		client.MatchingLabels{"ketches.cn/app": appEntity.Slug},
+	}
+	var result []kube.EventObject
+
+	httpRoutes := &gatewayapisv1.HTTPRouteList{}
+	if err := cli.List(ctx, httpRoutes, opts...); err != nil {
+		logging.Errorf(ctx, "failed to list http routes of app %s: %v", appEntity.ID, err)
+	}
+	for _, route := range httpRoutes.Items {
+		result = append(result, kube.EventObject{Kind: "HTTPRoute", Name: route.Name})
+	}
+
+	tcpRoutes := &gatewayapisv1alpha2.TCPRouteList{}
+	if err := cli.List(ctx, tcpRoutes, opts...); err != nil {
+		logging.Errorf(ctx, "failed to list tcp routes of app %s: %v", appEntity.ID, err)
+	}
+	for _, route := range tcpRoutes.Items {
+		result = append(result, kube.EventObject{Kind: "TCPRoute", Name: route.Name})
+	}
+
+	return result
+}
+
+// appRunningEvents returns the latest events of the app for the running info
+// stream, failures only drop the events.
+func appRunningEvents(ctx context.Context, appEntity *entities.App) []*models.AppEventModel {
+	objects, err := kube.AppEventObjects(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return nil
+	}
+	events, err := kube.ListEvents(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, objects)
+	if err != nil {
+		return nil
+	}
+	if len(events) > runningInfoEventLimit {
+		events = events[:runningInfoEventLimit]
+	}
+	return events
+}
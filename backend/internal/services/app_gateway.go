@@ -2,14 +2,24 @@ package services
 
 import (
 	"context"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type AppGatewayService interface {
@@ -34,24 +44,18 @@ func NewAppGatewayService() AppGatewayService {
 
 func (s *appGatewayService) ListAppGateways(ctx context.Context, req *models.ListAppGatewaysRequest) ([]*models.AppGatewayModel, app.Error) {
 	gateways := make([]*entities.AppGateway, 0)
-	if err := db.Instance().Where("app_id = ?", req.AppID).Find(&gateways).Error; err != nil {
-		log.Printf("failed to list app gateways: %v", err)
+	if err := db.WithContext(ctx).Where("app_id = ?", req.AppID).Find(&gateways).Error; err != nil {
+		logging.Errorf(ctx, "failed to list app gateways: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	result := make([]*models.AppGatewayModel, 0, len(gateways))
 	for _, gateway := range gateways {
-		result = append(result, &models.AppGatewayModel{
-			GatewayID:   gateway.ID,
-			Port:        gateway.Port,
-			Protocol:    gateway.Protocol,
-			Domain:      gateway.Domain,
-			Path:        gateway.Path,
-			CertID:      gateway.CertID,
-			GatewayPort: gateway.GatewayPort,
-			Exposed:     gateway.Exposed,
-			AppID:       gateway.AppID,
-		})
+		model, err := appGatewayModelFromEntity(ctx, gateway)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, model)
 	}
 
 	return result, nil
@@ -63,15 +67,29 @@ func (s *appGatewayService) CreateAppGateway(ctx context.Context, req *models.Cr
 		return nil, err
 	}
 
+	port, err := resolveAppGatewayPort(ctx, req.AppID, req.Port, req.PortName)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := encodeAppGatewayRules(ctx, appEntity, req.Protocol, req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAppGatewayRoutingBackend(ctx, appEntity, req.Protocol, req.Rules); err != nil {
+		return nil, err
+	}
+
 	gateway := &entities.AppGateway{
 		AppID:       req.AppID,
-		Port:        req.Port,
+		Port:        port,
+		PortName:    req.PortName,
 		Protocol:    req.Protocol,
 		Domain:      req.Domain,
 		Path:        req.Path,
 		CertID:      req.CertID,
 		GatewayPort: req.GatewayPort,
 		Exposed:     req.Exposed,
+		Rules:       rules,
 		EnvID:       appEntity.EnvID,
 		ProjectID:   appEntity.ProjectID,
 		AuditBase: entities.AuditBase{
@@ -80,22 +98,12 @@ func (s *appGatewayService) CreateAppGateway(ctx context.Context, req *models.Cr
 		},
 	}
 
-	if err := db.Instance().Create(gateway).Error; err != nil {
-		log.Printf("failed to create app gateway: %v", err)
+	if err := db.WithContext(ctx).Create(gateway).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app gateway: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
-	return &models.AppGatewayModel{
-		GatewayID:   gateway.ID,
-		Port:        gateway.Port,
-		Protocol:    gateway.Protocol,
-		Domain:      gateway.Domain,
-		Path:        gateway.Path,
-		CertID:      gateway.CertID,
-		GatewayPort: gateway.GatewayPort,
-		Exposed:     gateway.Exposed,
-		AppID:       gateway.AppID,
-	}, nil
+	return appGatewayModelFromEntity(ctx, gateway)
 }
 
 func (s *appGatewayService) UpdateAppGateway(ctx context.Context, req *models.UpdateAppGatewayRequest) (*models.AppGatewayModel, app.Error) {
@@ -104,31 +112,39 @@ func (s *appGatewayService) UpdateAppGateway(ctx context.Context, req *models.Up
 		return nil, err
 	}
 
-	gateway.Port = req.Port
+	port, err := resolveAppGatewayPort(ctx, gateway.AppID, req.Port, req.PortName)
+	if err != nil {
+		return nil, err
+	}
+	appEntity, err := orm.GetAppByID(ctx, gateway.AppID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := encodeAppGatewayRules(ctx, appEntity, req.Protocol, req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAppGatewayRoutingBackend(ctx, appEntity, req.Protocol, req.Rules); err != nil {
+		return nil, err
+	}
+
+	gateway.Port = port
+	gateway.PortName = req.PortName
 	gateway.Protocol = req.Protocol
 	gateway.Domain = req.Domain
 	gateway.Path = req.Path
 	gateway.CertID = req.CertID
 	gateway.GatewayPort = req.GatewayPort
 	gateway.Exposed = req.Exposed
+	gateway.Rules = rules
 	gateway.UpdatedBy = api.UserID(ctx)
 
-	if err := db.Instance().Select("Port", "Protocol", "Domain", "Path", "CertID", "GatewayPort", "Exposed", "UpdatedBy").Updates(gateway).Error; err != nil {
-		log.Printf("failed to update app gateway: %v", err)
+	if err := db.WithContext(ctx).Select("Port", "PortName", "Protocol", "Domain", "Path", "CertID", "GatewayPort", "Exposed", "Rules", "UpdatedBy").Updates(gateway).Error; err != nil {
+		logging.Errorf(ctx, "failed to update app gateway: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
-	return &models.AppGatewayModel{
-		GatewayID:   gateway.ID,
-		Port:        gateway.Port,
-		Protocol:    gateway.Protocol,
-		Domain:      gateway.Domain,
-		Path:        gateway.Path,
-		CertID:      gateway.CertID,
-		GatewayPort: gateway.GatewayPort,
-		Exposed:     gateway.Exposed,
-		AppID:       gateway.AppID,
-	}, nil
+	return appGatewayModelFromEntity(ctx, gateway)
 }
 
 func (s *appGatewayService) ToggleAppGatewayExposed(ctx context.Context, req *models.ToggleAppGatewayExposedRequest) app.Error {
@@ -139,8 +155,8 @@ func (s *appGatewayService) ToggleAppGatewayExposed(ctx context.Context, req *mo
 
 	gateway.Exposed = req.Exposed
 	gateway.UpdatedBy = api.UserID(ctx)
-	if err := db.Instance().Select("Exposed", "UpdatedBy").Updates(gateway).Error; err != nil {
-		log.Printf("failed to toggle app gateway exposed status: %v", err)
+	if err := db.WithContext(ctx).Select("Exposed", "UpdatedBy").Updates(gateway).Error; err != nil {
+		logging.Errorf(ctx, "failed to toggle app gateway exposed status: %v", err)
 		return app.ErrDatabaseOperationFailed
 	}
 	return nil
@@ -151,10 +167,316 @@ func (s *appGatewayService) DeleteAppGateways(ctx context.Context, req *models.D
 		return nil
 	}
 
-	if err := db.Instance().Delete(&entities.AppGateway{}, req.GatewayIDs).Error; err != nil {
-		log.Printf("failed to delete app gateways: %v", err)
+	if err := db.WithContext(ctx).Delete(&entities.AppGateway{}, req.GatewayIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app gateways: %v", err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	return nil
+}
+
+// resolveAppGatewayPort returns the port a gateway routes to, the service port
+// of the named app port if set.
+func resolveAppGatewayPort(ctx context.Context, appID string, port int32, portName string) (int32, app.Error) {
+	if portName == "" {
+		if port == 0 {
+			return 0, app.NewError(http.StatusBadRequest, "Either port or port name is required")
+		}
+		return port, nil
+	}
+
+	appPort := &entities.AppPort{}
+	if err := db.WithContext(ctx).First(appPort, "app_id = ? AND name = ?", appID, portName).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, app.NewError(http.StatusBadRequest, "App has no port named "+portName)
+		}
+		logging.Errorf(ctx, "failed to get port %s of app %s: %v", portName, appID, err)
+		return 0, app.ErrDatabaseOperationFailed
+	}
+	return appPort.ServicePort, nil
+}
+
+var httpHeaderNameRgx = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+\\-.^_`|~]+$")
+
+// encodeAppGatewayRules validates the HTTP route rules of a gateway of the
+// app and returns them JSON encoded.
+func encodeAppGatewayRules(ctx context.Context, appEntity *entities.App, protocol string, rules []*models.AppGatewayRuleModel) (string, app.Error) {
+	if len(rules) == 0 {
+		return "", nil
+	}
+	if protocol != app.AppGatewayProtocolHTTP && protocol != app.AppGatewayProtocolHTTPS {
+		return "", app.NewError(http.StatusBadRequest, "Only http and https gateways support rules")
+	}
+
+	for i, rule := range rules {
+		if err := validateAppGatewayRule(ctx, appEntity, rule); err != nil {
+			return "", app.NewError(err.Code(), fmt.Sprintf("Rule %d: %s", i+1, err.Message()))
+		}
+	}
+
+	b, err := json.Marshal(rules)
+	if err != nil {
+		logging.Errorf(ctx, "failed to marshal gateway rules of app %s: %v", appEntity.ID, err)
+		return "", app.NewError(http.StatusBadRequest, "Invalid gateway rules")
+	}
+	return string(b), nil
+}
+
+// validateAppGatewayRoutingBackend rejects gateways the Ingresses of clusters
+// routing through an ingress controller can't express, see ingressManifests.
+func validateAppGatewayRoutingBackend(ctx context.Context, appEntity *entities.App, protocol string, rules []*models.AppGatewayRuleModel) app.Error {
+	cluster, err := orm.GetClusterByID(ctx, appEntity.ClusterID)
+	if err != nil {
+		return err
+	}
+	if cluster.RoutingBackend != app.ClusterRoutingBackendIngress {
+		return nil
+	}
+	return validateIngressRoutableGateway(protocol, rules)
+}
+
+// validateIngressRoutableGateway rejects gateways an Ingress can't express.
+func validateIngressRoutableGateway(protocol string, rules []*models.AppGatewayRuleModel) app.Error {
+	if protocol != app.AppGatewayProtocolHTTP && protocol != app.AppGatewayProtocolHTTPS {
+		return app.NewError(http.StatusBadRequest, "Only http and https gateways are supported by clusters routing through Ingresses")
+	}
+	for i, rule := range rules {
+		unsupported := rule.RequestHeaders != nil || rule.ResponseHeaders != nil || rule.Rewrite != nil || rule.Redirect != nil || rule.Mirror != nil || len(rule.Backends) > 1
+		for _, match := range rule.Matches {
+			if match.Path == "" || match.Method != "" || len(match.Headers) > 0 || len(match.QueryParams) > 0 {
+				unsupported = true
+			}
+		}
+		if unsupported {
+			return app.NewError(http.StatusBadRequest, fmt.Sprintf("Rule %d: clusters routing through Ingresses only support path matches routed to a single backend", i+1))
+		}
+	}
+	return nil
+}
+
+// validateClusterGatewaysIngressRoutable makes sure the gateways of all apps
+// in the cluster can be expressed as Ingresses before the cluster switches to
+// the ingress routing backend.
+func validateClusterGatewaysIngressRoutable(ctx context.Context, clusterID string) app.Error {
+	var gateways []*entities.AppGateway
+	if err := db.WithContext(ctx).Joins("JOIN apps ON apps.id = app_gateways.app_id").Where("apps.cluster_id = ?", clusterID).Find(&gateways).Error; err != nil {
+		logging.Errorf(ctx, "failed to list app gateways of cluster %s: %v", clusterID, err)
 		return app.ErrDatabaseOperationFailed
 	}
 
+	for _, gateway := range gateways {
+		model, err := appGatewayModelFromEntity(ctx, gateway)
+		if err != nil {
+			return err
+		}
+		if err := validateIngressRoutableGateway(gateway.Protocol, model.Rules); err != nil {
+			appEntity, e := orm.GetAppByID(ctx, gateway.AppID)
+			if e != nil {
+				return e
+			}
+			return app.NewError(http.StatusConflict, fmt.Sprintf("Gateway %s of app %s can't route through Ingresses: %s", gatewayDisplayName(gateway), appEntity.Slug, err.Message()))
+		}
+	}
 	return nil
 }
+
+// gatewayDisplayName returns the domain of a gateway, or its port for tcp and
+// udp gateways.
+func gatewayDisplayName(gateway *entities.AppGateway) string {
+	if gateway.Domain != "" {
+		return gateway.Domain + gateway.Path
+	}
+	return fmt.Sprintf("%s/%d", gateway.Protocol, gateway.GatewayPort)
+}
+
+func validateAppGatewayRule(ctx context.Context, appEntity *entities.App, rule *models.AppGatewayRuleModel) app.Error {
+	for _, match := range rule.Matches {
+		if err := validateAppGatewayMatch(match); err != nil {
+			return err
+		}
+	}
+	for _, modifier := range []*models.AppGatewayHeaderModifierModel{rule.RequestHeaders, rule.ResponseHeaders} {
+		if err := validateAppGatewayHeaderModifier(modifier); err != nil {
+			return err
+		}
+	}
+
+	if rule.Rewrite != nil {
+		if rule.Rewrite.Hostname == "" && rule.Rewrite.PathType == "" {
+			return app.NewError(http.StatusBadRequest, "Rewrite needs a hostname or a path")
+		}
+		if err := validateAppGatewayHostname(rule.Rewrite.Hostname); err != nil {
+			return err
+		}
+		if err := validateAppGatewayPathModifier(rule, rule.Rewrite.PathType, rule.Rewrite.Path); err != nil {
+			return err
+		}
+	}
+
+	if rule.Redirect != nil {
+		if rule.Rewrite != nil || rule.Mirror != nil || len(rule.Backends) > 0 {
+			return app.NewError(http.StatusBadRequest, "Redirect can't be combined with rewrite, mirror or backends")
+		}
+		if rule.Redirect.Scheme == "" && rule.Redirect.Hostname == "" && rule.Redirect.Port == 0 && rule.Redirect.PathType == "" {
+			return app.NewError(http.StatusBadRequest, "Redirect needs a scheme, hostname, port or path")
+		}
+		if err := validateAppGatewayHostname(rule.Redirect.Hostname); err != nil {
+			return err
+		}
+		if err := validateAppGatewayPathModifier(rule, rule.Redirect.PathType, rule.Redirect.Path); err != nil {
+			return err
+		}
+	}
+
+	if rule.Mirror != nil {
+		if err := validateAppGatewayBackendApp(ctx, appEntity, rule.Mirror.AppID, rule.Mirror.Port); err != nil {
+			return err
+		}
+	}
+	for _, backend := range rule.Backends {
+		if err := validateAppGatewayBackendApp(ctx, appEntity, backend.AppID, backend.Port); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateAppGatewayMatch(match *models.AppGatewayMatchModel) app.Error {
+	if match.Path == "" {
+		if match.PathType != "" {
+			return app.NewError(http.StatusBadRequest, "Path is required for path type "+match.PathType)
+		}
+	} else if match.PathType == "RegularExpression" {
+		if _, err := regexp.Compile(match.Path); err != nil {
+			return app.NewError(http.StatusBadRequest, "Invalid path regular expression: "+err.Error())
+		}
+	} else if !strings.HasPrefix(match.Path, "/") {
+		return app.NewError(http.StatusBadRequest, "Path must start with /")
+	}
+
+	for _, valueMatch := range append(match.Headers, match.QueryParams...) {
+		if !httpHeaderNameRgx.MatchString(valueMatch.Name) {
+			return app.NewError(http.StatusBadRequest, "Invalid header or query param name "+valueMatch.Name)
+		}
+		if valueMatch.Type == "RegularExpression" {
+			if _, err := regexp.Compile(valueMatch.Value); err != nil {
+				return app.NewError(http.StatusBadRequest, "Invalid regular expression for "+valueMatch.Name+": "+err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func validateAppGatewayHeaderModifier(modifier *models.AppGatewayHeaderModifierModel) app.Error {
+	if modifier == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(modifier.Set)+len(modifier.Add)+len(modifier.Remove))
+	for _, header := range append(modifier.Set, modifier.Add...) {
+		names = append(names, header.Name)
+	}
+	names = append(names, modifier.Remove...)
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !httpHeaderNameRgx.MatchString(name) {
+			return app.NewError(http.StatusBadRequest, "Invalid header name "+name)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return app.NewError(http.StatusBadRequest, "Header "+name+" is modified more than once")
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func validateAppGatewayHostname(hostname string) app.Error {
+	if hostname == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+		return app.NewError(http.StatusBadRequest, "Invalid hostname "+hostname+": "+strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateAppGatewayPathModifier validates the path a rewrite or redirect
+// replaces, the matched prefix can only be replaced by rules matching path
+// prefixes.
+func validateAppGatewayPathModifier(rule *models.AppGatewayRuleModel, pathType, path string) app.Error {
+	if pathType == "" {
+		if path != "" {
+			return app.NewError(http.StatusBadRequest, "Path type is required to replace the path")
+		}
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return app.NewError(http.StatusBadRequest, "Replacement path must start with /")
+	}
+	if pathType == "ReplacePrefixMatch" {
+		for _, match := range rule.Matches {
+			if match.Path == "" || (match.PathType != "" && match.PathType != "PathPrefix") {
+				return app.NewError(http.StatusBadRequest, "Prefix can only be replaced when all matches match a path prefix")
+			}
+		}
+	}
+	return nil
+}
+
+// validateAppGatewayBackendApp checks that requests can be routed or mirrored
+// to the port of an app, which must be in the same env as the gateway app.
+func validateAppGatewayBackendApp(ctx context.Context, appEntity *entities.App, appID string, port int32) app.Error {
+	if appID == "" || appID == appEntity.ID {
+		return nil
+	}
+	if port == 0 {
+		return app.NewError(http.StatusBadRequest, "Port is required to route to another app")
+	}
+
+	slugs, err := orm.GetEnvAppSlugs(ctx, appEntity.EnvID, []string{appID})
+	if err != nil {
+		return err
+	}
+	slug, ok := slugs[appID]
+	if !ok {
+		return app.NewError(http.StatusBadRequest, "Requests can only be routed to apps of the same env")
+	}
+
+	ports, err := orm.AllAppPorts(ctx, appID)
+	if err != nil {
+		return err
+	}
+	gateways, err := orm.AllAppGateways(appID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(core.ServicePorts(ports, gateways), port) {
+		return app.NewError(http.StatusBadRequest, fmt.Sprintf("App %s has no service port %d", slug, port))
+	}
+	return nil
+}
+
+func appGatewayModelFromEntity(ctx context.Context, gateway *entities.AppGateway) (*models.AppGatewayModel, app.Error) {
+	result := &models.AppGatewayModel{
+		GatewayID:   gateway.ID,
+		Port:        gateway.Port,
+		PortName:    gateway.PortName,
+		Protocol:    gateway.Protocol,
+		Domain:      gateway.Domain,
+		Path:        gateway.Path,
+		CertID:      gateway.CertID,
+		GatewayPort: gateway.GatewayPort,
+		Exposed:     gateway.Exposed,
+		AppID:       gateway.AppID,
+	}
+	if gateway.Rules != "" {
+		if err := json.Unmarshal([]byte(gateway.Rules), &result.Rules); err != nil {
+			logging.Errorf(ctx, "failed to unmarshal rules of app gateway %s: %v", gateway.ID, err)
+			return nil, app.NewError(http.StatusInternalServerError, "failed to parse gateway rules")
+		}
+	}
+	return result, nil
+}
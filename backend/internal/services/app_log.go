@@ -0,0 +1,328 @@
+package services
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/metrics"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/logstream"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// maxLogLineSize bounds a single log line, longer lines are split.
+	maxLogLineSize = 1 << 20
+	// appLogInstanceResync is how often a followed app log stream looks for
+	// newly started instances.
+	appLogInstanceResync = 2 * time.Second
+)
+
+type AppLogService interface {
+	StreamAppLogs(ctx context.Context, req *models.AppLogsRequest) app.Error
+	DownloadAppLogs(ctx context.Context, req *models.AppLogsRequest) app.Error
+}
+
+type appLogService struct {
+	Service
+}
+
+var appLogServiceInstance = &appLogService{
+	Service: LoadService(),
+}
+
+func NewAppLogService() AppLogService {
+	return appLogServiceInstance
+}
+
+// appLogLine is a log line of an app instance.
+type appLogLine struct {
+	instance string
+	text     string
+}
+
+func (l appLogLine) String() string {
+	return "[" + l.instance + "] " + l.text
+}
+
+// StreamAppLogs merges the logs of all instances of an app into one SSE
+// stream, each line prefixed with its instance. Following the logs also picks
+// up instances started later.
+func (s *appLogService) StreamAppLogs(ctx context.Context, req *models.AppLogsRequest) app.Error {
+	appEntity, clientset, filter, err := appLogTarget(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	pods, err := kube.ListPods(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return err
+	}
+
+	w, e := logstream.NewSSEWriter(req.ResponseWriter)
+	if e != nil {
+		return app.NewError(http.StatusInternalServerError, "Streaming unsupported")
+	}
+
+	sessions := metrics.SSESessions.WithLabelValues("logs")
+	sessions.Inc()
+	defer sessions.Dec()
+
+	streamCtx, cancel := context.WithCancel(req.Request.Context())
+	defer cancel()
+
+	lines := make(chan appLogLine, 256)
+	var wg sync.WaitGroup
+	started := map[string]bool{}
+	startInstances := func(pods []*corev1.Pod, opts func() *corev1.PodLogOptions) {
+		for _, pod := range pods {
+			if started[pod.Name] || !podHasStarted(pod) {
+				continue
+			}
+			started[pod.Name] = true
+
+			podOpts := opts()
+			podOpts.Container = appLogContainer(appEntity, pod, req.ContainerName)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				streamPodLogLines(streamCtx, clientset, appEntity.ClusterNamespace, pod.Name, podOpts, filter, lines)
+			}()
+		}
+	}
+	startInstances(pods, func() *corev1.PodLogOptions { return appLogOptions(req) })
+
+	if !req.Follow {
+		go func() {
+			wg.Wait()
+			close(lines)
+		}()
+	}
+
+	resync := time.NewTicker(appLogInstanceResync)
+	defer resync.Stop()
+	heartbeat := time.NewTicker(logstream.DefaultHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				w.End(nil)
+				return nil
+			}
+			if e := w.Data(line.String()); e != nil {
+				return nil
+			}
+			heartbeat.Reset(logstream.DefaultHeartbeat)
+		case <-heartbeat.C:
+			if e := w.Heartbeat(); e != nil {
+				return nil
+			}
+		case <-resync.C:
+			if !req.Follow {
+				continue
+			}
+			pods, err := kube.ListPods(streamCtx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+			if err != nil {
+				continue
+			}
+			// Instances started after the stream began are read from their
+			// first line.
+			startInstances(pods, func() *corev1.PodLogOptions {
+				return &corev1.PodLogOptions{
+					Follow:     true,
+					Timestamps: req.ShowTimestamps,
+					LimitBytes: appLogOptions(req).LimitBytes,
+				}
+			})
+		case <-streamCtx.Done():
+			return nil
+		}
+	}
+}
+
+// DownloadAppLogs writes the logs of all instances of an app as a gzip file,
+// instance by instance.
+func (s *appLogService) DownloadAppLogs(ctx context.Context, req *models.AppLogsRequest) app.Error {
+	appEntity, clientset, filter, err := appLogTarget(ctx, req)
+	if err != nil {
+		return err
+	}
+	req.Follow = false
+
+	pods, err := kube.ListPods(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		return err
+	}
+
+	w := req.ResponseWriter
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%s.log.gz", appEntity.Slug, time.Now().Format("20060102150405"))))
+	w.WriteHeader(http.StatusOK)
+
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+
+	reqCtx := req.Request.Context()
+	for _, pod := range pods {
+		if !podHasStarted(pod) {
+			continue
+		}
+		opts := appLogOptions(req)
+		opts.Container = appLogContainer(appEntity, pod, req.ContainerName)
+
+		podCtx, cancel := context.WithCancel(reqCtx)
+		lines := make(chan appLogLine, 256)
+		go func() {
+			streamPodLogLines(podCtx, clientset, appEntity.ClusterNamespace, pod.Name, opts, filter, lines)
+			close(lines)
+		}()
+		var writeErr error
+		for line := range lines {
+			if writeErr != nil {
+				continue
+			}
+			if _, writeErr = io.WriteString(gz, line.String()+"\n"); writeErr != nil {
+				cancel()
+			}
+		}
+		cancel()
+		if writeErr != nil {
+			logging.Errorf(ctx, "failed to write logs of app %s: %v", appEntity.ID, writeErr)
+			return nil
+		}
+	}
+	return nil
+}
+
+func appLogTarget(ctx context.Context, req *models.AppLogsRequest) (*entities.App, kubernetes.Interface, *logFilter, app.Error) {
+	filter, err := newLogFilter(req.Filter, req.Regex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	clientset, err := kube.ClusterClientset(ctx, appEntity.ClusterID, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return appEntity, clientset, filter, nil
+}
+
+func appLogOptions(req *models.AppLogsRequest) *corev1.PodLogOptions {
+	return podLogOptions(req.Follow, req.TailLines, req.SinceSeconds, req.SinceTime, req.LimitBytes, req.ShowTimestamps)
+}
+
+// podLogOptions returns the log options of a container, since seconds take
+// precedence over since time as the API accepts only one of them.
+func podLogOptions(follow bool, tailLines, sinceSeconds int64, sinceTime time.Time, limitBytes int64, timestamps bool) *corev1.PodLogOptions {
+	opts := &corev1.PodLogOptions{
+		Follow:     follow,
+		Timestamps: timestamps,
+	}
+	if tailLines > 0 {
+		opts.TailLines = &tailLines
+	}
+	if sinceSeconds > 0 {
+		opts.SinceSeconds = &sinceSeconds
+	} else if !sinceTime.IsZero() {
+		opts.SinceTime = &metav1.Time{Time: sinceTime}
+	}
+	if limitBytes > 0 {
+		opts.LimitBytes = &limitBytes
+	}
+	return opts
+}
+
+// appLogContainer returns the container logs are read from, the main
+// container unless one is requested.
+func appLogContainer(appEntity *entities.App, pod *corev1.Pod, containerName string) string {
+	if containerName != "" {
+		return containerName
+	}
+	if main := kube.MainContainer(appEntity.Slug, pod); main != nil {
+		return main.Name
+	}
+	return ""
+}
+
+// podHasStarted tells whether the pod has containers logs can be read from.
+func podHasStarted(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running != nil || status.State.Terminated != nil || status.RestartCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// streamPodLogLines sends the matching log lines of a pod to lines until the
+// stream ends or ctx is done.
+func streamPodLogLines(ctx context.Context, clientset kubernetes.Interface, namespace, podName string, opts *corev1.PodLogOptions, filter *logFilter, lines chan<- appLogLine) {
+	rc, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		logging.Errorf(ctx, "failed to stream logs of pod %s in namespace %s: %v", podName, namespace, err)
+		return
+	}
+
+	stream := logstream.NewStream(ctx, rc, maxLogLineSize)
+	for text := range stream.Lines() {
+		if !filter.match(text) {
+			continue
+		}
+		select {
+		case lines <- appLogLine{instance: podName, text: text}:
+		case <-ctx.Done():
+			// Wait for the stream to be closed.
+			for range stream.Lines() {
+			}
+			return
+		}
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		logging.Errorf(ctx, "failed to read logs of pod %s in namespace %s: %v", podName, namespace, err)
+	}
+}
+
+// logFilter matches log lines by substring or regular expression, the zero
+// filter matches all lines.
+type logFilter struct {
+	substr string
+	re     *regexp.Regexp
+}
+
+func newLogFilter(filter string, isRegex bool) (*logFilter, app.Error) {
+	if !isRegex {
+		return &logFilter{substr: filter}, nil
+	}
+	re, err := regexp.Compile(filter)
+	if err != nil {
+		return nil, app.NewError(http.StatusBadRequest, "Invalid filter regular expression: "+err.Error())
+	}
+	return &logFilter{re: re}, nil
+}
+
+func (f *logFilter) match(line string) bool {
+	if f.re != nil {
+		return f.re.MatchString(line)
+	}
+	return strings.Contains(line, f.substr)
+}
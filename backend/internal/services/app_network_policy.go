@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+)
+
+type AppNetworkPolicyService interface {
+	GetAppNetworkPolicy(ctx context.Context, req *models.GetAppNetworkPolicyRequest) (*models.AppNetworkPolicyModel, app.Error)
+	SetAppNetworkPolicy(ctx context.Context, req *models.SetAppNetworkPolicyRequest) (*models.AppNetworkPolicyModel, app.Error)
+	DeleteAppNetworkPolicy(ctx context.Context, req *models.DeleteAppNetworkPolicyRequest) app.Error
+}
+
+type appNetworkPolicyService struct {
+	Service
+}
+
+var appNetworkPolicyServiceInstance = &appNetworkPolicyService{
+	Service: LoadService(),
+}
+
+func NewAppNetworkPolicyService() AppNetworkPolicyService {
+	return appNetworkPolicyServiceInstance
+}
+
+func (s *appNetworkPolicyService) GetAppNetworkPolicy(ctx context.Context, req *models.GetAppNetworkPolicyRequest) (*models.AppNetworkPolicyModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := orm.GetAppNetworkPolicy(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+
+	return appNetworkPolicyModelFromEntity(ctx, appEntity.EnvID, entity)
+}
+
+func (s *appNetworkPolicyService) SetAppNetworkPolicy(ctx context.Context, req *models.SetAppNetworkPolicyRequest) (*models.AppNetworkPolicyModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromApps := slices.Compact(slices.Sorted(slices.Values(req.AllowFromApps)))
+	if slices.Contains(fromApps, appEntity.ID) {
+		return nil, app.NewError(http.StatusBadRequest, "An app can't be a source of its own network policy")
+	}
+	slugs, err := orm.GetEnvAppSlugs(ctx, appEntity.EnvID, fromApps)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range fromApps {
+		if _, ok := slugs[id]; !ok {
+			return nil, app.NewError(http.StatusBadRequest, "App "+id+" is not in the env of the app")
+		}
+	}
+
+	entity, err := orm.GetAppNetworkPolicy(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		entity = &entities.AppNetworkPolicy{
+			AppID: req.AppID,
+			AuditBase: entities.AuditBase{
+				CreatedBy: api.UserID(ctx),
+			},
+		}
+	}
+	entity.AllowGateway = req.AllowGateway
+	entity.AllowFromApps = strings.Join(fromApps, ",")
+	entity.UpdatedBy = api.UserID(ctx)
+
+	if err := db.WithContext(ctx).Save(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to save app network policy for app %s: %v", req.AppID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after setting network policy for app %s: %v", req.AppID, err)
+	}
+
+	return appNetworkPolicyModelFromEntity(ctx, appEntity.EnvID, entity)
+}
+
+func (s *appNetworkPolicyService) DeleteAppNetworkPolicy(ctx context.Context, req *models.DeleteAppNetworkPolicyRequest) app.Error {
+	if err := db.WithContext(ctx).Where("app_id = ?", req.AppID).Delete(&entities.AppNetworkPolicy{}).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app network policy for app %s: %v", req.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after deleting network policy for app %s: %v", req.AppID, err)
+	}
+
+	return nil
+}
+
+func appNetworkPolicyModelFromEntity(ctx context.Context, envID string, entity *entities.AppNetworkPolicy) (*models.AppNetworkPolicyModel, app.Error) {
+	result := &models.AppNetworkPolicyModel{
+		PolicyID:      entity.ID,
+		AppID:         entity.AppID,
+		AllowGateway:  entity.AllowGateway,
+		AllowFromApps: []*models.AppNetworkPeer{},
+	}
+	if entity.AllowFromApps == "" {
+		return result, nil
+	}
+
+	fromApps := strings.Split(entity.AllowFromApps, ",")
+	slugs, err := orm.GetEnvAppSlugs(ctx, envID, fromApps)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range fromApps {
+		if slug, ok := slugs[id]; ok {
+			result.AllowFromApps = append(result.AllowFromApps, &models.AppNetworkPeer{
+				AppID: id,
+				Slug:  slug,
+			})
+		}
+	}
+
+	return result, nil
+}
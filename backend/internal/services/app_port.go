@@ -0,0 +1,206 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"gorm.io/gorm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type AppPortService interface {
+	ListAppPorts(ctx context.Context, req *models.ListAppPortsRequest) ([]*models.AppPortModel, app.Error)
+	CreateAppPort(ctx context.Context, req *models.CreateAppPortRequest) (*models.AppPortModel, app.Error)
+	UpdateAppPort(ctx context.Context, req *models.UpdateAppPortRequest) (*models.AppPortModel, app.Error)
+	DeleteAppPort(ctx context.Context, req *models.DeleteAppPortRequest) app.Error
+}
+
+type appPortService struct {
+	Service
+}
+
+var appPortServiceInstance = &appPortService{
+	Service: LoadService(),
+}
+
+func NewAppPortService() AppPortService {
+	return appPortServiceInstance
+}
+
+func (s *appPortService) ListAppPorts(ctx context.Context, req *models.ListAppPortsRequest) ([]*models.AppPortModel, app.Error) {
+	ports, err := orm.AllAppPorts(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.AppPortModel, 0, len(ports))
+	for _, port := range ports {
+		result = append(result, appPortModelFromEntity(port))
+	}
+	return result, nil
+}
+
+func (s *appPortService) CreateAppPort(ctx context.Context, req *models.CreateAppPortRequest) (*models.AppPortModel, app.Error) {
+	if errs := validation.IsValidPortName(req.Name); len(errs) > 0 {
+		return nil, app.NewError(http.StatusBadRequest, "Invalid port name: "+strings.Join(errs, "; "))
+	}
+
+	if _, err := orm.GetAppByID(ctx, req.AppID); err != nil {
+		return nil, err
+	}
+
+	port := &entities.AppPort{
+		AppID:         req.AppID,
+		Name:          req.Name,
+		ContainerPort: req.ContainerPort,
+		Protocol:      req.Protocol,
+		ServicePort:   req.ServicePort,
+		AppProtocol:   req.AppProtocol,
+		AuditBase: entities.AuditBase{
+			CreatedBy: api.UserID(ctx),
+			UpdatedBy: api.UserID(ctx),
+		},
+	}
+	defaultAppPort(port)
+
+	if err := db.WithContext(ctx).Create(port).Error; err != nil {
+		logging.Errorf(ctx, "failed to create port %s for app %s: %v", req.Name, req.AppID, err)
+		if db.IsErrDuplicatedKey(err) {
+			return nil, app.NewError(http.StatusConflict, "Port name or service port is already used by the app")
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after creating port for app %s: %v", req.AppID, err)
+	}
+
+	return appPortModelFromEntity(port), nil
+}
+
+func (s *appPortService) UpdateAppPort(ctx context.Context, req *models.UpdateAppPortRequest) (*models.AppPortModel, app.Error) {
+	port, err := getAppPort(ctx, req.AppID, req.PortID)
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol := cmp.Or(req.Protocol, string(corev1.ProtocolTCP)); protocol != port.Protocol {
+		if err := checkAppPortGatewayProtocols(ctx, port, protocol); err != nil {
+			return nil, err
+		}
+	}
+
+	port.ContainerPort = req.ContainerPort
+	port.Protocol = req.Protocol
+	port.ServicePort = req.ServicePort
+	port.AppProtocol = req.AppProtocol
+	port.UpdatedBy = api.UserID(ctx)
+	defaultAppPort(port)
+
+	if err := db.WithContext(ctx).Select("ContainerPort", "Protocol", "ServicePort", "AppProtocol", "UpdatedBy").Updates(port).Error; err != nil {
+		logging.Errorf(ctx, "failed to update port %s of app %s: %v", req.PortID, req.AppID, err)
+		if db.IsErrDuplicatedKey(err) {
+			return nil, app.NewError(http.StatusConflict, "Service port is already used by the app")
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after updating port for app %s: %v", req.AppID, err)
+	}
+
+	return appPortModelFromEntity(port), nil
+}
+
+func (s *appPortService) DeleteAppPort(ctx context.Context, req *models.DeleteAppPortRequest) app.Error {
+	port, err := getAppPort(ctx, req.AppID, req.PortID)
+	if err != nil {
+		return err
+	}
+
+	var gateways int64
+	if err := db.WithContext(ctx).Model(&entities.AppGateway{}).Where("app_id = ? AND port_name = ?", req.AppID, port.Name).Count(&gateways).Error; err != nil {
+		logging.Errorf(ctx, "failed to count gateways routed to port %s of app %s: %v", port.Name, req.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+	if gateways > 0 {
+		return app.NewError(http.StatusConflict, "Port "+port.Name+" is still routed to by gateways of the app")
+	}
+
+	if err := db.WithContext(ctx).Delete(port).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete port %s of app %s: %v", req.PortID, req.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after deleting port for app %s: %v", req.AppID, err)
+	}
+
+	return nil
+}
+
+// checkAppPortGatewayProtocols makes sure the gateways routed to the port by
+// name still match it once its protocol changes: udp gateways route to UDP
+// ports, the other gateways to TCP ports.
+func checkAppPortGatewayProtocols(ctx context.Context, port *entities.AppPort, protocol string) app.Error {
+	var gatewayProtocols []string
+	if err := db.WithContext(ctx).Model(&entities.AppGateway{}).Where("app_id = ? AND port_name = ?", port.AppID, port.Name).Distinct().Pluck("protocol", &gatewayProtocols).Error; err != nil {
+		logging.Errorf(ctx, "failed to list gateways routed to port %s of app %s: %v", port.Name, port.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+	for _, gatewayProtocol := range gatewayProtocols {
+		want := corev1.ProtocolTCP
+		if gatewayProtocol == app.AppGatewayProtocolUDP {
+			want = corev1.ProtocolUDP
+		}
+		if protocol != string(want) {
+			return app.NewError(http.StatusConflict, fmt.Sprintf("Port %s is routed to by %s gateways, which need a %s port", port.Name, gatewayProtocol, want))
+		}
+	}
+	return nil
+}
+
+func getAppPort(ctx context.Context, appID, portID string) (*entities.AppPort, app.Error) {
+	port := &entities.AppPort{}
+	if err := db.WithContext(ctx).First(port, "id = ? AND app_id = ?", portID, appID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, app.NewError(http.StatusNotFound, "App port not found")
+		}
+		logging.Errorf(ctx, "failed to get port %s of app %s: %v", portID, appID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	return port, nil
+}
+
+func defaultAppPort(port *entities.AppPort) {
+	if port.Protocol == "" {
+		port.Protocol = string(corev1.ProtocolTCP)
+	}
+	if port.ServicePort == 0 {
+		port.ServicePort = port.ContainerPort
+	}
+}
+
+func appPortModelFromEntity(port *entities.AppPort) *models.AppPortModel {
+	return &models.AppPortModel{
+		PortID:        port.ID,
+		AppID:         port.AppID,
+		Name:          port.Name,
+		ContainerPort: port.ContainerPort,
+		Protocol:      port.Protocol,
+		ServicePort:   port.ServicePort,
+		AppProtocol:   port.AppProtocol,
+	}
+}
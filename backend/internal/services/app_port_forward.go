@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/metrics"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/websocket"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type AppPortForwardService interface {
+	PortForwardAppInstance(ctx context.Context, req *models.PortForwardAppInstanceRequest) app.Error
+}
+
+type appPortForwardService struct {
+	Service
+}
+
+var appPortForwardServiceInstance = &appPortForwardService{
+	Service: LoadService(),
+}
+
+func NewAppPortForwardService() AppPortForwardService {
+	return appPortForwardServiceInstance
+}
+
+// PortForwardAppInstance tunnels a websocket to a port of an app instance.
+// Each websocket carries one TCP connection, clients open one per local
+// connection.
+func (s *appPortForwardService) PortForwardAppInstance(ctx context.Context, req *models.PortForwardAppInstanceRequest) app.Error {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return err
+	}
+
+	pod, err := appInstancePod(ctx, appEntity, req.InstanceName)
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return app.NewError(http.StatusBadRequest, "Only running instances can be port forwarded")
+	}
+
+	stream, err := kube.DialPodPort(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, pod.Name, req.Port)
+	if err != nil {
+		return err
+	}
+
+	conn, err := websocket.NewConn(req.ResponseWriter, req.Request)
+	if err != nil {
+		stream.Close()
+		logging.Errorf(ctx, "Error upgrading connection to WebSocket: %v", err)
+		return app.NewError(http.StatusInternalServerError, "Failed to upgrade connection to WebSocket")
+	}
+	sessions := metrics.WebSocketSessions.WithLabelValues("port_forward")
+	sessions.Inc()
+	defer sessions.Dec()
+
+	if e := websocket.Pipe(conn, stream); e != nil {
+		logging.Infof(ctx, "port forward to %s port %d of app %s ended: %v", pod.Name, req.Port, appEntity.ID, e)
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/ketches/ketches/internal/api"
@@ -10,6 +9,7 @@ import (
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/ketches/ketches/pkg/uuid"
 )
@@ -36,10 +36,10 @@ func NewAppProbeService() AppProbeService {
 
 func (s *appProbeService) ListAppProbes(ctx context.Context, req *models.ListAppProbesRequest) ([]*models.AppProbeModel, app.Error) {
 	var result []*models.AppProbeModel
-	if err := db.Instance().Model(&entities.AppProbe{}).
+	if err := db.WithContext(ctx).Model(&entities.AppProbe{}).
 		Where("app_id = ?", req.AppID).
 		Find(&result).Error; err != nil {
-		log.Printf("failed to list probes for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to list probes for app %s: %v", req.AppID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -72,16 +72,16 @@ func (s *appProbeService) CreateAppProbe(ctx context.Context, req *models.Create
 	case app.AppProbeModeExec:
 		entity.ExecCommand = req.Probe.ExecCommand
 	default:
-		log.Printf("invalid probe mode: %s", req.Probe.ProbeMode)
+		logging.Warnf(ctx, "invalid probe mode: %s", req.Probe.ProbeMode)
 		return nil, app.NewError(http.StatusBadRequest, "invalid probe mode: "+req.Probe.ProbeMode)
 	}
-	if err := db.Instance().Create(entity).Error; err != nil {
-		log.Printf("failed to create app probe for app %s: %v", req.AppID, err)
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app probe for app %s: %v", req.AppID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after creating probe for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after creating probe for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppProbeModel{
@@ -95,8 +95,8 @@ func (s *appProbeService) CreateAppProbe(ctx context.Context, req *models.Create
 
 func (s *appProbeService) UpdateAppProbe(ctx context.Context, req *models.UpdateAppProbeRequest) (*models.AppProbeModel, app.Error) {
 	var entity entities.AppProbe
-	if err := db.Instance().First(&entity, "id = ?", req.ProbeID).Error; err != nil {
-		log.Printf("failed to find app probe %s: %v", req.ProbeID, err)
+	if err := db.WithContext(ctx).First(&entity, "id = ?", req.ProbeID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find app probe %s: %v", req.ProbeID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "probe not found")
 		}
@@ -140,15 +140,15 @@ func (s *appProbeService) UpdateAppProbe(ctx context.Context, req *models.Update
 
 	entity.AuditBase.UpdatedBy = api.UserID(ctx)
 
-	if err := db.Instance().Model(&entity).Select("Enabled", "Type", "ProbeMode", "HTTPGetPath", "HTTPGetPort", "TCPSocketPort", "ExecCommand", "InitialDelaySeconds", "TimeoutSeconds", "PeriodSeconds",
+	if err := db.WithContext(ctx).Model(&entity).Select("Enabled", "Type", "ProbeMode", "HTTPGetPath", "HTTPGetPort", "TCPSocketPort", "ExecCommand", "InitialDelaySeconds", "TimeoutSeconds", "PeriodSeconds",
 		"SuccessThreshold", "FailureThreshold", "UpdatedBy").
 		Updates(&entity).Error; err != nil {
-		log.Printf("failed to update probe var %s: %v", req.ProbeID, err)
+		logging.Errorf(ctx, "failed to update probe var %s: %v", req.ProbeID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after updating probe var for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after updating probe var for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppProbeModel{
@@ -162,8 +162,8 @@ func (s *appProbeService) UpdateAppProbe(ctx context.Context, req *models.Update
 
 func (s *appProbeService) ToggleAppProbe(ctx context.Context, req *models.ToggleAppProbeRequest) (*models.AppProbeModel, app.Error) {
 	var entity entities.AppProbe
-	if err := db.Instance().First(&entity, "id = ?", req.ProbeID).Error; err != nil {
-		log.Printf("failed to find app probe %s: %v", req.ProbeID, err)
+	if err := db.WithContext(ctx).First(&entity, "id = ?", req.ProbeID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find app probe %s: %v", req.ProbeID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "probe not found")
 		}
@@ -173,14 +173,14 @@ func (s *appProbeService) ToggleAppProbe(ctx context.Context, req *models.Toggle
 	entity.Enabled = req.Enabled
 	entity.AuditBase.UpdatedBy = api.UserID(ctx)
 
-	if err := db.Instance().Model(&entity).Select("Enabled", "UpdatedBy").
+	if err := db.WithContext(ctx).Model(&entity).Select("Enabled", "UpdatedBy").
 		Updates(&entity).Error; err != nil {
-		log.Printf("failed to update probe var %s: %v", req.ProbeID, err)
+		logging.Errorf(ctx, "failed to update probe var %s: %v", req.ProbeID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after updating probe var for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after updating probe var for app %s: %v", req.AppID, err)
 	}
 
 	return &models.AppProbeModel{
@@ -192,13 +192,13 @@ func (s *appProbeService) ToggleAppProbe(ctx context.Context, req *models.Toggle
 }
 
 func (s *appProbeService) DeleteAppProbe(ctx context.Context, req *models.DeleteAppProbeRequest) app.Error {
-	if err := db.Instance().Delete(&entities.AppProbe{}, "id = ?", req.ProbeID).Error; err != nil {
-		log.Printf("failed to delete app probe for app %s: %v", req.AppID, err)
+	if err := db.WithContext(ctx).Delete(&entities.AppProbe{}, "id = ?", req.ProbeID).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app probe for app %s: %v", req.AppID, err)
 		return app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after creating env var for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after creating env var for app %s: %v", req.AppID, err)
 	}
 
 	return nil
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+)
+
+type AppReleaseService interface {
+	GetAppRelease(ctx context.Context, req *models.GetAppReleaseRequest) (*models.AppReleaseModel, app.Error)
+	AppReleaseAction(ctx context.Context, req *models.AppReleaseActionRequest) (*models.AppReleaseModel, app.Error)
+}
+
+type appReleaseService struct {
+	Service
+}
+
+var appReleaseServiceInstance = &appReleaseService{
+	Service: LoadService(),
+}
+
+func NewAppReleaseService() AppReleaseService {
+	return appReleaseServiceInstance
+}
+
+func (s *appReleaseService) GetAppRelease(ctx context.Context, req *models.GetAppReleaseRequest) (*models.AppReleaseModel, app.Error) {
+	release, err := orm.GetAppRelease(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, nil
+	}
+
+	strategy, err := orm.GetAppRolloutStrategy(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	return appReleaseModelFromEntity(release, strategy, time.Now()), nil
+}
+
+func (s *appReleaseService) AppReleaseAction(ctx context.Context, req *models.AppReleaseActionRequest) (*models.AppReleaseModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	release, err := orm.GetActiveAppRelease(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, app.NewError(http.StatusConflict, "App has no active release")
+	}
+	strategy, err := orm.GetAppRolloutStrategy(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch req.Action {
+	case app.ReleaseActionPause:
+		if release.State != app.ReleaseStateProgressing {
+			return nil, app.NewError(http.StatusConflict, "Only progressing releases can be paused")
+		}
+		err = updateAppRelease(ctx, release, map[string]any{"state": app.ReleaseStatePaused})
+	case app.ReleaseActionResume:
+		if release.State != app.ReleaseStatePaused {
+			return nil, app.NewError(http.StatusConflict, "Only paused releases can be resumed")
+		}
+		// The current step starts over, the time it was paused doesn't count
+		err = updateAppRelease(ctx, release, map[string]any{
+			"state":           app.ReleaseStateProgressing,
+			"step_started_at": now,
+		})
+	case app.ReleaseActionPromote:
+		if release.State == app.ReleaseStatePromoting {
+			return nil, app.NewError(http.StatusConflict, "Release is already being promoted")
+		}
+		if release.CanaryEdition != appEntity.Edition {
+			return nil, app.NewError(http.StatusConflict, "App changed since the release started, deploy it to release the new edition")
+		}
+		if !isAppReleaseHealthy(release, strategy, now) {
+			return nil, app.NewError(http.StatusConflict, "The new edition has not been healthy for the healthy period yet")
+		}
+		err = promoteAppRelease(ctx, appEntity, release)
+	case app.ReleaseActionAbort:
+		if release.State == app.ReleaseStatePromoting {
+			return nil, app.NewError(http.StatusConflict, "Release is being promoted, the previous edition is no longer running")
+		}
+		err = abortAppRelease(ctx, appEntity, release, "Aborted manually")
+	default:
+		return nil, app.NewError(http.StatusBadRequest, "Unknown release action")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	release, err = orm.GetAppRelease(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	return appReleaseModelFromEntity(release, strategy, now), nil
+}
+
+// startAppRelease deploys the app as a release of its edition when its
+// rollout strategy has a release type and the stable workload is running
+// another edition, it reports whether it did. A release in progress for
+// another edition starts over for the app edition.
+func startAppRelease(ctx context.Context, appEntity *entities.App, options *core.AppDeployOption) (bool, app.Error) {
+	if appEntity.AppType != app.AppTypeDeployment {
+		return false, nil
+	}
+	strategy, err := orm.GetAppRolloutStrategy(ctx, appEntity.ID)
+	if err != nil {
+		return false, err
+	}
+	if strategy == nil || strategy.ReleaseType == "" {
+		return false, nil
+	}
+
+	stable, err := kube.GetDeployment(ctx, appEntity.ClusterID, appEntity.ClusterNamespace, appEntity.Slug)
+	if err != nil {
+		if err.Code() == http.StatusNotFound {
+			// First deploys have nothing to release against
+			return false, nil
+		}
+		return false, err
+	}
+	stableEdition := stable.Labels["ketches.cn/edition"]
+	if stableEdition == appEntity.Edition || stable.Labels["ketches.cn/debugging"] == "true" {
+		return false, nil
+	}
+	if stable.Spec.Replicas != nil && *stable.Spec.Replicas == 0 {
+		// Stopped apps serve no traffic to shift
+		return false, nil
+	}
+
+	release, err := orm.GetAppRelease(ctx, appEntity.ID)
+	if err != nil {
+		return false, err
+	}
+	if release == nil {
+		release = &entities.AppRelease{
+			AppID: appEntity.ID,
+			AuditBase: entities.AuditBase{
+				CreatedBy: api.UserID(ctx),
+			},
+		}
+	}
+	if release.CanaryEdition == appEntity.Edition && (release.State == app.ReleaseStateProgressing || release.State == app.ReleaseStatePaused || release.State == app.ReleaseStateSwitched || release.State == app.ReleaseStatePromoting) {
+		// Already released, the deploy only applies it again
+		return false, nil
+	}
+
+	release.ReleaseType = strategy.ReleaseType
+	release.StableEdition = stableEdition
+	release.CanaryEdition = appEntity.Edition
+	// No traffic is shifted before the new edition is healthy, the scheduler
+	// moves canary releases to their first step then
+	release.Weight = 0
+	release.Step = -1
+	release.State = app.ReleaseStateProgressing
+	release.StepStartedAt = time.Now()
+	release.HealthySince = nil
+	release.Message = ""
+	release.UpdatedBy = api.UserID(ctx)
+	if err := db.WithContext(ctx).Save(release).Error; err != nil {
+		logging.Errorf(ctx, "failed to save release of app %s: %v", appEntity.ID, err)
+		return false, app.ErrDatabaseOperationFailed
+	}
+
+	return true, appServiceInstance.deployApp(ctx, appEntity, options)
+}
+
+// promoteAppRelease promotes the release of a canary edition, or switches all
+// traffic to it first for blue-green releases. Promoting releases roll the
+// stable workload to the new edition while the canary workload keeps serving,
+// the release scheduler prunes the canary workload once the rollout is done.
+func promoteAppRelease(ctx context.Context, appEntity *entities.App, release *entities.AppRelease) app.Error {
+	if release.ReleaseType == app.ReleaseTypeBlueGreen && release.State != app.ReleaseStateSwitched {
+		if err := updateAppRelease(ctx, release, map[string]any{
+			"state":           app.ReleaseStateSwitched,
+			"weight":          int32(100),
+			"step_started_at": time.Now(),
+		}); err != nil {
+			return err
+		}
+		return appServiceInstance.deployApp(ctx, appEntity, nil)
+	}
+
+	if err := updateAppRelease(ctx, release, map[string]any{
+		"state":  app.ReleaseStatePromoting,
+		"weight": int32(100),
+	}); err != nil {
+		return err
+	}
+	return appServiceInstance.deployApp(ctx, appEntity, nil)
+}
+
+// finishAppReleasePromotion prunes the canary workload of a promoting release
+// once the stable workload has rolled out the new edition.
+func finishAppReleasePromotion(ctx context.Context, appEntity *entities.App, release *entities.AppRelease) app.Error {
+	progress, err := core.GetAppRolloutProgress(ctx, appEntity)
+	if err != nil {
+		return err
+	}
+	if progress.ActualEdition != release.CanaryEdition || progress.State != app.RolloutStateComplete {
+		if progress.State == app.RolloutStateFailed {
+			logging.Warnf(ctx, "rollout of edition %s of app %s failed, the canary workload keeps serving: %s", release.CanaryEdition, appEntity.ID, progress.Message)
+		}
+		return nil
+	}
+
+	if err := updateAppRelease(ctx, release, map[string]any{"state": app.ReleaseStatePromoted}); err != nil {
+		return err
+	}
+	logging.Infof(ctx, "release of edition %s of app %s promoted", release.CanaryEdition, appEntity.ID)
+	return appServiceInstance.deployApp(ctx, appEntity, nil)
+}
+
+// abortAppRelease switches all traffic back to the stable edition and prunes
+// the canary workload.
+func abortAppRelease(ctx context.Context, appEntity *entities.App, release *entities.AppRelease, message string) app.Error {
+	if err := appServiceInstance.deployApp(ctx, appEntity, &core.AppDeployOption{
+		AbortRelease: true,
+	}); err != nil {
+		return err
+	}
+	return updateAppRelease(ctx, release, map[string]any{
+		"state":   app.ReleaseStateAborted,
+		"weight":  int32(0),
+		"message": message,
+	})
+}
+
+// abortActiveAppRelease aborts the release of the app if it has one, before
+// it is stopped or debugged in place. Promoting releases are marked promoted
+// instead, the previous edition is no longer running.
+func abortActiveAppRelease(ctx context.Context, appEntity *entities.App, message string) app.Error {
+	release, err := orm.GetActiveAppRelease(ctx, appEntity.ID)
+	if err != nil || release == nil {
+		return err
+	}
+	if release.State == app.ReleaseStatePromoting {
+		return updateAppRelease(ctx, release, map[string]any{"state": app.ReleaseStatePromoted})
+	}
+	return abortAppRelease(ctx, appEntity, release, message)
+}
+
+func updateAppRelease(ctx context.Context, release *entities.AppRelease, updates map[string]any) app.Error {
+	updates["updated_by"] = api.UserID(ctx)
+	if err := db.WithContext(ctx).Model(release).Updates(updates).Error; err != nil {
+		logging.Errorf(ctx, "failed to update release of app %s: %v", release.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+	return nil
+}
+
+// isAppReleaseHealthy reports whether the canary edition has been healthy for
+// the healthy period of the rollout strategy.
+func isAppReleaseHealthy(release *entities.AppRelease, strategy *entities.AppRolloutStrategy, now time.Time) bool {
+	if release.HealthySince == nil {
+		return false
+	}
+	var period time.Duration
+	if strategy != nil {
+		period = time.Duration(strategy.HealthyPeriodSeconds) * time.Second
+	}
+	return now.Sub(*release.HealthySince) >= period
+}
+
+// appReleaseSchedulerInterval is how often active releases are checked for
+// the health of their canary edition.
+const appReleaseSchedulerInterval = 15 * time.Second
+
+// RunAppReleaseScheduler tracks the health of the canary editions of active
+// releases, advances canary releases through their steps and switches
+// blue-green releases once the new edition has been healthy for the healthy
+// period, and finishes promotions once the stable workload rolled out the new
+// edition, until ctx is done. Paused releases only have their health tracked.
+func RunAppReleaseScheduler(ctx context.Context) {
+	ticker := time.NewTicker(appReleaseSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			runActiveAppReleases(ctx, now)
+		}
+	}
+}
+
+func runActiveAppReleases(ctx context.Context, now time.Time) {
+	var releases []*entities.AppRelease
+	if err := db.WithContext(ctx).Find(&releases, "state IN ?", []string{
+		app.ReleaseStateProgressing,
+		app.ReleaseStatePaused,
+		app.ReleaseStateSwitched,
+		app.ReleaseStatePromoting,
+	}).Error; err != nil {
+		logging.Errorf(ctx, "failed to list active app releases: %v", err)
+		return
+	}
+
+	for _, release := range releases {
+		if !claimAppReleaseRun(ctx, release, now) {
+			continue
+		}
+		if err := runAppRelease(ctx, release, now); err != nil {
+			logging.Errorf(ctx, "failed to run release of app %s: %v", release.AppID, err)
+		}
+	}
+}
+
+// claimAppReleaseRun moves the check time of the release to now, only if no
+// other api server replica ran the release this interval, and reports whether
+// this replica runs it.
+func claimAppReleaseRun(ctx context.Context, release *entities.AppRelease, now time.Time) bool {
+	if release.CheckedAt != nil && now.Sub(*release.CheckedAt) < appReleaseSchedulerInterval/2 {
+		return false
+	}
+
+	query := db.WithContext(ctx).Model(&entities.AppRelease{}).Where("id = ?", release.ID)
+	if release.CheckedAt == nil {
+		query = query.Where("checked_at IS NULL")
+	} else {
+		query = query.Where("checked_at = ?", *release.CheckedAt)
+	}
+	result := query.Update("checked_at", now)
+	if result.Error != nil {
+		logging.Errorf(ctx, "failed to claim release of app %s: %v", release.AppID, result.Error)
+		return false
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+	release.CheckedAt = &now
+	return true
+}
+
+func runAppRelease(ctx context.Context, release *entities.AppRelease, now time.Time) app.Error {
+	appEntity, err := orm.GetAppByID(ctx, release.AppID)
+	if err != nil {
+		return err
+	}
+	if appEntity.Edition != release.CanaryEdition {
+		// Waiting for the changed app to be deployed as a new release
+		return nil
+	}
+	if release.State == app.ReleaseStatePromoting {
+		return finishAppReleasePromotion(ctx, appEntity, release)
+	}
+
+	healthy, err := core.IsAppEditionHealthy(ctx, appEntity, release.CanaryEdition)
+	if err != nil {
+		return err
+	}
+	if healthy != (release.HealthySince != nil) {
+		release.HealthySince = nil
+		if healthy {
+			release.HealthySince = &now
+		}
+		if err := updateAppRelease(ctx, release, map[string]any{"healthy_since": release.HealthySince}); err != nil {
+			return err
+		}
+	}
+
+	if release.State != app.ReleaseStateProgressing {
+		return nil
+	}
+	strategy, err := orm.GetAppRolloutStrategy(ctx, release.AppID)
+	if err != nil {
+		return err
+	}
+	// Each step holds until the new edition has been healthy for the healthy
+	// period, and at least as long since the step started
+	if !isAppReleaseHealthy(release, strategy, now) {
+		return nil
+	}
+	if strategy != nil && now.Sub(release.StepStartedAt) < time.Duration(strategy.HealthyPeriodSeconds)*time.Second {
+		return nil
+	}
+
+	var steps []int32
+	if strategy != nil && release.ReleaseType == app.ReleaseTypeCanary {
+		steps = splitCanarySteps(strategy.CanarySteps)
+	}
+	if next := release.Step + 1; next < len(steps) {
+		if err := updateAppRelease(ctx, release, map[string]any{
+			"step":            next,
+			"weight":          steps[next],
+			"step_started_at": now,
+		}); err != nil {
+			return err
+		}
+		logging.Infof(ctx, "release of app %s shifted %d%% of traffic to edition %s", appEntity.ID, steps[next], release.CanaryEdition)
+		return appServiceInstance.deployApp(ctx, appEntity, nil)
+	}
+
+	logging.Infof(ctx, "promoting release of edition %s of app %s", release.CanaryEdition, appEntity.ID)
+	return promoteAppRelease(ctx, appEntity, release)
+}
+
+func appReleaseModelFromEntity(release *entities.AppRelease, strategy *entities.AppRolloutStrategy, now time.Time) *models.AppReleaseModel {
+	result := &models.AppReleaseModel{
+		ReleaseID:     release.ID,
+		AppID:         release.AppID,
+		ReleaseType:   release.ReleaseType,
+		StableEdition: release.StableEdition,
+		CanaryEdition: release.CanaryEdition,
+		Weight:        release.Weight,
+		Step:          release.Step,
+		State:         release.State,
+		StepStartedAt: release.StepStartedAt,
+		HealthySince:  release.HealthySince,
+		Promotable:    isAppReleaseHealthy(release, strategy, now),
+		Message:       release.Message,
+	}
+	if strategy != nil {
+		result.HealthyPeriodSeconds = strategy.HealthyPeriodSeconds
+		if release.ReleaseType == app.ReleaseTypeCanary {
+			result.CanarySteps = splitCanarySteps(strategy.CanarySteps)
+		}
+	}
+	return result
+}
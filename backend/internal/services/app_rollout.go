@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type AppRolloutService interface {
+	GetAppRolloutStrategy(ctx context.Context, req *models.GetAppRolloutStrategyRequest) (*models.AppRolloutStrategyModel, app.Error)
+	SetAppRolloutStrategy(ctx context.Context, req *models.SetAppRolloutStrategyRequest) (*models.AppRolloutStrategyModel, app.Error)
+	DeleteAppRolloutStrategy(ctx context.Context, req *models.DeleteAppRolloutStrategyRequest) app.Error
+	GetAppRolloutProgress(ctx context.Context, req *models.GetAppRolloutProgressRequest) (*models.AppRolloutProgressModel, app.Error)
+}
+
+type appRolloutService struct {
+	Service
+}
+
+var appRolloutServiceInstance = &appRolloutService{
+	Service: LoadService(),
+}
+
+func NewAppRolloutService() AppRolloutService {
+	return appRolloutServiceInstance
+}
+
+func (s *appRolloutService) GetAppRolloutStrategy(ctx context.Context, req *models.GetAppRolloutStrategyRequest) (*models.AppRolloutStrategyModel, app.Error) {
+	entity, err := orm.GetAppRolloutStrategy(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+
+	return appRolloutStrategyModelFromEntity(entity), nil
+}
+
+func (s *appRolloutService) SetAppRolloutStrategy(ctx context.Context, req *models.SetAppRolloutStrategyRequest) (*models.AppRolloutStrategyModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAppRolloutStrategy(appEntity.AppType, req); err != nil {
+		return nil, err
+	}
+
+	entity, err := orm.GetAppRolloutStrategy(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		entity = &entities.AppRolloutStrategy{
+			AppID: req.AppID,
+			AuditBase: entities.AuditBase{
+				CreatedBy: api.UserID(ctx),
+			},
+		}
+	}
+	entity.StrategyType = req.StrategyType
+	entity.MaxSurge = req.MaxSurge
+	entity.MaxUnavailable = req.MaxUnavailable
+	entity.Partition = req.Partition
+	entity.MinReadySeconds = req.MinReadySeconds
+	entity.ProgressDeadlineSeconds = req.ProgressDeadlineSeconds
+	entity.ReleaseType = req.ReleaseType
+	entity.CanarySteps = joinCanarySteps(req.CanarySteps)
+	entity.HealthyPeriodSeconds = req.HealthyPeriodSeconds
+	entity.UpdatedBy = api.UserID(ctx)
+
+	if err := db.WithContext(ctx).Save(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to save app rollout strategy for app %s: %v", req.AppID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after setting rollout strategy for app %s: %v", req.AppID, err)
+	}
+
+	return appRolloutStrategyModelFromEntity(entity), nil
+}
+
+func (s *appRolloutService) DeleteAppRolloutStrategy(ctx context.Context, req *models.DeleteAppRolloutStrategyRequest) app.Error {
+	if err := db.WithContext(ctx).Where("app_id = ?", req.AppID).Delete(&entities.AppRolloutStrategy{}).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app rollout strategy for app %s: %v", req.AppID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
+		logging.Errorf(ctx, "failed to update app edition after deleting rollout strategy for app %s: %v", req.AppID, err)
+	}
+
+	return nil
+}
+
+func (s *appRolloutService) GetAppRolloutProgress(ctx context.Context, req *models.GetAppRolloutProgressRequest) (*models.AppRolloutProgressModel, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, req.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := core.GetAppRolloutProgress(ctx, appEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.AppRolloutProgressModel{
+		AppID:             appEntity.ID,
+		Slug:              appEntity.Slug,
+		DesiredEdition:    progress.DesiredEdition,
+		ActualEdition:     progress.ActualEdition,
+		DesiredReplicas:   progress.DesiredReplicas,
+		UpdatedReplicas:   progress.UpdatedReplicas,
+		ReadyReplicas:     progress.ReadyReplicas,
+		AvailableReplicas: progress.AvailableReplicas,
+		State:             progress.State,
+		Message:           progress.Message,
+		Editions:          make([]*models.AppRolloutEditionModel, 0, len(progress.Editions)),
+	}
+	for _, edition := range progress.Editions {
+		result.Editions = append(result.Editions, &models.AppRolloutEditionModel{
+			Edition:       edition.Edition,
+			Replicas:      edition.Replicas,
+			ReadyReplicas: edition.ReadyReplicas,
+		})
+	}
+
+	return result, nil
+}
+
+func validateAppRolloutStrategy(appType string, req *models.SetAppRolloutStrategyRequest) app.Error {
+	switch req.StrategyType {
+	case app.RolloutStrategyTypeRecreate:
+		if appType != app.AppTypeDeployment {
+			return app.NewError(http.StatusBadRequest, "Recreate strategy is only supported by Deployment apps")
+		}
+	case app.RolloutStrategyTypeOnDelete:
+		if appType != app.AppTypeStatefulSet {
+			return app.NewError(http.StatusBadRequest, "OnDelete strategy is only supported by StatefulSet apps")
+		}
+	}
+
+	if req.MaxSurge != "" && appType != app.AppTypeDeployment {
+		return app.NewError(http.StatusBadRequest, "maxSurge is only supported by Deployment apps")
+	}
+	if req.Partition > 0 && appType != app.AppTypeStatefulSet {
+		return app.NewError(http.StatusBadRequest, "partition is only supported by StatefulSet apps")
+	}
+	if req.ProgressDeadlineSeconds > 0 && appType != app.AppTypeDeployment {
+		return app.NewError(http.StatusBadRequest, "progressDeadlineSeconds is only supported by Deployment apps")
+	}
+	if req.ProgressDeadlineSeconds > 0 && req.ProgressDeadlineSeconds <= req.MinReadySeconds {
+		return app.NewError(http.StatusBadRequest, "progressDeadlineSeconds must be greater than minReadySeconds")
+	}
+	if req.ReleaseType != "" && appType != app.AppTypeDeployment {
+		return app.NewError(http.StatusBadRequest, "releaseType is only supported by Deployment apps")
+	}
+	if len(req.CanarySteps) > 0 && req.ReleaseType != app.ReleaseTypeCanary {
+		return app.NewError(http.StatusBadRequest, "canarySteps are only supported by canary releases")
+	}
+	if req.ReleaseType == app.ReleaseTypeCanary && len(req.CanarySteps) == 0 {
+		return app.NewError(http.StatusBadRequest, "canary releases require at least one canary step")
+	}
+	for i := 1; i < len(req.CanarySteps); i++ {
+		if req.CanarySteps[i] <= req.CanarySteps[i-1] {
+			return app.NewError(http.StatusBadRequest, "canarySteps must be in ascending order")
+		}
+	}
+
+	for field, value := range map[string]string{"maxSurge": req.MaxSurge, "maxUnavailable": req.MaxUnavailable} {
+		if value == "" {
+			continue
+		}
+		v := intstr.Parse(value)
+		if v.Type == intstr.String && len(validation.IsValidPercent(v.StrVal)) > 0 {
+			return app.NewError(http.StatusBadRequest, field+" must be a non-negative number or percentage")
+		}
+		if v.Type == intstr.Int && v.IntVal < 0 {
+			return app.NewError(http.StatusBadRequest, field+" must be a non-negative number or percentage")
+		}
+	}
+	if isZeroIntOrPercent(req.MaxSurge) && isZeroIntOrPercent(req.MaxUnavailable) && req.StrategyType == app.RolloutStrategyTypeRollingUpdate && appType == app.AppTypeDeployment {
+		return app.NewError(http.StatusBadRequest, "maxSurge and maxUnavailable cannot both be zero")
+	}
+
+	return nil
+}
+
+func isZeroIntOrPercent(value string) bool {
+	return value == "0" || value == "0%"
+}
+
+func joinCanarySteps(steps []int32) string {
+	result := make([]string, 0, len(steps))
+	for _, step := range steps {
+		result = append(result, strconv.Itoa(int(step)))
+	}
+	return strings.Join(result, ",")
+}
+
+// splitCanarySteps parses the canary steps saved by joinCanarySteps.
+func splitCanarySteps(steps string) []int32 {
+	if steps == "" {
+		return nil
+	}
+	var result []int32
+	for _, step := range strings.Split(steps, ",") {
+		if v, err := strconv.Atoi(step); err == nil {
+			result = append(result, int32(v))
+		}
+	}
+	return result
+}
+
+func appRolloutStrategyModelFromEntity(entity *entities.AppRolloutStrategy) *models.AppRolloutStrategyModel {
+	return &models.AppRolloutStrategyModel{
+		StrategyID:              entity.ID,
+		AppID:                   entity.AppID,
+		StrategyType:            entity.StrategyType,
+		MaxSurge:                entity.MaxSurge,
+		MaxUnavailable:          entity.MaxUnavailable,
+		Partition:               entity.Partition,
+		MinReadySeconds:         entity.MinReadySeconds,
+		ProgressDeadlineSeconds: entity.ProgressDeadlineSeconds,
+		ReleaseType:             entity.ReleaseType,
+		CanarySteps:             splitCanarySteps(entity.CanarySteps),
+		HealthyPeriodSeconds:    entity.HealthyPeriodSeconds,
+	}
+}
@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 	"strings"
 
@@ -12,6 +11,7 @@ import (
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 )
 
@@ -41,11 +41,11 @@ func (s *appSchedulingRuleService) GetAppSchedulingRule(ctx context.Context, req
 	}
 
 	var rule entities.AppSchedulingRule
-	if err := db.Instance().Where("app_id = ?", req.AppID).First(&rule).Error; err != nil {
+	if err := db.WithContext(ctx).Where("app_id = ?", req.AppID).First(&rule).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, nil // 没有调度规则返回nil
 		}
-		log.Printf("failed to get app scheduling rule: %v", err)
+		logging.Errorf(ctx, "failed to get app scheduling rule: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -66,7 +66,7 @@ func (s *appSchedulingRuleService) GetAppSchedulingRule(ctx context.Context, req
 
 	if rule.Tolerations != "" {
 		if err := json.Unmarshal([]byte(rule.Tolerations), &tolerations); err != nil {
-			log.Printf("failed to unmarshal app scheduling rule tolerations: %v", err)
+			logging.Errorf(ctx, "failed to unmarshal app scheduling rule tolerations: %v", err)
 			return nil, app.NewError(http.StatusInternalServerError, "无法解析调度规则容忍设置")
 		}
 	}
@@ -137,7 +137,7 @@ func (s *appSchedulingRuleService) SetAppSchedulingRule(ctx context.Context, req
 		}
 		tolerations, e = json.Marshal(req.Tolerations)
 		if e != nil {
-			log.Printf("failed to marshal tolerations: %v", e)
+			logging.Errorf(ctx, "failed to marshal tolerations: %v", e)
 			return nil, app.NewError(http.StatusInternalServerError, "无法解析调度规则容忍设置")
 		}
 	}
@@ -145,11 +145,11 @@ func (s *appSchedulingRuleService) SetAppSchedulingRule(ctx context.Context, req
 	// 查找现有规则
 	var existingRule entities.AppSchedulingRule
 	found := true
-	if err := db.Instance().Where("app_id = ?", req.AppID).First(&existingRule).Error; err != nil {
+	if err := db.WithContext(ctx).Where("app_id = ?", req.AppID).First(&existingRule).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			found = false
 		} else {
-			log.Printf("failed to query existing scheduling rule: %v", err)
+			logging.Errorf(ctx, "failed to query existing scheduling rule: %v", err)
 			return nil, app.ErrDatabaseOperationFailed
 		}
 	}
@@ -163,8 +163,8 @@ func (s *appSchedulingRuleService) SetAppSchedulingRule(ctx context.Context, req
 		existingRule.Tolerations = string(tolerations)
 		existingRule.UpdatedBy = api.UserID(ctx)
 
-		if err := db.Instance().Save(&existingRule).Error; err != nil {
-			log.Printf("failed to update app scheduling rule: %v", err)
+		if err := db.WithContext(ctx).Save(&existingRule).Error; err != nil {
+			logging.Errorf(ctx, "failed to update app scheduling rule: %v", err)
 			return nil, app.ErrDatabaseOperationFailed
 		}
 	} else {
@@ -182,8 +182,8 @@ func (s *appSchedulingRuleService) SetAppSchedulingRule(ctx context.Context, req
 			},
 		}
 
-		if err := db.Instance().Create(&existingRule).Error; err != nil {
-			log.Printf("failed to create app scheduling rule: %v", err)
+		if err := db.WithContext(ctx).Create(&existingRule).Error; err != nil {
+			logging.Errorf(ctx, "failed to create app scheduling rule: %v", err)
 			return nil, app.ErrDatabaseOperationFailed
 		}
 	}
@@ -207,8 +207,8 @@ func (s *appSchedulingRuleService) DeleteAppSchedulingRule(ctx context.Context,
 		return err
 	}
 
-	if err := db.Instance().Where("app_id = ?", appID).Delete(&entities.AppSchedulingRule{}).Error; err != nil {
-		log.Printf("failed to delete app scheduling rule: %v", err)
+	if err := db.WithContext(ctx).Where("app_id = ?", appID).Delete(&entities.AppSchedulingRule{}).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app scheduling rule: %v", err)
 		return app.ErrDatabaseOperationFailed
 	}
 
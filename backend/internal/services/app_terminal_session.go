@@ -0,0 +1,224 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/asciicast"
+	"github.com/ketches/ketches/pkg/shellwords"
+	"github.com/ketches/ketches/pkg/websocket"
+)
+
+type AppTerminalSessionService interface {
+	ListAppTerminalSessions(ctx context.Context, req *models.ListAppTerminalSessionsRequest) (*models.ListAppTerminalSessionsResponse, app.Error)
+	GetAppTerminalSessionRecording(ctx context.Context, req *models.GetAppTerminalSessionRecordingRequest) (string, app.Error)
+}
+
+type appTerminalSessionService struct {
+	Service
+}
+
+var appTerminalSessionServiceInstance = &appTerminalSessionService{
+	Service: LoadService(),
+}
+
+func NewAppTerminalSessionService() AppTerminalSessionService {
+	return appTerminalSessionServiceInstance
+}
+
+func (s *appTerminalSessionService) ListAppTerminalSessions(ctx context.Context, req *models.ListAppTerminalSessionsRequest) (*models.ListAppTerminalSessionsResponse, app.Error) {
+	if req.PageNo < 1 {
+		req.PageNo = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 10
+	}
+
+	query := db.WithContext(ctx).Model(&entities.AppTerminalSession{}).Where("app_id = ?", req.AppID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logging.Errorf(ctx, "failed to count terminal sessions of app %s: %v", req.AppID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	sessions := []*entities.AppTerminalSession{}
+	if err := query.Order("started_at DESC").Offset((req.PageNo - 1) * req.PageSize).Limit(req.PageSize).Find(&sessions).Error; err != nil {
+		logging.Errorf(ctx, "failed to list terminal sessions of app %s: %v", req.AppID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	result := &models.ListAppTerminalSessionsResponse{
+		Total:   total,
+		Records: make([]*models.AppTerminalSessionModel, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		model := &models.AppTerminalSessionModel{
+			SessionID:     session.ID,
+			AppID:         session.AppID,
+			InstanceName:  session.InstanceName,
+			ContainerName: session.ContainerName,
+			Command:       session.Command,
+			UserID:        session.UserID,
+			StartedAt:     session.StartedAt.Format(time.RFC3339),
+			Recorded:      session.RecordingFile != "",
+		}
+		if session.EndedAt != nil {
+			model.EndedAt = session.EndedAt.Format(time.RFC3339)
+		}
+		result.Records = append(result.Records, model)
+	}
+	return result, nil
+}
+
+// GetAppTerminalSessionRecording returns the path of the asciicast recording
+// of a terminal session.
+func (s *appTerminalSessionService) GetAppTerminalSessionRecording(ctx context.Context, req *models.GetAppTerminalSessionRecordingRequest) (string, app.Error) {
+	session, err := orm.GetAppTerminalSession(ctx, req.AppID, req.SessionID)
+	if err != nil {
+		return "", err
+	}
+
+	dir := app.TerminalRecordingDir()
+	if session.RecordingFile == "" || dir == "" {
+		return "", app.NewError(http.StatusNotFound, "Terminal session was not recorded")
+	}
+
+	path := filepath.Join(dir, filepath.Base(session.RecordingFile))
+	if _, err := os.Stat(path); err != nil {
+		logging.Errorf(ctx, "failed to stat recording of terminal session %s: %v", session.ID, err)
+		return "", app.NewError(http.StatusNotFound, "Recording of the terminal session not found")
+	}
+	return path, nil
+}
+
+// defaultTerminalCommand starts the first shell found in the container.
+var defaultTerminalCommand = []string{"sh", "-c", "clear; exec $(command -v bash || command -v ash || command -v sh)"}
+
+// terminalCommand returns the command of a web terminal, split into arguments
+// like a shell does, e.g. `sh -c 'ls -l'`.
+func terminalCommand(command string) ([]string, app.Error) {
+	args, err := shellwords.Split(command)
+	if err != nil {
+		return nil, app.NewError(http.StatusBadRequest, "invalid terminal command: "+err.Error())
+	}
+	if len(args) == 0 {
+		return defaultTerminalCommand, nil
+	}
+	return args, nil
+}
+
+// outputRecorder records the output and size of a terminal, leaving out what
+// users type.
+type outputRecorder struct {
+	*asciicast.Recorder
+}
+
+func (outputRecorder) Input([]byte) error {
+	return nil
+}
+
+// appTerminalSession is the audit record of a web terminal, and its asciicast
+// recording when recording is enabled.
+type appTerminalSession struct {
+	entity   *entities.AppTerminalSession
+	file     *os.File
+	recorder *asciicast.Recorder
+}
+
+// terminalRecorder returns the recorder of the terminal, nil when the session
+// is not recorded.
+func (s *appTerminalSession) terminalRecorder() websocket.TerminalRecorder {
+	if s.recorder == nil {
+		return nil
+	}
+	if app.TerminalRecordInput() {
+		return s.recorder
+	}
+	return outputRecorder{s.recorder}
+}
+
+func startAppTerminalSession(ctx context.Context, appEntity *entities.App, req *models.ExecAppContainerTerminalRequest, command []string, cols, rows uint16) *appTerminalSession {
+	userID := api.UserID(ctx)
+	session := &appTerminalSession{
+		entity: &entities.AppTerminalSession{
+			AppID:         appEntity.ID,
+			InstanceName:  req.InstanceName,
+			ContainerName: req.ContainerName,
+			Command:       strings.Join(command, " "),
+			UserID:        userID,
+			StartedAt:     time.Now(),
+			AuditBase: entities.AuditBase{
+				CreatedBy: userID,
+				UpdatedBy: userID,
+			},
+		},
+	}
+	if err := db.WithContext(ctx).Create(session.entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create terminal session of app %s: %v", appEntity.ID, err)
+		return session
+	}
+
+	dir := app.TerminalRecordingDir()
+	if dir == "" {
+		return session
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		logging.Errorf(ctx, "failed to create terminal recording directory %s: %v", dir, err)
+		return session
+	}
+
+	fileName := session.entity.ID + ".cast"
+	file, err := os.OpenFile(filepath.Join(dir, fileName), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		logging.Errorf(ctx, "failed to create recording of terminal session %s: %v", session.entity.ID, err)
+		return session
+	}
+	recorder, err := asciicast.NewRecorder(bufio.NewWriter(file), asciicast.Header{
+		Width:   int(cols),
+		Height:  int(rows),
+		Command: session.entity.Command,
+		Title:   appEntity.Slug + "/" + req.InstanceName + "/" + req.ContainerName,
+		Env:     map[string]string{"TERM": "xterm"},
+	})
+	if err != nil {
+		logging.Errorf(ctx, "failed to write recording of terminal session %s: %v", session.entity.ID, err)
+		file.Close()
+		return session
+	}
+	if err := db.WithContext(ctx).Model(session.entity).Update("recording_file", fileName).Error; err != nil {
+		logging.Errorf(ctx, "failed to update terminal session %s: %v", session.entity.ID, err)
+	}
+
+	session.file, session.recorder = file, recorder
+	return session
+}
+
+// finish closes the recording and marks the session ended.
+func (s *appTerminalSession) finish(ctx context.Context) {
+	if s.file != nil {
+		// Close the recorder first, the terminal may still record output
+		if err := s.recorder.Close(); err != nil {
+			logging.Errorf(ctx, "failed to flush recording of terminal session %s: %v", s.entity.ID, err)
+		}
+		s.file.Close()
+	}
+	if s.entity.ID == "" {
+		return
+	}
+	if err := db.Instance().Model(s.entity).Update("ended_at", time.Now()).Error; err != nil {
+		logging.Errorf(ctx, "failed to update terminal session %s: %v", s.entity.ID, err)
+	}
+}
@@ -2,17 +2,22 @@ package services
 
 import (
 	"context"
-	"log"
 	"net/http"
+	"path"
+	"slices"
 	"strings"
 
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/ketches/ketches/pkg/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type AppVolumeService interface {
@@ -32,54 +37,52 @@ func NewAppVolumeService() AppVolumeService {
 
 func (s *appVolumeService) ListAppVolumes(ctx context.Context, req *models.ListAppVolumesRequest) ([]*models.AppVolumeModel, app.Error) {
 	var result []*entities.AppVolume
-	if err := db.Instance().Model(&entities.AppVolume{}).
+	if err := db.WithContext(ctx).Model(&entities.AppVolume{}).
 		Where("app_id = ?", req.AppID).
 		Find(&result).Error; err != nil {
-		log.Println("failed to list app volumes:", err)
+		logging.Errorf(ctx, "failed to list app volumes: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 	var records []*models.AppVolumeModel
 	for _, v := range result {
-		records = append(records, &models.AppVolumeModel{
-			VolumeID:   v.ID,
-			AppID:      v.AppID,
-			Slug:       v.Slug,
-			MountPath:  v.MountPath,
-			SubPath:    v.SubPath,
-			Capacity:   v.Capacity,
-			VolumeType: v.VolumeType,
-			AccessModes: func() []string {
-				if v.AccessModes == "" {
-					return nil
-				}
-				return strings.Split(v.AccessModes, ";")
-			}(),
-			VolumeMode:   v.VolumeMode,
-			StorageClass: v.StorageClass,
-		})
+		records = append(records, appVolumeModelFromEntity(v))
 	}
 	return records, nil
 }
 
 func (s *appVolumeService) CreateAppVolume(ctx context.Context, req *models.CreateAppVolumeRequest) (*models.AppVolumeModel, app.Error) {
+	if err := validateAppVolume(ctx, req); err != nil {
+		return nil, err
+	}
+
 	entity := &entities.AppVolume{
-		UUIDBase:     entities.UUIDBase{ID: uuid.New()},
-		AppID:        req.AppID,
-		Slug:         req.Slug,
-		MountPath:    req.MountPath,
-		SubPath:      req.SubPath,
-		Capacity:     req.Capacity,
-		VolumeType:   req.VolumeType,
-		AccessModes:  strings.Join(req.AccessModes, ";"),
-		StorageClass: req.StorageClass,
-		VolumeMode:   req.VolumeMode,
+		UUIDBase:       entities.UUIDBase{ID: uuid.New()},
+		AppID:          req.AppID,
+		Slug:           req.Slug,
+		MountPath:      req.MountPath,
+		SubPath:        req.SubPath,
+		Capacity:       req.Capacity,
+		VolumeType:     req.VolumeType,
+		AccessModes:    strings.Join(req.AccessModes, ";"),
+		StorageClass:   req.StorageClass,
+		VolumeMode:     req.VolumeMode,
+		ReadOnly:       req.ReadOnly,
+		EmptyDirMedium: req.EmptyDirMedium,
+		HostPath:       req.HostPath,
+		HostPathType:   req.HostPathType,
+		NFSServer:      req.NFSServer,
+		NFSPath:        req.NFSPath,
+		ClaimName:      req.ClaimName,
 		AuditBase: entities.AuditBase{
 			CreatedBy: api.UserID(ctx),
 			UpdatedBy: api.UserID(ctx),
 		},
 	}
-	if err := db.Instance().Create(entity).Error; err != nil {
-		log.Println("failed to create app volume:", err)
+	if entity.VolumeMode == "" {
+		entity.VolumeMode = string(corev1.PersistentVolumeFilesystem)
+	}
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create app volume: %v", err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusBadRequest, "volume slug or mount path already exists")
 		}
@@ -87,41 +90,37 @@ func (s *appVolumeService) CreateAppVolume(ctx context.Context, req *models.Crea
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after creating volume for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after creating volume for app %s: %v", req.AppID, err)
 	}
 
-	return &models.AppVolumeModel{
-		VolumeID:     entity.ID,
-		AppID:        entity.AppID,
-		Slug:         entity.Slug,
-		MountPath:    entity.MountPath,
-		Capacity:     entity.Capacity,
-		VolumeType:   entity.VolumeType,
-		AccessModes:  splitAccessModes(entity.AccessModes),
-		StorageClass: entity.StorageClass,
-	}, nil
+	return appVolumeModelFromEntity(entity), nil
 }
 
 func (s *appVolumeService) UpdateAppVolume(ctx context.Context, req *models.UpdateAppVolumeRequest) (*models.AppVolumeModel, app.Error) {
 	var entity entities.AppVolume
-	if err := db.Instance().First(&entity, "id = ?", req.VolumeID).Error; err != nil {
-		log.Printf("failed to find app volume %s: %v", req.VolumeID, err)
+	if err := db.WithContext(ctx).First(&entity, "id = ?", req.VolumeID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find app volume %s: %v", req.VolumeID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "volume not found")
 		}
 		return nil, app.ErrDatabaseOperationFailed
 	}
+	if entity.VolumeType == app.AppVolumeTypeHostPath && !api.IsAdmin(ctx) {
+		return nil, app.NewError(http.StatusForbidden, "Only admin can update hostPath volumes")
+	}
 	entity.MountPath = req.MountPath
 	entity.SubPath = req.SubPath
+	entity.ReadOnly = req.ReadOnly
 
-	if err := db.Instance().Model(&entity).Select("MountPath", "SubPath", "UpdatedBy").Updates(&entities.AppVolume{
+	if err := db.WithContext(ctx).Model(&entity).Select("MountPath", "SubPath", "ReadOnly", "UpdatedBy").Updates(&entities.AppVolume{
 		MountPath: req.MountPath,
 		SubPath:   req.SubPath,
+		ReadOnly:  req.ReadOnly,
 		AuditBase: entities.AuditBase{
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update app volume %s: %v", req.VolumeID, err)
+		logging.Errorf(ctx, "failed to update app volume %s: %v", req.VolumeID, err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusBadRequest, "volume mount path already exists")
 		}
@@ -129,20 +128,10 @@ func (s *appVolumeService) UpdateAppVolume(ctx context.Context, req *models.Upda
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after updating volume for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after updating volume for app %s: %v", req.AppID, err)
 	}
 
-	return &models.AppVolumeModel{
-		VolumeID:     entity.ID,
-		AppID:        entity.AppID,
-		Slug:         entity.Slug,
-		MountPath:    entity.MountPath,
-		SubPath:      entity.SubPath,
-		Capacity:     entity.Capacity,
-		VolumeType:   entity.VolumeType,
-		AccessModes:  splitAccessModes(entity.AccessModes),
-		StorageClass: entity.StorageClass,
-	}, nil
+	return appVolumeModelFromEntity(&entity), nil
 }
 
 func (s *appVolumeService) DeleteAppVolumes(ctx context.Context, req *models.DeleteAppVolumesRequest) app.Error {
@@ -150,13 +139,27 @@ func (s *appVolumeService) DeleteAppVolumes(ctx context.Context, req *models.Del
 		return nil
 	}
 
-	if err := db.Instance().Delete(&entities.AppVolume{}, req.VolumeIDs).Error; err != nil {
-		log.Println("failed to delete app volumes:", err)
+	var slugs []string
+	if err := db.WithContext(ctx).Model(&entities.AppVolume{}).Where("id IN ? AND app_id = ?", req.VolumeIDs, req.AppID).Pluck("slug", &slugs).Error; err != nil {
+		logging.Errorf(ctx, "failed to get app volumes: %v", err)
+		return app.ErrDatabaseOperationFailed
+	}
+	if err := checkAppContainerVolumeMountsUnused(ctx, req.AppID, slugs); err != nil {
+		return err
+	}
+
+	if err := db.WithContext(ctx).Delete(&entities.AppVolume{}, req.VolumeIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete app volumes: %v", err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	if err := db.WithContext(ctx).Delete(&entities.AppVolumeSnapshotPolicy{}, "volume_id IN ?", req.VolumeIDs).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete volume snapshot policies: %v", err)
 		return app.ErrDatabaseOperationFailed
 	}
 
 	if _, err := orm.UpdateAppEdition(ctx, req.AppID); err != nil {
-		log.Printf("failed to update app edition after deleting volume for app %s: %v", req.AppID, err)
+		logging.Errorf(ctx, "failed to update app edition after deleting volume for app %s: %v", req.AppID, err)
 	}
 
 	return nil
@@ -168,3 +171,103 @@ func splitAccessModes(modes string) []string {
 	}
 	return strings.Split(modes, ";")
 }
+
+// validateAppVolume checks the fields each volume type needs, hostPath
+// volumes expose the node filesystem and are restricted to admins.
+func validateAppVolume(ctx context.Context, req *models.CreateAppVolumeRequest) app.Error {
+	switch req.VolumeType {
+	case app.AppVolumeTypeEmptyDir:
+		// Capacity is the optional size limit, no other fields are needed
+	case app.AppVolumeTypePVC:
+		if req.Capacity <= 0 {
+			return app.NewError(http.StatusBadRequest, "capacity is required for pvc volumes")
+		}
+		if err := validateVolumeAccessModes(req.AccessModes); err != nil {
+			return err
+		}
+	case app.AppVolumeTypeHostPath:
+		if !api.IsAdmin(ctx) {
+			return app.NewError(http.StatusForbidden, "Only admin can create hostPath volumes")
+		}
+		if !path.IsAbs(req.HostPath) {
+			return app.NewError(http.StatusBadRequest, "hostPath must be an absolute path")
+		}
+		if req.HostPathType != "" && !slices.Contains(hostPathTypes, corev1.HostPathType(req.HostPathType)) {
+			return app.NewError(http.StatusBadRequest, "invalid hostPath type: "+req.HostPathType)
+		}
+	case app.AppVolumeTypeNFS:
+		if req.NFSServer == "" {
+			return app.NewError(http.StatusBadRequest, "nfsServer is required for nfs volumes")
+		}
+		if !path.IsAbs(req.NFSPath) {
+			return app.NewError(http.StatusBadRequest, "nfsPath must be an absolute path")
+		}
+	case app.AppVolumeTypeExistingClaim:
+		if errs := validation.IsDNS1123Subdomain(req.ClaimName); len(errs) > 0 {
+			return app.NewError(http.StatusBadRequest, "invalid claim name: "+strings.Join(errs, ", "))
+		}
+	case app.AppVolumeTypeShared:
+		if errs := validation.IsDNS1123Label(core.SharedVolumeClaimName(req.ClaimName)); req.ClaimName == "" || len(errs) > 0 {
+			return app.NewError(http.StatusBadRequest, "invalid shared volume name: "+strings.Join(errs, ", "))
+		}
+		if req.Capacity <= 0 {
+			return app.NewError(http.StatusBadRequest, "capacity is required for shared volumes")
+		}
+		if err := validateVolumeAccessModes(req.AccessModes); err != nil {
+			return err
+		}
+	default:
+		return app.NewError(http.StatusBadRequest, "unsupported volume type: "+req.VolumeType)
+	}
+
+	if req.EmptyDirMedium != "" && req.VolumeType != app.AppVolumeTypeEmptyDir {
+		return app.NewError(http.StatusBadRequest, "emptyDirMedium is only supported by emptyDir volumes")
+	}
+	return nil
+}
+
+var hostPathTypes = []corev1.HostPathType{
+	corev1.HostPathDirectoryOrCreate,
+	corev1.HostPathDirectory,
+	corev1.HostPathFileOrCreate,
+	corev1.HostPathFile,
+	corev1.HostPathSocket,
+	corev1.HostPathCharDev,
+	corev1.HostPathBlockDev,
+}
+
+func validateVolumeAccessModes(accessModes []string) app.Error {
+	if len(accessModes) == 0 {
+		return app.NewError(http.StatusBadRequest, "accessModes is required for claimed volumes")
+	}
+	for _, mode := range accessModes {
+		switch corev1.PersistentVolumeAccessMode(mode) {
+		case corev1.ReadWriteOnce, corev1.ReadOnlyMany, corev1.ReadWriteMany, corev1.ReadWriteOncePod:
+		default:
+			return app.NewError(http.StatusBadRequest, "invalid access mode: "+mode)
+		}
+	}
+	return nil
+}
+
+func appVolumeModelFromEntity(entity *entities.AppVolume) *models.AppVolumeModel {
+	return &models.AppVolumeModel{
+		VolumeID:       entity.ID,
+		AppID:          entity.AppID,
+		Slug:           entity.Slug,
+		MountPath:      entity.MountPath,
+		SubPath:        entity.SubPath,
+		Capacity:       entity.Capacity,
+		VolumeType:     entity.VolumeType,
+		AccessModes:    splitAccessModes(entity.AccessModes),
+		StorageClass:   entity.StorageClass,
+		VolumeMode:     entity.VolumeMode,
+		ReadOnly:       entity.ReadOnly,
+		EmptyDirMedium: entity.EmptyDirMedium,
+		HostPath:       entity.HostPath,
+		HostPathType:   entity.HostPathType,
+		NFSServer:      entity.NFSServer,
+		NFSPath:        entity.NFSPath,
+		ClaimName:      entity.ClaimName,
+	}
+}
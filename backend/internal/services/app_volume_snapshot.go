@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/utils"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type AppVolumeSnapshotService interface {
+	ListAppVolumeSnapshots(ctx context.Context, req *models.ListAppVolumeSnapshotsRequest) ([]*models.AppVolumeSnapshotModel, app.Error)
+	CreateAppVolumeSnapshot(ctx context.Context, req *models.CreateAppVolumeSnapshotRequest) (*models.AppVolumeSnapshotModel, app.Error)
+	DeleteAppVolumeSnapshot(ctx context.Context, req *models.DeleteAppVolumeSnapshotRequest) app.Error
+	RestoreAppVolumeSnapshot(ctx context.Context, req *models.RestoreAppVolumeSnapshotRequest) app.Error
+	GetAppVolumeSnapshotPolicy(ctx context.Context, req *models.GetAppVolumeSnapshotPolicyRequest) (*models.AppVolumeSnapshotPolicyModel, app.Error)
+	SetAppVolumeSnapshotPolicy(ctx context.Context, req *models.SetAppVolumeSnapshotPolicyRequest) (*models.AppVolumeSnapshotPolicyModel, app.Error)
+	DeleteAppVolumeSnapshotPolicy(ctx context.Context, req *models.DeleteAppVolumeSnapshotPolicyRequest) app.Error
+}
+
+type appVolumeSnapshotService struct {
+	Service
+}
+
+var appVolumeSnapshotServiceInstance = &appVolumeSnapshotService{
+	Service: LoadService(),
+}
+
+func NewAppVolumeSnapshotService() AppVolumeSnapshotService {
+	return appVolumeSnapshotServiceInstance
+}
+
+func (s *appVolumeSnapshotService) ListAppVolumeSnapshots(ctx context.Context, req *models.ListAppVolumeSnapshotsRequest) ([]*models.AppVolumeSnapshotModel, app.Error) {
+	appEntity, volume, kcli, err := snapshotTarget(ctx, req.AppID, req.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := core.ListVolumeSnapshots(ctx, kcli, appEntity, volume)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.AppVolumeSnapshotModel, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, appVolumeSnapshotModel(volume.ID, snapshot))
+	}
+	return result, nil
+}
+
+func (s *appVolumeSnapshotService) CreateAppVolumeSnapshot(ctx context.Context, req *models.CreateAppVolumeSnapshotRequest) (*models.AppVolumeSnapshotModel, app.Error) {
+	appEntity, volume, kcli, err := snapshotTarget(ctx, req.AppID, req.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Replica > 0 && (appEntity.AppType != app.AppTypeStatefulSet || req.Replica >= int(appEntity.Replicas)) {
+		return nil, app.NewError(http.StatusBadRequest, "replica is out of range")
+	}
+	claimName, err := core.AppVolumeClaimName(appEntity, volume, req.Replica)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := core.CreateVolumeSnapshot(ctx, kcli, appEntity, volume, claimName, req.SnapshotClass, false)
+	if err != nil {
+		return nil, err
+	}
+	return appVolumeSnapshotModel(volume.ID, snapshot), nil
+}
+
+func (s *appVolumeSnapshotService) DeleteAppVolumeSnapshot(ctx context.Context, req *models.DeleteAppVolumeSnapshotRequest) app.Error {
+	appEntity, volume, kcli, err := snapshotTarget(ctx, req.AppID, req.VolumeID)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the snapshot belongs to the volume
+	if _, err := core.GetVolumeSnapshot(ctx, kcli, appEntity, volume, req.SnapshotName); err != nil {
+		return err
+	}
+	return core.DeleteVolumeSnapshot(ctx, kcli, appEntity.ClusterNamespace, req.SnapshotName)
+}
+
+func (s *appVolumeSnapshotService) RestoreAppVolumeSnapshot(ctx context.Context, req *models.RestoreAppVolumeSnapshotRequest) app.Error {
+	appEntity, volume, kcli, err := snapshotTarget(ctx, req.AppID, req.VolumeID)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := core.GetVolumeSnapshot(ctx, kcli, appEntity, volume, req.SnapshotName)
+	if err != nil {
+		return err
+	}
+
+	if !req.InPlace {
+		if errs := validation.IsDNS1123Subdomain(req.ClaimName); len(errs) > 0 {
+			return app.NewError(http.StatusBadRequest, "invalid claim name: "+req.ClaimName)
+		}
+		return core.RestoreVolumeSnapshot(ctx, kcli, appEntity, volume, snapshot, req.ClaimName, false)
+	}
+
+	switch core.GetAppStatus(ctx, appEntity).Status {
+	case app.AppStatusStopped, app.AppStatusUndeployed:
+	default:
+		return app.NewError(http.StatusConflict, "Stop the app before restoring a snapshot in place")
+	}
+	return core.RestoreVolumeSnapshot(ctx, kcli, appEntity, volume, snapshot, snapshot.ClaimName, true)
+}
+
+func (s *appVolumeSnapshotService) GetAppVolumeSnapshotPolicy(ctx context.Context, req *models.GetAppVolumeSnapshotPolicyRequest) (*models.AppVolumeSnapshotPolicyModel, app.Error) {
+	if _, err := orm.GetAppVolume(ctx, req.AppID, req.VolumeID); err != nil {
+		return nil, err
+	}
+
+	entity, err := orm.GetAppVolumeSnapshotPolicy(ctx, req.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+	return appVolumeSnapshotPolicyModelFromEntity(entity), nil
+}
+
+func (s *appVolumeSnapshotService) SetAppVolumeSnapshotPolicy(ctx context.Context, req *models.SetAppVolumeSnapshotPolicyRequest) (*models.AppVolumeSnapshotPolicyModel, app.Error) {
+	appEntity, volume, _, err := snapshotTarget(ctx, req.AppID, req.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := core.AppVolumeClaimName(appEntity, volume, 0); err != nil {
+		return nil, err
+	}
+
+	entity, err := orm.GetAppVolumeSnapshotPolicy(ctx, req.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		entity = &entities.AppVolumeSnapshotPolicy{
+			AppID:    req.AppID,
+			VolumeID: req.VolumeID,
+			AuditBase: entities.AuditBase{
+				CreatedBy: api.UserID(ctx),
+			},
+		}
+	}
+	entity.SnapshotClass = req.SnapshotClass
+	entity.IntervalMinutes = req.IntervalMinutes
+	entity.Retention = req.Retention
+	entity.Enabled = req.Enabled
+	entity.UpdatedBy = api.UserID(ctx)
+
+	if err := db.WithContext(ctx).Save(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to save snapshot policy for volume %s: %v", req.VolumeID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	return appVolumeSnapshotPolicyModelFromEntity(entity), nil
+}
+
+func (s *appVolumeSnapshotService) DeleteAppVolumeSnapshotPolicy(ctx context.Context, req *models.DeleteAppVolumeSnapshotPolicyRequest) app.Error {
+	if err := db.WithContext(ctx).Where("app_id = ? AND volume_id = ?", req.AppID, req.VolumeID).Delete(&entities.AppVolumeSnapshotPolicy{}).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete snapshot policy for volume %s: %v", req.VolumeID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+	return nil
+}
+
+// volumeSnapshotSchedulerInterval is how often snapshot policies are checked
+// for due snapshots.
+const volumeSnapshotSchedulerInterval = time.Minute
+
+// RunVolumeSnapshotScheduler takes the snapshots of due snapshot policies
+// until ctx is done.
+func RunVolumeSnapshotScheduler(ctx context.Context) {
+	ticker := time.NewTicker(volumeSnapshotSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			runDueVolumeSnapshotPolicies(ctx, now)
+		}
+	}
+}
+
+func runDueVolumeSnapshotPolicies(ctx context.Context, now time.Time) {
+	var policies []*entities.AppVolumeSnapshotPolicy
+	if err := db.WithContext(ctx).Find(&policies, "enabled = ?", true).Error; err != nil {
+		logging.Errorf(ctx, "failed to list volume snapshot policies: %v", err)
+		return
+	}
+
+	for _, policy := range policies {
+		if policy.LastSnapshotAt != nil && now.Sub(*policy.LastSnapshotAt) < time.Duration(policy.IntervalMinutes)*time.Minute {
+			continue
+		}
+		if !claimVolumeSnapshotPolicyRun(ctx, policy, now) {
+			continue
+		}
+		if err := runVolumeSnapshotPolicy(ctx, policy); err != nil {
+			logging.Errorf(ctx, "failed to run snapshot policy of volume %s: %v", policy.VolumeID, err)
+		}
+	}
+}
+
+// claimVolumeSnapshotPolicyRun moves the last snapshot time of the policy to
+// now, only if no other api server replica did so since the policy was read.
+// The replica that moves it runs the policy, failed runs are retried on the
+// next interval, not on every tick.
+func claimVolumeSnapshotPolicyRun(ctx context.Context, policy *entities.AppVolumeSnapshotPolicy, now time.Time) bool {
+	query := db.WithContext(ctx).Model(&entities.AppVolumeSnapshotPolicy{}).Where("id = ?", policy.ID)
+	if policy.LastSnapshotAt == nil {
+		query = query.Where("last_snapshot_at IS NULL")
+	} else {
+		query = query.Where("last_snapshot_at = ?", *policy.LastSnapshotAt)
+	}
+	result := query.Update("last_snapshot_at", now)
+	if result.Error != nil {
+		logging.Errorf(ctx, "failed to update snapshot policy of volume %s: %v", policy.VolumeID, result.Error)
+		return false
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+	policy.LastSnapshotAt = &now
+	return true
+}
+
+func runVolumeSnapshotPolicy(ctx context.Context, policy *entities.AppVolumeSnapshotPolicy) app.Error {
+	appEntity, volume, kcli, err := snapshotTarget(ctx, policy.AppID, policy.VolumeID)
+	if err != nil {
+		return err
+	}
+
+	replicas := 1
+	if appEntity.AppType == app.AppTypeStatefulSet && volume.VolumeType == app.AppVolumeTypePVC {
+		replicas = int(appEntity.Replicas)
+	}
+	for replica := range replicas {
+		claimName, err := core.AppVolumeClaimName(appEntity, volume, replica)
+		if err != nil {
+			return err
+		}
+		if _, err := core.CreateVolumeSnapshot(ctx, kcli, appEntity, volume, claimName, policy.SnapshotClass, true); err != nil {
+			return err
+		}
+	}
+
+	return core.PruneVolumeSnapshots(ctx, kcli, appEntity, volume, policy.Retention)
+}
+
+// snapshotTarget loads the app and volume, and checks the snapshot CRDs are
+// available in the app's cluster.
+func snapshotTarget(ctx context.Context, appID, volumeID string) (*entities.App, *entities.AppVolume, client.Client, app.Error) {
+	appEntity, err := orm.GetAppByID(ctx, appID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	volume, err := orm.GetAppVolume(ctx, appID, volumeID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	kcli, err := kube.ClusterRuntimeClient(ctx, appEntity.ClusterID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	installed, err := core.CheckVolumeSnapshotInstalled(ctx, kcli)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !installed {
+		return nil, nil, nil, app.NewError(http.StatusBadRequest, "Volume snapshot CRDs are not installed in the cluster")
+	}
+
+	return appEntity, volume, kcli, nil
+}
+
+func appVolumeSnapshotModel(volumeID string, snapshot *core.VolumeSnapshot) *models.AppVolumeSnapshotModel {
+	return &models.AppVolumeSnapshotModel{
+		Name:          snapshot.Name,
+		VolumeID:      volumeID,
+		ClaimName:     snapshot.ClaimName,
+		SnapshotClass: snapshot.SnapshotClass,
+		Scheduled:     snapshot.Scheduled,
+		ReadyToUse:    snapshot.ReadyToUse,
+		RestoreSize:   snapshot.RestoreSize,
+		Error:         snapshot.Error,
+		CreatedAt:     utils.HumanizeTime(snapshot.CreatedAt),
+	}
+}
+
+func appVolumeSnapshotPolicyModelFromEntity(entity *entities.AppVolumeSnapshotPolicy) *models.AppVolumeSnapshotPolicyModel {
+	result := &models.AppVolumeSnapshotPolicyModel{
+		PolicyID:        entity.ID,
+		AppID:           entity.AppID,
+		VolumeID:        entity.VolumeID,
+		SnapshotClass:   entity.SnapshotClass,
+		IntervalMinutes: entity.IntervalMinutes,
+		Retention:       entity.Retention,
+		Enabled:         entity.Enabled,
+	}
+	if entity.LastSnapshotAt != nil {
+		result.LastSnapshotAt = utils.HumanizeTime(*entity.LastSnapshotAt)
+	}
+	return result
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"log"
 	"net/http"
 	"slices"
 	"strings"
@@ -17,9 +16,11 @@ import (
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
 	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/ketches/ketches/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,7 @@ type ClusterService interface {
 	EnableCluster(ctx context.Context, req *models.EnabledClusterRequest) app.Error
 	DisableCluster(ctx context.Context, req *models.DisableClusterRequest) app.Error
 	PingClusterKubeConfig(ctx context.Context, req *models.PingClusterKubeConfigRequest) bool
+	ListClusterIngressClasses(ctx context.Context, req *models.ListClusterIngressClassesRequest) ([]*models.ClusterIngressClassModel, app.Error)
 	ListClusterNodes(ctx context.Context, req *models.ListClusterNodesRequest) ([]*models.ClusterNodeModel, app.Error)
 	ListClusterNodeRefs(ctx context.Context, req *models.ListClusterNodeRefsRequest) ([]*models.ClusterNodeRef, app.Error)
 	GetClusterNode(ctx context.Context, req *models.GetClusterNodeRequest) (*models.ClusterNodeModel, app.Error)
@@ -64,20 +66,20 @@ func NewClusterService() ClusterService {
 }
 
 func (s *clusterService) ListClusters(ctx context.Context, req *models.ListClustersRequest) (*models.ListClustersResponse, app.Error) {
-	query := db.Instance().Model(&entities.Cluster{})
+	query := db.WithContext(ctx).Model(&entities.Cluster{})
 	if len(req.Query) > 0 {
 		query = db.CaseInsensitiveLike(query, req.Query, "slug", "display_name")
 	}
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
-		log.Printf("failed to count clusters for user %s: %v", api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to count clusters for user %s: %v", api.UserID(ctx), err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	var clusters []*entities.Cluster
 	if err := req.PagedSQL(query).Find(&clusters).Error; err != nil {
-		log.Printf("failed to list clusters for user %s: %v", api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to list clusters for user %s: %v", api.UserID(ctx), err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -94,6 +96,9 @@ func (s *clusterService) ListClusters(ctx context.Context, req *models.ListClust
 			DisplayName: cluster.DisplayName,
 			Description: cluster.Description,
 			Enabled:     cluster.Enabled,
+
+			RoutingBackend:   cluster.RoutingBackend,
+			IngressClassName: cluster.IngressClassName,
 		}
 		if api.IsAdmin(ctx) {
 			item.KubeConfig = cluster.KubeConfig
@@ -104,12 +109,12 @@ func (s *clusterService) ListClusters(ctx context.Context, req *models.ListClust
 			defer wg.Done()
 			kstore, err := kube.ClusterStore(ctx, item.ClusterID)
 			if err != nil {
-				log.Printf("failed to get cluster store for %s: %v", item.ClusterID, err)
+				logging.Errorf(ctx, "failed to get cluster store for %s: %v", item.ClusterID, err)
 				return
 			}
 			nodes, e := kstore.NodeLister().List(labels.Everything())
 			if e != nil {
-				log.Printf("failed to list nodes for cluster %s: %v", item.ClusterID, e)
+				logging.Errorf(ctx, "failed to list nodes for cluster %s: %v", item.ClusterID, e)
 				return
 			}
 			if len(nodes) == 0 {
@@ -137,8 +142,8 @@ func (s *clusterService) ListClusters(ctx context.Context, req *models.ListClust
 
 func (s *clusterService) AllClusterRefs(ctx context.Context) ([]*models.ClusterRef, app.Error) {
 	result := []*models.ClusterRef{}
-	if err := db.Instance().Model(&entities.Cluster{}).Where("enabled = ?", true).Find(&result).Error; err != nil {
-		log.Printf("failed to list cluster refs: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.Cluster{}).Where("enabled = ?", true).Find(&result).Error; err != nil {
+		logging.Errorf(ctx, "failed to list cluster refs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -147,8 +152,8 @@ func (s *clusterService) AllClusterRefs(ctx context.Context) ([]*models.ClusterR
 
 func (s *clusterService) GetCluster(ctx context.Context, req *models.GetClusterRequest) (*models.ClusterModel, app.Error) {
 	cluster := &entities.Cluster{}
-	if err := db.Instance().Where("id = ?", req.ClusterID).First(cluster).Error; err != nil {
-		log.Printf("failed to get cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+	if err := db.WithContext(ctx).Where("id = ?", req.ClusterID).First(cluster).Error; err != nil {
+		logging.Errorf(ctx, "failed to get cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Cluster not found")
 		}
@@ -161,6 +166,9 @@ func (s *clusterService) GetCluster(ctx context.Context, req *models.GetClusterR
 		DisplayName: cluster.DisplayName,
 		Description: cluster.Description,
 		Enabled:     cluster.Enabled,
+
+		RoutingBackend:   cluster.RoutingBackend,
+		IngressClassName: cluster.IngressClassName,
 	}
 	if api.IsAdmin(ctx) {
 		result.KubeConfig = cluster.KubeConfig
@@ -171,7 +179,7 @@ func (s *clusterService) GetCluster(ctx context.Context, req *models.GetClusterR
 
 func (s *clusterService) GetClusterRef(ctx context.Context, req *models.GetClusterRefRequest) (*models.ClusterRef, app.Error) {
 	result := &models.ClusterRef{}
-	if err := db.Instance().Model(&entities.Cluster{}).First(result, "id = ?", req.ClusterID).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&entities.Cluster{}).First(result, "id = ?", req.ClusterID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Cluster not found")
 		}
@@ -189,14 +197,28 @@ func (s *clusterService) CreateCluster(ctx context.Context, req *models.CreateCl
 		GatewayIP:   req.GatewayIP,
 		Description: req.Description,
 		Enabled:     true,
+
+		RoutingBackend:   req.RoutingBackend,
+		IngressClassName: req.IngressClassName,
 		AuditBase: entities.AuditBase{
 			CreatedBy: api.UserID(ctx),
 			UpdatedBy: api.UserID(ctx),
 		},
 	}
 
-	if err := db.Instance().Create(cluster).Error; err != nil {
-		log.Printf("failed to create cluster for user %s: %v", api.UserID(ctx), err)
+	if cluster.RoutingBackend == "" {
+		cluster.RoutingBackend = app.ClusterRoutingBackendGatewayAPI
+	}
+	kcli, err := kube.RuntimeClientFromKubeConfigBytes(ctx, []byte(req.KubeConfig))
+	if err != nil {
+		return nil, err
+	}
+	if err := validateClusterRoutingBackend(ctx, kcli, cluster.RoutingBackend, cluster.IngressClassName); err != nil {
+		return nil, err
+	}
+
+	if err := db.WithContext(ctx).Create(cluster).Error; err != nil {
+		logging.Errorf(ctx, "failed to create cluster for user %s: %v", api.UserID(ctx), err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusConflict, "cluster with this slug already exists")
 		}
@@ -210,13 +232,16 @@ func (s *clusterService) CreateCluster(ctx context.Context, req *models.CreateCl
 		Description: cluster.Description,
 		KubeConfig:  cluster.KubeConfig,
 		Enabled:     cluster.Enabled,
+
+		RoutingBackend:   cluster.RoutingBackend,
+		IngressClassName: cluster.IngressClassName,
 	}, nil
 }
 
 func (s *clusterService) UpdateCluster(ctx context.Context, req *models.UpdateClusterRequest) (*models.ClusterModel, app.Error) {
 	cluster := &entities.Cluster{}
-	if err := db.Instance().Where("id = ?", req.ClusterID).First(cluster).Error; err != nil {
-		log.Printf("failed to get cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+	if err := db.WithContext(ctx).Where("id = ?", req.ClusterID).First(cluster).Error; err != nil {
+		logging.Errorf(ctx, "failed to get cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "cluster not found")
 		}
@@ -226,17 +251,36 @@ func (s *clusterService) UpdateCluster(ctx context.Context, req *models.UpdateCl
 	cluster.DisplayName = req.DisplayName
 	cluster.KubeConfig = req.KubeConfig
 	cluster.Description = req.Description
+	if req.RoutingBackend != "" {
+		kcli, err := kube.RuntimeClientFromKubeConfigBytes(ctx, []byte(cluster.KubeConfig))
+		if err != nil {
+			return nil, err
+		}
+		if err := validateClusterRoutingBackend(ctx, kcli, req.RoutingBackend, req.IngressClassName); err != nil {
+			return nil, err
+		}
+		if req.RoutingBackend == app.ClusterRoutingBackendIngress && cluster.RoutingBackend != app.ClusterRoutingBackendIngress {
+			if err := validateClusterGatewaysIngressRoutable(ctx, cluster.ID); err != nil {
+				return nil, err
+			}
+		}
+		cluster.RoutingBackend = req.RoutingBackend
+		cluster.IngressClassName = req.IngressClassName
+	}
 
-	if err := db.Instance().Select("DisplayName", "KubeConfig", "Description", "UpdatedBy").Updates(&entities.Cluster{
+	if err := db.WithContext(ctx).Select("DisplayName", "KubeConfig", "Description", "RoutingBackend", "IngressClassName", "UpdatedBy").Updates(&entities.Cluster{
 		UUIDBase:    cluster.UUIDBase,
 		DisplayName: cluster.DisplayName,
 		KubeConfig:  cluster.KubeConfig,
 		Description: cluster.Description,
+
+		RoutingBackend:   cluster.RoutingBackend,
+		IngressClassName: cluster.IngressClassName,
 		AuditBase: entities.AuditBase{
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to update cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -247,22 +291,25 @@ func (s *clusterService) UpdateCluster(ctx context.Context, req *models.UpdateCl
 		Description: cluster.Description,
 		KubeConfig:  cluster.KubeConfig,
 		Enabled:     cluster.Enabled,
+
+		RoutingBackend:   cluster.RoutingBackend,
+		IngressClassName: cluster.IngressClassName,
 	}, nil
 }
 
 func (s *clusterService) DeleteCluster(ctx context.Context, req *models.DeleteClusterRequest) app.Error {
 	var envCount int64
-	if err := db.Instance().Model(&entities.Env{}).Where("cluster_id = ?", req.ClusterID).Count(&envCount).Error; err != nil {
-		log.Printf("failed to count environments for cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+	if err := db.WithContext(ctx).Model(&entities.Env{}).Where("cluster_id = ?", req.ClusterID).Count(&envCount).Error; err != nil {
+		logging.Errorf(ctx, "failed to count environments for cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		return app.ErrDatabaseOperationFailed
 	}
 	if envCount > 0 {
-		log.Printf("cannot delete cluster %s for user %s: cluster has associated environments", req.ClusterID, api.UserID(ctx))
+		logging.Infof(ctx, "cannot delete cluster %s for user %s: cluster has associated environments", req.ClusterID, api.UserID(ctx))
 		return app.NewError(http.StatusConflict, "cluster has associated environments")
 	}
 
-	if err := db.Instance().Delete(&entities.Cluster{}, "id = ?", req.ClusterID).Error; err != nil {
-		log.Printf("failed to delete cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+	if err := db.WithContext(ctx).Delete(&entities.Cluster{}, "id = ?", req.ClusterID).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		return app.ErrDatabaseOperationFailed
 	}
 
@@ -270,7 +317,7 @@ func (s *clusterService) DeleteCluster(ctx context.Context, req *models.DeleteCl
 }
 
 func (s *clusterService) EnableCluster(ctx context.Context, req *models.EnabledClusterRequest) app.Error {
-	if err := db.Instance().Updates(&entities.Cluster{
+	if err := db.WithContext(ctx).Updates(&entities.Cluster{
 		UUIDBase: entities.UUIDBase{
 			ID: req.ClusterID},
 		Enabled: true,
@@ -278,7 +325,7 @@ func (s *clusterService) EnableCluster(ctx context.Context, req *models.EnabledC
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to enable cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to enable cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		return app.ErrDatabaseOperationFailed
 	}
 
@@ -286,7 +333,7 @@ func (s *clusterService) EnableCluster(ctx context.Context, req *models.EnabledC
 }
 
 func (s *clusterService) DisableCluster(ctx context.Context, req *models.DisableClusterRequest) app.Error {
-	if err := db.Instance().Updates(&entities.Cluster{
+	if err := db.WithContext(ctx).Updates(&entities.Cluster{
 		UUIDBase: entities.UUIDBase{
 			ID: req.ClusterID,
 		},
@@ -295,7 +342,7 @@ func (s *clusterService) DisableCluster(ctx context.Context, req *models.Disable
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to disable cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to disable cluster %s for user %s: %v", req.ClusterID, api.UserID(ctx), err)
 		return app.ErrDatabaseOperationFailed
 	}
 
@@ -303,7 +350,55 @@ func (s *clusterService) DisableCluster(ctx context.Context, req *models.Disable
 }
 
 func (s *clusterService) PingClusterKubeConfig(ctx context.Context, req *models.PingClusterKubeConfigRequest) bool {
-	return kube.CheckKubeConfigBytes([]byte(req.KubeConfig))
+	return kube.CheckKubeConfigBytes(ctx, []byte(req.KubeConfig))
+}
+
+// validateClusterRoutingBackend checks the cluster can route through the
+// backend: the Gateway API must be installed, or the ingress class must exist.
+// Apps pick up a changed backend when they are deployed next.
+func validateClusterRoutingBackend(ctx context.Context, kcli client.Client, routingBackend, ingressClassName string) app.Error {
+	switch routingBackend {
+	case app.ClusterRoutingBackendGatewayAPI:
+		if ingressClassName != "" {
+			return app.NewError(http.StatusBadRequest, "Ingress class is only used by the ingress routing backend")
+		}
+		installed, err := core.CheckGatewayAPIInstalled(ctx, kcli)
+		if err != nil {
+			return err
+		}
+		if !installed {
+			return app.NewError(http.StatusBadRequest, "Gateway API is not installed in the cluster")
+		}
+	case app.ClusterRoutingBackendIngress:
+		if ingressClassName == "" {
+			return nil
+		}
+		if err := kcli.Get(ctx, client.ObjectKey{Name: ingressClassName}, &networkingv1.IngressClass{}); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return app.NewError(http.StatusBadRequest, "Ingress class "+ingressClassName+" not found in the cluster")
+			}
+			logging.Errorf(ctx, "failed to get ingress class %s: %v", ingressClassName, err)
+			return app.ErrClusterOperationFailed
+		}
+	}
+	return nil
+}
+
+func (s *clusterService) ListClusterIngressClasses(ctx context.Context, req *models.ListClusterIngressClassesRequest) ([]*models.ClusterIngressClassModel, app.Error) {
+	ingressClasses, err := kube.ListIngressClasses(ctx, req.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.ClusterIngressClassModel, 0, len(ingressClasses))
+	for _, ingressClass := range ingressClasses {
+		result = append(result, &models.ClusterIngressClassModel{
+			Name:       ingressClass.Name,
+			Controller: ingressClass.Spec.Controller,
+			IsDefault:  kube.IsDefaultIngressClass(ingressClass),
+		})
+	}
+	return result, nil
 }
 
 func (s *clusterService) ListClusterNodes(ctx context.Context, req *models.ListClusterNodesRequest) ([]*models.ClusterNodeModel, app.Error) {
@@ -314,7 +409,7 @@ func (s *clusterService) ListClusterNodes(ctx context.Context, req *models.ListC
 
 	nodes, e := kstore.NodeLister().List(labels.Everything())
 	if e != nil {
-		log.Printf("failed to list nodes for cluster %s: %v", req.ClusterID, e)
+		logging.Errorf(ctx, "failed to list nodes for cluster %s: %v", req.ClusterID, e)
 		return nil, app.ErrClusterOperationFailed
 	}
 
@@ -339,7 +434,7 @@ func (s *clusterService) ListClusterNodeRefs(ctx context.Context, req *models.Li
 
 	nodes, e := kstore.NodeLister().List(labels.Everything())
 	if e != nil {
-		log.Printf("failed to list nodes for cluster %s: %v", req.ClusterID, e)
+		logging.Errorf(ctx, "failed to list nodes for cluster %s: %v", req.ClusterID, e)
 		return nil, app.ErrClusterOperationFailed
 	}
 
@@ -374,7 +469,7 @@ func (s *clusterService) GetClusterNode(ctx context.Context, req *models.GetClus
 
 	node, e := kstore.NodeLister().Get(req.NodeName)
 	if e != nil {
-		log.Printf("failed to get node %s for cluster %s: %v", req.NodeName, req.ClusterID, e)
+		logging.Errorf(ctx, "failed to get node %s for cluster %s: %v", req.NodeName, req.ClusterID, e)
 		if k8serrors.IsNotFound(e) {
 			return nil, app.NewError(http.StatusNotFound, "node not found")
 		}
@@ -392,7 +487,7 @@ func (s *clusterService) ListClusterNodeLabels(ctx context.Context, req *models.
 
 	nodes, kubeErr := store.NodeLister().List(labels.Everything())
 	if kubeErr != nil {
-		log.Printf("failed to list nodes: %v", kubeErr)
+		logging.Errorf(ctx, "failed to list nodes: %v", kubeErr)
 		return nil, app.ErrClusterOperationFailed
 	}
 
@@ -425,7 +520,7 @@ func (s *clusterService) ListClusterNodeTaints(ctx context.Context, req *models.
 	result := make([]*models.ClusterNodeTaintModel, 0)
 	nodes, kubeErr := store.NodeLister().List(labels.Everything())
 	if kubeErr != nil {
-		log.Printf("failed to list nodes: %v", kubeErr)
+		logging.Errorf(ctx, "failed to list nodes: %v", kubeErr)
 		return nil, app.ErrClusterOperationFailed
 	}
 
@@ -466,10 +561,10 @@ func (s *clusterService) CheckClusterExtensionFeatureEnabled(ctx context.Context
 	var crd apiextensionsv1.CustomResourceDefinition
 	if err := kclient.Get(ctx, client.ObjectKey{Name: "helmrepositories.helm-operator.ketches.cn"}, &crd); err != nil {
 		if k8serrors.IsNotFound(err) {
-			log.Printf("crd HelmRepository not found for cluster %s: %v", req.ClusterID, err)
+			logging.Errorf(ctx, "crd HelmRepository not found for cluster %s: %v", req.ClusterID, err)
 			return false, nil
 		}
-		log.Printf("failed to get crd HelmRepository for cluster %s: %v", req.ClusterID, err)
+		logging.Errorf(ctx, "failed to get crd HelmRepository for cluster %s: %v", req.ClusterID, err)
 		return false, app.ErrClusterOperationFailed
 	}
 
@@ -482,7 +577,7 @@ func (s *clusterService) EnableClusterExtension(ctx context.Context, req *models
 		return err
 	}
 	if err := helmoperatorinstaller.NewInstaller(kclient).Install(ctx); err != nil {
-		log.Printf("failed to install helm operator for cluster %s: %v", req.ClusterID, err)
+		logging.Errorf(ctx, "failed to install helm operator for cluster %s: %v", req.ClusterID, err)
 		return app.NewError(http.StatusInternalServerError, "failed to enable cluster extension feature")
 	}
 
@@ -514,18 +609,18 @@ func (s *clusterService) ListClusterExtensions(ctx context.Context, req *models.
 	var helmrepository helmoperatorv1alpha1.HelmRepository
 	if err := kclient.Get(ctx, client.ObjectKey{Namespace: "ketches", Name: "ketches-extension-charts"}, &helmrepository); err != nil {
 		if k8serrors.IsNotFound(err) {
-			log.Printf("ketches helm repository not found for cluster %s: %v", req.ClusterID, err)
+			logging.Errorf(ctx, "ketches helm repository not found for cluster %s: %v", req.ClusterID, err)
 			result := make(models.ListClusterExtensionsResponse)
 			return &result, nil
 		}
-		log.Printf("failed to get ketches helm repository for cluster %s: %v", req.ClusterID, err)
+		logging.Errorf(ctx, "failed to get ketches helm repository for cluster %s: %v", req.ClusterID, err)
 		return nil, app.ErrClusterOperationFailed
 	}
 
 	// Get all installed HelmReleases
 	var helmreleases helmoperatorv1alpha1.HelmReleaseList
 	if err := kclient.List(ctx, &helmreleases, client.MatchingLabels{"ketches.cn/owned": "ketches"}); err != nil {
-		log.Printf("failed to list helm releases for cluster %s: %v", req.ClusterID, err)
+		logging.Errorf(ctx, "failed to list helm releases for cluster %s: %v", req.ClusterID, err)
 		return nil, app.ErrClusterOperationFailed
 	}
 
@@ -640,7 +735,7 @@ func (s *clusterService) InstallClusterExtension(ctx context.Context, req *model
 	}
 
 	if err := kclient.Create(ctx, release); err != nil {
-		log.Printf("failed to create helm release %s for cluster %s: %v", req.ExtensionName, req.ClusterID, err)
+		logging.Errorf(ctx, "failed to create helm release %s for cluster %s: %v", req.ExtensionName, req.ClusterID, err)
 		if k8serrors.IsAlreadyExists(err) {
 			return app.NewError(http.StatusConflict, "extension already installed")
 		}
@@ -661,7 +756,7 @@ func (s *clusterService) UninstallClusterExtension(ctx context.Context, req *mod
 		"ketches.cn/owned":          "ketches",
 		"extension.ketches.cn/name": req.ExtensionName,
 	}); err != nil {
-		log.Printf("failed to list helm releases for extension %s in cluster %s: %v", req.ExtensionName, req.ClusterID, err)
+		logging.Errorf(ctx, "failed to list helm releases for extension %s in cluster %s: %v", req.ExtensionName, req.ClusterID, err)
 		return app.ErrClusterOperationFailed
 	}
 
@@ -672,7 +767,7 @@ func (s *clusterService) UninstallClusterExtension(ctx context.Context, req *mod
 	// Delete the HelmRelease
 	release := &helmreleases.Items[0]
 	if err := kclient.Delete(ctx, release); err != nil {
-		log.Printf("failed to delete helm release %s for cluster %s: %v", req.ExtensionName, req.ClusterID, err)
+		logging.Errorf(ctx, "failed to delete helm release %s for cluster %s: %v", req.ExtensionName, req.ClusterID, err)
 		if k8serrors.IsNotFound(err) {
 			return app.NewError(http.StatusNotFound, "extension not found")
 		}
@@ -694,7 +789,7 @@ func (s *clusterService) GetClusterExtensionValues(ctx context.Context, req *mod
 
 	cm, e := kstore.ConfigMapLister().ConfigMaps("ketches").Get(configMapName)
 	if e != nil {
-		log.Printf("failed to get ConfigMap %s for extension %s in cluster %s: %v", configMapName, req.ExtensionName, req.ClusterID, e)
+		logging.Errorf(ctx, "failed to get ConfigMap %s for extension %s in cluster %s: %v", configMapName, req.ExtensionName, req.ClusterID, e)
 		if k8serrors.IsNotFound(e) {
 			return "", nil // Return empty string if ConfigMap doesn't exist
 		}
@@ -721,7 +816,7 @@ func (s *clusterService) GetInstalledExtensionValues(ctx context.Context, req *m
 		"ketches.cn/owned":          "ketches",
 		"extension.ketches.cn/name": req.ExtensionName,
 	}); err != nil {
-		log.Printf("failed to list helm releases for extension %s in cluster %s: %v", req.ExtensionName, req.ClusterID, err)
+		logging.Errorf(ctx, "failed to list helm releases for extension %s in cluster %s: %v", req.ExtensionName, req.ClusterID, err)
 		return "", app.ErrClusterOperationFailed
 	}
 
@@ -746,7 +841,7 @@ func (s *clusterService) UpdateClusterExtension(ctx context.Context, req *models
 		"ketches.cn/owned":          "ketches",
 		"extension.ketches.cn/name": req.ExtensionName,
 	}); err != nil {
-		log.Printf("failed to list helm releases for extension %s in cluster %s: %v", req.ExtensionName, req.ClusterID, err)
+		logging.Errorf(ctx, "failed to list helm releases for extension %s in cluster %s: %v", req.ExtensionName, req.ClusterID, err)
 		return app.ErrClusterOperationFailed
 	}
 
@@ -764,7 +859,7 @@ func (s *clusterService) UpdateClusterExtension(ctx context.Context, req *models
 	}
 
 	if err := kclient.Update(ctx, release); err != nil {
-		log.Printf("failed to update helm release %s for cluster %s: %v", req.ExtensionName, req.ClusterID, err)
+		logging.Errorf(ctx, "failed to update helm release %s for cluster %s: %v", req.ExtensionName, req.ClusterID, err)
 		return app.ErrClusterOperationFailed
 	}
 
@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/internal/app"
@@ -13,6 +15,7 @@ import (
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/db/orm"
 	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"github.com/ketches/ketches/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +31,7 @@ type EnvService interface {
 	GetEnvRef(ctx context.Context, req *models.GetEnvRefRequest) (*models.EnvRef, app.Error)
 	UpdateEnv(ctx context.Context, req *models.UpdateEnvRequest) (*models.EnvModel, app.Error)
 	DeleteEnv(ctx context.Context, req *models.DeleteEnvRequest) app.Error
+	EnvAction(ctx context.Context, req *models.EnvActionRequest) (*models.EnvActionResponse, app.Error)
 }
 
 type envService struct {
@@ -41,7 +45,7 @@ func NewEnvService() EnvService {
 }
 
 func (s *envService) ListEnvs(ctx context.Context, req *models.ListEnvsRequest) (*models.ListEnvsResponse, app.Error) {
-	query := db.Instance().Model(&entities.Env{})
+	query := db.WithContext(ctx).Model(&entities.Env{})
 	if len(req.ProjectID) > 0 {
 		// Permission check is now handled by middleware when accessing specific project resources
 		query = query.Where("project_id = ?", req.ProjectID)
@@ -53,13 +57,13 @@ func (s *envService) ListEnvs(ctx context.Context, req *models.ListEnvsRequest)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
-		log.Printf("failed to count envs: %v", err)
+		logging.Errorf(ctx, "failed to count envs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	envs := []*entities.Env{}
 	if err := req.PagedSQL(query).Find(&envs).Error; err != nil {
-		log.Printf("failed to list envs: %v", err)
+		logging.Errorf(ctx, "failed to list envs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -75,6 +79,8 @@ func (s *envService) ListEnvs(ctx context.Context, req *models.ListEnvsRequest)
 			Description: env.Description,
 			ProjectID:   env.ProjectID,
 			CreatedAt:   utils.HumanizeTime(env.CreatedAt),
+
+			NetworkPolicyMode: env.NetworkPolicyMode,
 		})
 	}
 
@@ -83,8 +89,8 @@ func (s *envService) ListEnvs(ctx context.Context, req *models.ListEnvsRequest)
 
 func (s *envService) AllEnvRefs(ctx context.Context, req *models.AllEnvRefsRequest) ([]*models.EnvRef, app.Error) {
 	refs := []*models.EnvRef{}
-	if err := db.Instance().Model(&entities.Env{}).Where("project_id = ?", req.ProjectID).Find(&refs).Error; err != nil {
-		log.Printf("failed to list env refs: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.Env{}).Where("project_id = ?", req.ProjectID).Find(&refs).Error; err != nil {
+		logging.Errorf(ctx, "failed to list env refs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -97,12 +103,7 @@ func (s *envService) CreateEnv(ctx context.Context, req *models.CreateEnvRequest
 		return nil, err
 	}
 
-	clusterSlug, err := orm.GetClusterSlugByID(ctx, req.ClusterID)
-	if err != nil {
-		return nil, err
-	}
-
-	clusterGatewayIP, err := orm.GetClusterGatewayIPByID(ctx, req.ClusterID)
+	cluster, err := orm.GetClusterByID(ctx, req.ClusterID)
 	if err != nil {
 		return nil, err
 	}
@@ -114,8 +115,13 @@ func (s *envService) CreateEnv(ctx context.Context, req *models.CreateEnvRequest
 		ProjectID:        req.ProjectID,
 		ProjectSlug:      projectSlug,
 		ClusterID:        req.ClusterID,
-		ClusterSlug:      clusterSlug,
+		ClusterSlug:      cluster.Slug,
 		ClusterNamespace: fmt.Sprintf("%s-%s", projectSlug, req.Slug),
+
+		NetworkPolicyMode: req.NetworkPolicyMode,
+	}
+	if env.NetworkPolicyMode == "" {
+		env.NetworkPolicyMode = app.EnvNetworkPolicyModeOpen
 	}
 
 	// Create namespace for the env in the cluster
@@ -123,8 +129,8 @@ func (s *envService) CreateEnv(ctx context.Context, req *models.CreateEnvRequest
 		return nil, err
 	}
 
-	if err := db.Instance().Create(env).Error; err != nil {
-		log.Printf("failed to create env: %v", err)
+	if err := db.WithContext(ctx).Create(env).Error; err != nil {
+		logging.Errorf(ctx, "failed to create env: %v", err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusConflict, "Env with this slug already exists in the project")
 		}
@@ -133,29 +139,40 @@ func (s *envService) CreateEnv(ctx context.Context, req *models.CreateEnvRequest
 
 	kcli, err := kube.ClusterRuntimeClient(ctx, env.ClusterID)
 	if err != nil {
-		log.Printf("failed to get cluster runtime client for env %s: %v", env.ID, err)
+		logging.Errorf(ctx, "failed to get cluster runtime client for env %s: %v", env.ID, err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to get cluster runtime client")
 	}
 
-	if installed, _ := core.CheckGatewayAPIInstalled(ctx, kcli); installed {
+	registryCredentials, err := orm.AllProjectRegistryCredentials(ctx, env.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.SyncRegistryCredentialSecrets(ctx, kcli, env.ClusterNamespace, registryCredentials); err != nil {
+		logging.Errorf(ctx, "failed to sync registry credentials into namespace %s: %v", env.ClusterNamespace, err)
+	}
+
+	if err := core.ApplyEnvNetworkPolicy(ctx, kcli, env.ClusterNamespace, env.NetworkPolicyMode, envResourceLabels(env)); err != nil {
+		logging.Errorf(ctx, "failed to apply network policy of env %s: %v", env.ID, err)
+	}
+
+	// Clusters routing through Ingresses expose apps without an env Gateway
+	var gatewayAPIInstalled bool
+	if cluster.RoutingBackend != app.ClusterRoutingBackendIngress {
+		gatewayAPIInstalled, _ = core.CheckGatewayAPIInstalled(ctx, kcli)
+	}
+	if gatewayAPIInstalled {
 		core.ApplyResource(ctx, kcli, &gatewayapisv1.Gateway{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      env.ClusterNamespace,
 				Namespace: env.ClusterNamespace,
-				Labels: map[string]string{
-					"ketches.cn/owned":     "true",
-					"ketches.cn/env":       env.Slug,
-					"ketches.cn/envID":     env.ID,
-					"ketches.cn/project":   env.ProjectSlug,
-					"ketches.cn/projectID": env.ProjectID,
-				},
+				Labels:    envResourceLabels(env),
 			},
 			Spec: gatewayapisv1.GatewaySpec{
 				GatewayClassName: gatewayapisv1.ObjectName("ketches"),
 				Addresses: []gatewayapisv1.GatewaySpecAddress{
 					{
 						Type:  utils.Ptr(gatewayapisv1.IPAddressType),
-						Value: clusterGatewayIP,
+						Value: cluster.GatewayIP,
 					},
 				},
 				Listeners: []gatewayapisv1.Listener{
@@ -187,13 +204,15 @@ func (s *envService) CreateEnv(ctx context.Context, req *models.CreateEnvRequest
 		Description: env.Description,
 		ProjectID:   env.ProjectID,
 		ClusterID:   env.ClusterID,
+
+		NetworkPolicyMode: env.NetworkPolicyMode,
 	}, nil
 }
 
 func (s *envService) GetEnv(ctx context.Context, req *models.GetEnvRequest) (*models.EnvModel, app.Error) {
 	env := new(entities.Env)
-	if err := db.Instance().First(env, "id = ?", req.EnvID).Error; err != nil {
-		log.Printf("failed to get env %s: %v", req.EnvID, err)
+	if err := db.WithContext(ctx).First(env, "id = ?", req.EnvID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get env %s: %v", req.EnvID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -207,12 +226,14 @@ func (s *envService) GetEnv(ctx context.Context, req *models.GetEnvRequest) (*mo
 		Description: env.Description,
 		ProjectID:   env.ProjectID,
 		CreatedAt:   utils.HumanizeTime(env.CreatedAt),
+
+		NetworkPolicyMode: env.NetworkPolicyMode,
 	}, nil
 }
 
 func (s *envService) GetEnvRef(ctx context.Context, req *models.GetEnvRefRequest) (*models.EnvRef, app.Error) {
 	result := &models.EnvRef{}
-	if err := db.Instance().Model(&entities.Env{}).First(result, "id = ?", req.EnvID).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&entities.Env{}).First(result, "id = ?", req.EnvID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -224,8 +245,8 @@ func (s *envService) GetEnvRef(ctx context.Context, req *models.GetEnvRefRequest
 
 func (s *envService) UpdateEnv(ctx context.Context, req *models.UpdateEnvRequest) (*models.EnvModel, app.Error) {
 	env := &entities.Env{}
-	if err := db.Instance().First(env, "id = ?", req.EnvID).Error; err != nil {
-		log.Printf("failed to find env %s: %v", req.EnvID, err)
+	if err := db.WithContext(ctx).First(env, "id = ?", req.EnvID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find env %s: %v", req.EnvID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -235,15 +256,38 @@ func (s *envService) UpdateEnv(ctx context.Context, req *models.UpdateEnvRequest
 	env.DisplayName = req.DisplayName
 	env.Description = req.Description
 
-	if err := db.Instance().Updates(&entities.Env{
-		UUIDBase:    env.UUIDBase,
-		DisplayName: env.DisplayName,
-		Description: env.Description,
+	if req.NetworkPolicyMode != "" && req.NetworkPolicyMode != env.NetworkPolicyMode {
+		if req.NetworkPolicyMode != app.EnvNetworkPolicyModeOpen {
+			// Dependents in other envs would lose access to their dependencies
+			dependent, err := crossEnvDependent(ctx, env.ID)
+			if err != nil {
+				return nil, err
+			}
+			if dependent != "" {
+				return nil, app.NewError(http.StatusConflict, "App "+dependent+" of another env depends on apps of the env, remove the dependency before rejecting traffic from other envs")
+			}
+		}
+		kcli, err := kube.ClusterRuntimeClient(ctx, env.ClusterID)
+		if err != nil {
+			logging.Errorf(ctx, "failed to get cluster runtime client for env %s: %v", env.ID, err)
+			return nil, app.NewError(http.StatusInternalServerError, "Failed to get cluster runtime client")
+		}
+		if err := core.ApplyEnvNetworkPolicy(ctx, kcli, env.ClusterNamespace, req.NetworkPolicyMode, envResourceLabels(env)); err != nil {
+			return nil, err
+		}
+		env.NetworkPolicyMode = req.NetworkPolicyMode
+	}
+
+	if err := db.WithContext(ctx).Updates(&entities.Env{
+		UUIDBase:          env.UUIDBase,
+		DisplayName:       env.DisplayName,
+		Description:       env.Description,
+		NetworkPolicyMode: env.NetworkPolicyMode,
 		AuditBase: entities.AuditBase{
 			UpdatedBy: api.UserID(ctx),
 		},
 	}).Error; err != nil {
-		log.Printf("failed to update env %s: %v", req.EnvID, err)
+		logging.Errorf(ctx, "failed to update env %s: %v", req.EnvID, err)
 		return nil, app.NewError(http.StatusInternalServerError, "Failed to update env")
 	}
 
@@ -253,13 +297,15 @@ func (s *envService) UpdateEnv(ctx context.Context, req *models.UpdateEnvRequest
 		DisplayName: env.DisplayName,
 		Description: env.Description,
 		ProjectID:   env.ProjectID,
+
+		NetworkPolicyMode: env.NetworkPolicyMode,
 	}, nil
 }
 
 func (s *envService) DeleteEnv(ctx context.Context, req *models.DeleteEnvRequest) app.Error {
 	env := &entities.Env{}
-	if err := db.Instance().First(env, "id = ?", req.EnvID).Error; err != nil {
-		log.Printf("failed to find env %s: %v", req.EnvID, err)
+	if err := db.WithContext(ctx).First(env, "id = ?", req.EnvID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find env %s: %v", req.EnvID, err)
 		if db.IsErrRecordNotFound(err) {
 			return app.NewError(http.StatusNotFound, "Env not found")
 		}
@@ -275,18 +321,127 @@ func (s *envService) DeleteEnv(ctx context.Context, req *models.DeleteEnvRequest
 	}
 
 	if err := kube.DeleteNamespace(ctx, env.ClusterID, env.ClusterNamespace); err != nil {
-		log.Printf("failed to delete namespace %s: %v", env.ClusterNamespace, err)
+		logging.Errorf(ctx, "failed to delete namespace %s: %v", env.ClusterNamespace, err)
 		return app.NewError(http.StatusInternalServerError, "Failed to delete env")
 	}
 
-	if err := db.Instance().Delete(env).Error; err != nil {
-		log.Printf("failed to delete env %s: %v", req.EnvID, err)
+	if err := db.WithContext(ctx).Delete(env).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete env %s: %v", req.EnvID, err)
 		return app.NewError(http.StatusInternalServerError, "Failed to delete env")
 	}
 
 	return nil
 }
 
+// envActionLayerTimeout is how long an env action waits for the apps of a
+// layer to be started or stopped before moving on to the next layer.
+const envActionLayerTimeout = 5 * time.Minute
+
+// runningEnvActions holds the IDs of the envs an action is running for, one
+// action runs per env at a time.
+var runningEnvActions sync.Map
+
+func (s *envService) EnvAction(ctx context.Context, req *models.EnvActionRequest) (*models.EnvActionResponse, app.Error) {
+	if _, err := orm.GetEnvByID(ctx, req.EnvID); err != nil {
+		return nil, err
+	}
+
+	apps, layers, _, err := envAppLayers(ctx, req.EnvID)
+	if err != nil {
+		return nil, err
+	}
+	// Only apps deployed before are started or stopped, the others stay
+	// undeployed.
+	for i, layer := range layers {
+		layers[i] = slices.DeleteFunc(layer, func(appID string) bool {
+			return core.GetAppStatus(ctx, apps[appID]).Status == app.AppStatusUndeployed
+		})
+	}
+	layers = slices.DeleteFunc(layers, func(layer []string) bool {
+		return len(layer) == 0
+	})
+	if req.Action == app.EnvActionStop {
+		// Stop dependents before the apps they depend on
+		slices.Reverse(layers)
+	}
+
+	result := &models.EnvActionResponse{
+		EnvID:  req.EnvID,
+		Action: req.Action,
+		Layers: make([][]*models.AppRef, 0, len(layers)),
+	}
+	for _, layer := range layers {
+		refs := make([]*models.AppRef, 0, len(layer))
+		for _, appID := range layer {
+			refs = append(refs, &models.AppRef{
+				AppID:       apps[appID].ID,
+				Slug:        apps[appID].Slug,
+				DisplayName: apps[appID].DisplayName,
+				EnvID:       apps[appID].EnvID,
+				ProjectID:   apps[appID].ProjectID,
+			})
+		}
+		result.Layers = append(result.Layers, refs)
+	}
+
+	if running, loaded := runningEnvActions.LoadOrStore(req.EnvID, req.Action); loaded {
+		return nil, app.NewError(http.StatusConflict, fmt.Sprintf("A %s action of the env is running, try again once it completes", running))
+	}
+
+	// Waiting for every layer outlives the request, it must not be canceled
+	// with it.
+	actionCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer runningEnvActions.Delete(req.EnvID)
+		for _, layer := range layers {
+			var deployed []*entities.App
+			for _, appID := range layer {
+				if err := appServiceInstance.deployApp(actionCtx, apps[appID], &core.AppDeployOption{
+					ZeroReplicas: req.Action == app.EnvActionStop,
+				}); err != nil {
+					logging.Errorf(actionCtx, "failed to %s app %s of env %s: %v", req.Action, appID, req.EnvID, err)
+					continue
+				}
+				deployed = append(deployed, apps[appID])
+			}
+			waitEnvActionLayer(actionCtx, req.Action, deployed)
+		}
+		logging.Infof(actionCtx, "%s of env %s completed", req.Action, req.EnvID)
+	}()
+
+	return result, nil
+}
+
+// waitEnvActionLayer waits until the apps are running, or stopped for the
+// stop action, or envActionLayerTimeout passes.
+func waitEnvActionLayer(ctx context.Context, action app.EnvAction, apps []*entities.App) {
+	done := func(status app.AppStatus) bool {
+		if action == app.EnvActionStop {
+			return status == app.AppStatusStopped || status == app.AppStatusUndeployed
+		}
+		return status == app.AppStatusRunning || status == app.AppStatusCompleted
+	}
+
+	deadline := time.Now().Add(envActionLayerTimeout)
+	ticker := time.NewTicker(3 * time.Second)
+	defer ticker.Stop()
+	for len(apps) > 0 {
+		apps = slices.DeleteFunc(apps, func(appEntity *entities.App) bool {
+			return done(core.GetAppStatus(ctx, appEntity).Status)
+		})
+		if len(apps) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			for _, appEntity := range apps {
+				logging.Warnf(ctx, "app %s did not %s within %s, continuing with the apps depending on it", appEntity.ID, action, envActionLayerTimeout)
+			}
+			return
+		}
+		<-ticker.C
+	}
+}
+
 func buildNamespace(env *entities.Env) *corev1.Namespace {
 	return &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -299,3 +454,15 @@ func buildNamespace(env *entities.Env) *corev1.Namespace {
 		},
 	}
 }
+
+// envResourceLabels returns the labels of the resources rendered for an env
+// in its namespace.
+func envResourceLabels(env *entities.Env) map[string]string {
+	return map[string]string{
+		"ketches.cn/owned":     "true",
+		"ketches.cn/env":       env.Slug,
+		"ketches.cn/envID":     env.ID,
+		"ketches.cn/project":   env.ProjectSlug,
+		"ketches.cn/projectID": env.ProjectID,
+	}
+}
@@ -3,14 +3,15 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"slices"
 
 	"github.com/ketches/ketches/internal/api"
 	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"gorm.io/gorm"
 )
@@ -43,7 +44,7 @@ func NewProjectService() ProjectService {
 
 func (s *projectService) ListProjects(ctx context.Context, req *models.ListProjectsRequest) (*models.ListProjectResponse, app.Error) {
 	projects := []*entities.Project{}
-	query := db.Instance().Model(&entities.Project{})
+	query := db.WithContext(ctx).Model(&entities.Project{})
 	if !api.IsAdmin(ctx) {
 		query = query.Joins("INNER JOIN project_members ON project_members.project_id = projects.id").Where("project_members.user_id = ?", api.UserID(ctx))
 	}
@@ -54,12 +55,12 @@ func (s *projectService) ListProjects(ctx context.Context, req *models.ListProje
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
-		log.Printf("failed to count projects for user %s: %v", api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to count projects for user %s: %v", api.UserID(ctx), err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	if err := req.PagedSQL(query).Find(&projects).Error; err != nil {
-		log.Printf("failed to list projects for user %s: %v", api.UserID(ctx), err)
+		logging.Errorf(ctx, "failed to list projects for user %s: %v", api.UserID(ctx), err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -81,11 +82,11 @@ func (s *projectService) ListProjects(ctx context.Context, req *models.ListProje
 
 func (s *projectService) AllProjectRefs(ctx context.Context) ([]*models.ProjectRef, app.Error) {
 	refs := []*models.ProjectRef{}
-	if err := db.Instance().Model(&entities.Project{}).
+	if err := db.WithContext(ctx).Model(&entities.Project{}).
 		Select("projects.id, projects.slug, projects.display_name").
 		Joins("INNER JOIN project_members ON project_members.project_id = projects.id").Where("project_members.user_id = ?", api.UserID(ctx)).
 		Find(&refs).Error; err != nil {
-		log.Printf("failed to list project refs: %v", err)
+		logging.Errorf(ctx, "failed to list project refs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -96,36 +97,56 @@ func (s *projectService) GetStatistics(ctx context.Context, req *models.GetProje
 	stats := &models.ProjectStatisticsModel{}
 
 	// Get total environments
-	if err := db.Instance().Model(&entities.Env{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalEnvs).Error; err != nil {
-		log.Printf("failed to count environments: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.Env{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalEnvs).Error; err != nil {
+		logging.Errorf(ctx, "failed to count environments: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	// Get total apps
-	if err := db.Instance().Model(&entities.App{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalApps).Error; err != nil {
-		log.Printf("failed to count apps: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.App{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalApps).Error; err != nil {
+		logging.Errorf(ctx, "failed to count apps: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	// Get total app gateways
-	if err := db.Instance().Model(&entities.AppGateway{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalAppGateways).Error; err != nil {
-		log.Printf("failed to count app gateways: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.AppGateway{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalAppGateways).Error; err != nil {
+		logging.Errorf(ctx, "failed to count app gateways: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	// Get total members
-	if err := db.Instance().Model(&entities.ProjectMember{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalMembers).Error; err != nil {
-		log.Printf("failed to count project members: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.ProjectMember{}).Where("project_id = ?", req.ProjectID).Count(&stats.TotalMembers).Error; err != nil {
+		logging.Errorf(ctx, "failed to count project members: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
+	// Get actual usage of environments on clusters with metrics
+	envs := []*entities.Env{}
+	if err := db.WithContext(ctx).Where("project_id = ?", req.ProjectID).Order("created_at").Find(&envs).Error; err != nil {
+		logging.Errorf(ctx, "failed to list environments: %v", err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	for _, env := range envs {
+		usage := core.NamespaceUsage(ctx, env.ClusterID, env.ClusterNamespace)
+		if usage == nil {
+			continue
+		}
+		stats.EnvUsages = append(stats.EnvUsages, &models.EnvResourceUsageModel{
+			EnvID:       env.ID,
+			Slug:        env.Slug,
+			DisplayName: env.DisplayName,
+			Usage:       usage,
+		})
+		stats.Usage = core.AddResourceUsage(stats.Usage, usage)
+	}
+
 	return stats, nil
 }
 
 func (s *projectService) GetProject(ctx context.Context, req *models.GetProjectRequest) (*models.ProjectModel, app.Error) {
 	project := new(entities.Project)
-	if err := db.Instance().First(project, "id = ?", req.ProjectID).Error; err != nil {
-		log.Printf("failed to get project %s: %v", req.ProjectID, err)
+	if err := db.WithContext(ctx).First(project, "id = ?", req.ProjectID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get project %s: %v", req.ProjectID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Project not found")
 		}
@@ -142,7 +163,7 @@ func (s *projectService) GetProject(ctx context.Context, req *models.GetProjectR
 
 func (s *projectService) GetProjectRef(ctx context.Context, req *models.GetProjectRefRequest) (*models.ProjectRef, app.Error) {
 	result := &models.ProjectRef{}
-	if err := db.Instance().Model(&entities.Project{}).First(result, "id = ?", req.ProjectID).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&entities.Project{}).First(result, "id = ?", req.ProjectID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Project not found")
 		}
@@ -167,9 +188,9 @@ func (s *projectService) CreateProject(ctx context.Context, req *models.CreatePr
 		},
 	}
 
-	if err := db.Transaction(func(tx *gorm.DB) error {
+	if err := db.Transaction(ctx, func(tx *gorm.DB) error {
 		if err := tx.Create(project).Error; err != nil {
-			log.Printf("failed to create project: %v", err)
+			logging.Errorf(ctx, "failed to create project: %v", err)
 			return err
 		}
 
@@ -181,7 +202,7 @@ func (s *projectService) CreateProject(ctx context.Context, req *models.CreatePr
 		pm.CreatedBy = operator
 		pm.UpdatedBy = operator
 		if err := tx.Create(pm).Error; err != nil {
-			log.Printf("failed to add project owner %s to project %s: %v", operator, project.ID, err)
+			logging.Errorf(ctx, "failed to add project owner %s to project %s: %v", operator, project.ID, err)
 			if db.IsErrDuplicatedKey(err) {
 				return nil
 			}
@@ -205,7 +226,7 @@ func (s *projectService) CreateProject(ctx context.Context, req *models.CreatePr
 
 func (s *projectService) UpdateProject(ctx context.Context, req *models.UpdateProjectRequest) (*models.ProjectModel, app.Error) {
 	project := &entities.Project{}
-	if err := db.Instance().First(project, "id = ?", req.ProjectID).Error; err != nil {
+	if err := db.WithContext(ctx).First(project, "id = ?", req.ProjectID).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Project not found")
 		}
@@ -214,8 +235,8 @@ func (s *projectService) UpdateProject(ctx context.Context, req *models.UpdatePr
 	project.DisplayName = req.DisplayName
 	project.Description = req.Description
 	project.UpdatedBy = api.UserID(ctx)
-	if err := db.Instance().Save(project).Error; err != nil {
-		log.Printf("failed to update project %s: %v", req.ProjectID, err)
+	if err := db.WithContext(ctx).Save(project).Error; err != nil {
+		logging.Errorf(ctx, "failed to update project %s: %v", req.ProjectID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 	return &models.ProjectModel{
@@ -227,17 +248,20 @@ func (s *projectService) UpdateProject(ctx context.Context, req *models.UpdatePr
 }
 
 func (s *projectService) DeleteProject(ctx context.Context, req *models.DeleteProjectRequest) app.Error {
-	if err := db.Transaction(func(tx *gorm.DB) error {
+	if err := db.Transaction(ctx, func(tx *gorm.DB) error {
 		if err := tx.Delete(entities.Project{}, "id = ?", req.ProjectID).Error; err != nil {
 			return err
 		}
 		if err := tx.Delete(entities.ProjectMember{}, "project_id = ?", req.ProjectID).Error; err != nil {
 			return err
 		}
+		if err := tx.Delete(entities.ProjectRegistryCredential{}, "project_id = ?", req.ProjectID).Error; err != nil {
+			return err
+		}
 
 		return nil
 	}); err != nil {
-		log.Printf("failed to delete project %s: %v", req.ProjectID, err)
+		logging.Errorf(ctx, "failed to delete project %s: %v", req.ProjectID, err)
 		return app.ErrDatabaseOperationFailed
 	}
 
@@ -245,7 +269,7 @@ func (s *projectService) DeleteProject(ctx context.Context, req *models.DeletePr
 }
 
 func (s *projectService) ListProjectMembers(ctx context.Context, req *models.ListProjectMembersRequest) (*models.ListProjectMembersResponse, app.Error) {
-	query := db.Instance().Model(&entities.ProjectMember{}).
+	query := db.WithContext(ctx).Model(&entities.ProjectMember{}).
 		Select("project_members.project_id,project_members.user_id,users.username,users.fullname,users.email,users.phone,project_members.project_role,project_members.created_at").
 		Joins("LEFT JOIN users ON users.id = project_members.user_id AND project_members.project_id = ?", req.ProjectID).
 		Where("project_members.project_id = ?", req.ProjectID)
@@ -256,13 +280,13 @@ func (s *projectService) ListProjectMembers(ctx context.Context, req *models.Lis
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
-		log.Printf("failed to count project members for project %s: %v", req.ProjectID, err)
+		logging.Errorf(ctx, "failed to count project members for project %s: %v", req.ProjectID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
 	members := []*models.ProjectMemberModel{}
 	if err := req.PagedSQL(query).Find(&members).Error; err != nil {
-		log.Printf("failed to list project members for project %s: %v", req.ProjectID, err)
+		logging.Errorf(ctx, "failed to list project members for project %s: %v", req.ProjectID, err)
 		if !db.IsErrRecordNotFound(err) {
 			return nil, app.ErrDatabaseOperationFailed
 		}
@@ -276,12 +300,12 @@ func (s *projectService) ListProjectMembers(ctx context.Context, req *models.Lis
 
 func (s *projectService) ListAddableProjectMembers(ctx context.Context, projectID string) ([]*models.UserRef, app.Error) {
 	members := []*models.UserRef{}
-	query := db.Instance().Model(&entities.User{}).
+	query := db.WithContext(ctx).Model(&entities.User{}).
 		Select("users.id, users.username, users.fullname, users.email, users.phone").
 		Joins("LEFT JOIN project_members ON project_members.user_id = users.id AND project_members.project_id = ?", projectID).
 		Where("project_members.user_id IS NULL")
 	if err := query.Find(&members).Error; err != nil {
-		log.Printf("failed to list addable project members for project %s: %v", projectID, err)
+		logging.Errorf(ctx, "failed to list addable project members for project %s: %v", projectID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 	return members, nil
@@ -306,9 +330,9 @@ func (s *projectService) AddProjectMembers(ctx context.Context, req *models.AddP
 
 	var failureCount int
 	for _, member := range members {
-		if err := db.Instance().Create(member).Error; err != nil {
+		if err := db.WithContext(ctx).Create(member).Error; err != nil {
 			if db.IsErrDuplicatedKey(err) {
-				log.Printf("project member %s already exists in project %s", member.UserID, member.ProjectID)
+				logging.Infof(ctx, "project member %s already exists in project %s", member.UserID, member.ProjectID)
 				continue // Skip if member already exists
 			}
 			failureCount++
@@ -327,8 +351,8 @@ func (s *projectService) UpdateProjectMember(ctx context.Context, req *models.Up
 	}
 
 	member := &entities.ProjectMember{}
-	if err := db.Instance().First(member, "project_id = ? AND user_id = ?", req.ProjectID, req.UserID).Error; err != nil {
-		log.Printf("failed to find project member %s in project %s: %v", req.UserID, req.ProjectID, err)
+	if err := db.WithContext(ctx).First(member, "project_id = ? AND user_id = ?", req.ProjectID, req.UserID).Error; err != nil {
+		logging.Errorf(ctx, "failed to find project member %s in project %s: %v", req.UserID, req.ProjectID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, "Project member not found")
 		}
@@ -336,8 +360,8 @@ func (s *projectService) UpdateProjectMember(ctx context.Context, req *models.Up
 	}
 
 	member.ProjectRole = req.ProjectRole
-	if err := db.Instance().Save(member).Error; err != nil {
-		log.Printf("failed to update project member %s in project %s: %v", req.UserID, req.ProjectID, err)
+	if err := db.WithContext(ctx).Save(member).Error; err != nil {
+		logging.Errorf(ctx, "failed to update project member %s in project %s: %v", req.UserID, req.ProjectID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -353,8 +377,8 @@ func (s *projectService) RemoveProjectMembers(ctx context.Context, req *models.R
 		failureCount int
 	)
 	for _, userID := range req.UserIDs {
-		if err := db.Instance().Delete(&entities.ProjectMember{}, "project_id = ? AND user_id = ?", req.ProjectID, userID).Error; err != nil {
-			log.Printf("failed to remove project member %s from project %s: %v", userID, req.ProjectID, err)
+		if err := db.WithContext(ctx).Delete(&entities.ProjectMember{}, "project_id = ? AND user_id = ?", req.ProjectID, userID).Error; err != nil {
+			logging.Errorf(ctx, "failed to remove project member %s from project %s: %v", userID, req.ProjectID, err)
 			if db.IsErrRecordNotFound(err) {
 				continue // Ignore if member not found
 			}
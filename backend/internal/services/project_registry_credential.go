@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ketches/ketches/internal/api"
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/core"
+	"github.com/ketches/ketches/internal/db"
+	"github.com/ketches/ketches/internal/db/entities"
+	"github.com/ketches/ketches/internal/db/orm"
+	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
+	"github.com/ketches/ketches/internal/models"
+	"github.com/ketches/ketches/pkg/registry"
+	"github.com/ketches/ketches/pkg/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ProjectRegistryCredentialService interface {
+	ListProjectRegistryCredentials(ctx context.Context, req *models.ListProjectRegistryCredentialsRequest) ([]*models.ProjectRegistryCredentialModel, app.Error)
+	CreateProjectRegistryCredential(ctx context.Context, req *models.CreateProjectRegistryCredentialRequest) (*models.ProjectRegistryCredentialModel, app.Error)
+	UpdateProjectRegistryCredential(ctx context.Context, req *models.UpdateProjectRegistryCredentialRequest) (*models.ProjectRegistryCredentialModel, app.Error)
+	DeleteProjectRegistryCredential(ctx context.Context, req *models.DeleteProjectRegistryCredentialRequest) app.Error
+	TestProjectRegistryCredential(ctx context.Context, req *models.TestProjectRegistryCredentialRequest) (*models.TestProjectRegistryCredentialResponse, app.Error)
+}
+
+type projectRegistryCredentialService struct {
+	Service
+}
+
+var projectRegistryCredentialServiceInstance = &projectRegistryCredentialService{
+	Service: LoadService(),
+}
+
+func NewProjectRegistryCredentialService() ProjectRegistryCredentialService {
+	return projectRegistryCredentialServiceInstance
+}
+
+// registryPingTimeout bounds the registry auth handshake of a credential test.
+const registryPingTimeout = 10 * time.Second
+
+func (s *projectRegistryCredentialService) ListProjectRegistryCredentials(ctx context.Context, req *models.ListProjectRegistryCredentialsRequest) ([]*models.ProjectRegistryCredentialModel, app.Error) {
+	credentials, err := orm.AllProjectRegistryCredentials(ctx, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.ProjectRegistryCredentialModel, 0, len(credentials))
+	for _, credential := range credentials {
+		result = append(result, projectRegistryCredentialModelFromEntity(credential))
+	}
+
+	return result, nil
+}
+
+func (s *projectRegistryCredentialService) CreateProjectRegistryCredential(ctx context.Context, req *models.CreateProjectRegistryCredentialRequest) (*models.ProjectRegistryCredentialModel, app.Error) {
+	host := registry.NormalizeHost(req.Host)
+	if host == "" {
+		return nil, app.NewError(http.StatusBadRequest, "Invalid registry host")
+	}
+
+	entity := &entities.ProjectRegistryCredential{
+		UUIDBase:  entities.UUIDBase{ID: uuid.New()},
+		ProjectID: req.ProjectID,
+		Host:      host,
+		Username:  req.Username,
+		Token:     req.Token,
+		AuditBase: entities.AuditBase{
+			CreatedBy: api.UserID(ctx),
+			UpdatedBy: api.UserID(ctx),
+		},
+	}
+	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to create registry credential for project %s: %v", req.ProjectID, err)
+		if db.IsErrDuplicatedKey(err) {
+			return nil, app.NewError(http.StatusConflict, "Registry credential for this host already exists in the project")
+		}
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if err := syncProjectRegistryCredential(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	return projectRegistryCredentialModelFromEntity(entity), nil
+}
+
+func (s *projectRegistryCredentialService) UpdateProjectRegistryCredential(ctx context.Context, req *models.UpdateProjectRegistryCredentialRequest) (*models.ProjectRegistryCredentialModel, app.Error) {
+	entity, err := orm.GetProjectRegistryCredential(ctx, req.ProjectID, req.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.Username = req.Username
+	if req.Token != "" {
+		entity.Token = req.Token
+	}
+	entity.UpdatedBy = api.UserID(ctx)
+	if err := db.WithContext(ctx).Select("Username", "Token", "UpdatedBy").Updates(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to update registry credential %s: %v", req.CredentialID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+
+	if err := syncProjectRegistryCredential(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	return projectRegistryCredentialModelFromEntity(entity), nil
+}
+
+func (s *projectRegistryCredentialService) DeleteProjectRegistryCredential(ctx context.Context, req *models.DeleteProjectRegistryCredentialRequest) app.Error {
+	entity, err := orm.GetProjectRegistryCredential(ctx, req.ProjectID, req.CredentialID)
+	if err != nil {
+		return err
+	}
+
+	envs, err := projectEnvs(ctx, req.ProjectID)
+	if err != nil {
+		return err
+	}
+	for _, env := range envs {
+		kcli, err := kube.ClusterRuntimeClient(ctx, env.ClusterID)
+		if err != nil {
+			return err
+		}
+		if err := kube.DeleteResource(ctx, kcli, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      core.RegistryCredentialSecretName(entity.ID),
+				Namespace: env.ClusterNamespace,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := db.WithContext(ctx).Delete(entity).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete registry credential %s: %v", req.CredentialID, err)
+		return app.ErrDatabaseOperationFailed
+	}
+
+	return nil
+}
+
+func (s *projectRegistryCredentialService) TestProjectRegistryCredential(ctx context.Context, req *models.TestProjectRegistryCredentialRequest) (*models.TestProjectRegistryCredentialResponse, app.Error) {
+	host, username, token := req.Host, req.Username, req.Token
+	if req.CredentialID != "" {
+		entity, err := orm.GetProjectRegistryCredential(ctx, req.ProjectID, req.CredentialID)
+		if err != nil {
+			return nil, err
+		}
+		host, username, token = entity.Host, entity.Username, entity.Token
+	}
+	if host == "" {
+		return nil, app.NewError(http.StatusBadRequest, "Registry host is required")
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, registryPingTimeout)
+	defer cancel()
+
+	client := registry.NewClient(registryPingTimeout, app.RegistryTestAllowPrivateNetworks())
+	if err := registry.Ping(pingCtx, client, host, username, token); err != nil {
+		logging.Errorf(ctx, "registry credential test against %s failed: %v", host, err)
+		// Errors carry the addresses and responses of the registry, they are
+		// not passed on to the caller
+		message := "Failed to reach the registry, check the registry host"
+		switch {
+		case errors.Is(err, registry.ErrUnauthorized):
+			message = "Registry rejected the username or token"
+		case errors.Is(err, registry.ErrForbiddenAddress):
+			message = "Registry host resolves to a private network address, which is not allowed"
+		}
+		return &models.TestProjectRegistryCredentialResponse{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
+	return &models.TestProjectRegistryCredentialResponse{Success: true}, nil
+}
+
+// syncProjectRegistryCredential applies the pull secret of the credential
+// into every env namespace of its project.
+func syncProjectRegistryCredential(ctx context.Context, credential *entities.ProjectRegistryCredential) app.Error {
+	envs, err := projectEnvs(ctx, credential.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for _, env := range envs {
+		kcli, err := kube.ClusterRuntimeClient(ctx, env.ClusterID)
+		if err != nil {
+			return err
+		}
+		if err := core.SyncRegistryCredentialSecrets(ctx, kcli, env.ClusterNamespace, []*entities.ProjectRegistryCredential{credential}); err != nil {
+			logging.Errorf(ctx, "failed to sync registry credential %s into namespace %s: %v", credential.ID, env.ClusterNamespace, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func projectEnvs(ctx context.Context, projectID string) ([]*entities.Env, app.Error) {
+	envs := []*entities.Env{}
+	if err := db.WithContext(ctx).Find(&envs, "project_id = ?", projectID).Error; err != nil {
+		logging.Errorf(ctx, "failed to list envs of project %s: %v", projectID, err)
+		return nil, app.ErrDatabaseOperationFailed
+	}
+	return envs, nil
+}
+
+func projectRegistryCredentialModelFromEntity(entity *entities.ProjectRegistryCredential) *models.ProjectRegistryCredentialModel {
+	return &models.ProjectRegistryCredentialModel{
+		CredentialID: entity.ID,
+		ProjectID:    entity.ProjectID,
+		Host:         entity.Host,
+		Username:     entity.Username,
+		SecretName:   core.RegistryCredentialSecretName(entity.ID),
+	}
+}
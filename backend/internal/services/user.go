@@ -19,7 +19,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
 	"unicode"
@@ -29,6 +28,7 @@ import (
 	"github.com/ketches/ketches/internal/db"
 	"github.com/ketches/ketches/internal/db/entities"
 	"github.com/ketches/ketches/internal/kube"
+	"github.com/ketches/ketches/internal/logging"
 	"github.com/ketches/ketches/internal/models"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -68,7 +68,7 @@ func NewUserService() UserService {
 }
 
 func (s *userService) List(ctx context.Context, req *models.ListUsersRequest) (*models.ListUsersResponse, app.Error) {
-	query := db.Instance().Model(&entities.User{})
+	query := db.WithContext(ctx).Model(&entities.User{})
 
 	if req.Query != "" {
 		query = db.CaseInsensitiveLike(query, req.Query, "username", "fullname")
@@ -76,7 +76,7 @@ func (s *userService) List(ctx context.Context, req *models.ListUsersRequest) (*
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
-		log.Printf("failed to count users: %v", err)
+		logging.Errorf(ctx, "failed to count users: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -106,8 +106,8 @@ func (s *userService) List(ctx context.Context, req *models.ListUsersRequest) (*
 
 func (s *userService) Get(ctx context.Context, req *models.GetUserProfileRequest) (*models.UserModel, app.Error) {
 	user := new(entities.User)
-	if err := db.Instance().First(user, "id = ?", req.UserID).Error; err != nil {
-		log.Printf("user %s does not exist: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).First(user, "id = ?", req.UserID).Error; err != nil {
+		logging.Infof(ctx, "user %s does not exist: %v", req.UserID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.UserID))
 		}
@@ -144,8 +144,8 @@ func (s *userService) SignUp(ctx context.Context, req *models.UserSignUpRequest)
 		Role:     req.Role,
 	}
 
-	if err := db.Instance().Create(user).Error; err != nil {
-		log.Printf("failed to sign up user: %v\n", err)
+	if err := db.WithContext(ctx).Create(user).Error; err != nil {
+		logging.Errorf(ctx, "failed to sign up user: %v", err)
 		if db.IsErrDuplicatedKey(err) {
 			return nil, app.NewError(http.StatusConflict, "username or email already exists")
 		}
@@ -153,8 +153,9 @@ func (s *userService) SignUp(ctx context.Context, req *models.UserSignUpRequest)
 	}
 
 	// Create a default project for signed-up user
+	projectCtx := context.WithoutCancel(ctx)
 	go func() {
-		NewProjectService().CreateProject(ctx, &models.CreateProjectRequest{
+		NewProjectService().CreateProject(projectCtx, &models.CreateProjectRequest{
 			Slug:        fmt.Sprintf("%s-%s", user.Username, user.ID[0:5]),
 			DisplayName: fmt.Sprintf("%s's Personal Project", user.Fullname),
 			Description: fmt.Sprintf("%s's personal project, automatically created upon user sign up", user.Fullname),
@@ -174,18 +175,18 @@ func (s *userService) SignUp(ctx context.Context, req *models.UserSignUpRequest)
 
 func (s *userService) SignIn(ctx context.Context, req *models.UserSignInRequest) (*models.UserModel, app.Error) {
 	user := new(entities.User)
-	if err := db.Instance().First(user, "username = ?", req.Username).Error; err != nil {
+	if err := db.WithContext(ctx).First(user, "username = ?", req.Username).Error; err != nil {
 		if db.IsErrRecordNotFound(err) {
-			if err := db.Instance().First(user, "email = ?", req.Username).Error; err != nil {
+			if err := db.WithContext(ctx).First(user, "email = ?", req.Username).Error; err != nil {
 				if db.IsErrRecordNotFound(err) {
 					return nil, app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.Username))
 				} else {
-					log.Printf("failed to get user by email %s: %v\n", req.Username, err)
+					logging.Errorf(ctx, "failed to get user by email %s: %v", req.Username, err)
 					return nil, app.ErrDatabaseOperationFailed
 				}
 			}
 		} else {
-			log.Printf("failed to get user by username %s: %v\n", req.Username, err)
+			logging.Errorf(ctx, "failed to get user by username %s: %v", req.Username, err)
 			return nil, app.ErrDatabaseOperationFailed
 		}
 	}
@@ -202,7 +203,7 @@ func (s *userService) SignIn(ctx context.Context, req *models.UserSignInRequest)
 		TokenType: app.TokenTypeAccessToken,
 	})
 	if err != nil {
-		log.Printf("failed to generate user %s access token: %v", user.ID, err)
+		logging.Errorf(ctx, "failed to generate user %s access token: %v", user.ID, err)
 		return nil, app.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to generate user %s access token", user.ID))
 	}
 
@@ -215,11 +216,11 @@ func (s *userService) SignIn(ctx context.Context, req *models.UserSignInRequest)
 		TokenType: app.TokenTypeRefreshToken,
 	})
 	if err != nil {
-		log.Printf("failed to generate user %s refresh token: %v", user.ID, err)
+		logging.Errorf(ctx, "failed to generate user %s refresh token: %v", user.ID, err)
 		return nil, app.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to generate user %s refresh token", user.ID))
 	}
 
-	if err := db.Instance().Create(&entities.UserToken{
+	if err := db.WithContext(ctx).Create(&entities.UserToken{
 		UserID:    user.ID,
 		Token:     refreshToken,
 		TokenType: string(app.TokenTypeRefreshToken),
@@ -228,7 +229,7 @@ func (s *userService) SignIn(ctx context.Context, req *models.UserSignInRequest)
 			CreatedBy: user.ID,
 		},
 	}).Error; err != nil {
-		log.Printf("failed to create user %s refresh token: %v", user.ID, err)
+		logging.Errorf(ctx, "failed to create user %s refresh token: %v", user.ID, err)
 		return nil, app.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to create user %s refresh token", user.ID))
 	}
 
@@ -253,12 +254,12 @@ func (s *userService) SignOut(ctx context.Context, req *models.UserSignOutReques
 		return app.NewError(http.StatusBadRequest, "refresh token is required")
 	}
 
-	if err := db.Instance().Delete(&entities.UserToken{}, "user_id = ? AND token = ? AND token_type = ?", req.UserID, refreshToken, app.TokenTypeRefreshToken).Error; err != nil {
-		log.Printf("failed to delete user %s refresh token: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).Delete(&entities.UserToken{}, "user_id = ? AND token = ? AND token_type = ?", req.UserID, refreshToken, app.TokenTypeRefreshToken).Error; err != nil {
+		logging.Errorf(ctx, "failed to delete user %s refresh token: %v", req.UserID, err)
 		return app.ErrDatabaseOperationFailed
 	}
 
-	log.Printf("user %s signed out successfully", req.UserID)
+	logging.Infof(ctx, "user %s signed out successfully", req.UserID)
 	return nil
 }
 
@@ -272,8 +273,8 @@ func (s *userService) Update(ctx context.Context, req *models.UserUpdateRequest)
 	}
 
 	user := new(entities.User)
-	if err := db.Instance().First(user, "id = ?", req.UserID).Error; err != nil {
-		log.Printf("failed to get user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).First(user, "id = ?", req.UserID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get user %s: %v", req.UserID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.UserID))
 		}
@@ -285,8 +286,8 @@ func (s *userService) Update(ctx context.Context, req *models.UserUpdateRequest)
 	user.Phone = req.Phone
 	user.Gender = req.Gender
 
-	if err := db.Instance().Save(user).Error; err != nil {
-		log.Printf("failed to update user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).Save(user).Error; err != nil {
+		logging.Errorf(ctx, "failed to update user %s: %v", req.UserID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -315,8 +316,8 @@ func (s *userService) Rename(ctx context.Context, req *models.UserRenameRequest)
 	}
 
 	user := new(entities.User)
-	if err := db.Instance().First(user, "id = ?", req.UserID).Error; err != nil {
-		log.Printf("failed to get user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).First(user, "id = ?", req.UserID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get user %s: %v", req.UserID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.UserID))
 		}
@@ -328,8 +329,8 @@ func (s *userService) Rename(ctx context.Context, req *models.UserRenameRequest)
 	}
 
 	user.Username = req.NewUsername
-	if err := db.Instance().Model(user).Where("id = ?", req.UserID).Update("username", user.Username).Error; err != nil {
-		log.Printf("failed to rename user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).Model(user).Where("id = ?", req.UserID).Update("username", user.Username).Error; err != nil {
+		logging.Errorf(ctx, "failed to rename user %s: %v", req.UserID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -341,8 +342,8 @@ func (s *userService) Rename(ctx context.Context, req *models.UserRenameRequest)
 
 func (s *userService) ChangeRole(ctx context.Context, req *models.UserChangeRoleRequest) (*models.UserModel, app.Error) {
 	user := new(entities.User)
-	if err := db.Instance().First(user, "id = ?", req.UserID).Error; err != nil {
-		log.Printf("failed to get user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).First(user, "id = ?", req.UserID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get user %s: %v", req.UserID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.UserID))
 		}
@@ -350,8 +351,8 @@ func (s *userService) ChangeRole(ctx context.Context, req *models.UserChangeRole
 	}
 
 	user.Role = req.NewRole
-	if err := db.Instance().Model(user).Where("id = ?", req.UserID).Update("role", user.Role).Error; err != nil {
-		log.Printf("failed to change user %s role: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).Model(user).Where("id = ?", req.UserID).Update("role", user.Role).Error; err != nil {
+		logging.Errorf(ctx, "failed to change user %s role: %v", req.UserID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -364,8 +365,8 @@ func (s *userService) ChangeRole(ctx context.Context, req *models.UserChangeRole
 
 func (s *userService) ResetPassword(ctx context.Context, req *models.UserResetPasswordRequest) (*models.UserModel, app.Error) {
 	user := new(entities.User)
-	if err := db.Instance().First(user, "id = ?", req.UserID).Error; err != nil {
-		log.Printf("failed to get user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).First(user, "id = ?", req.UserID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get user %s: %v", req.UserID, err)
 		if db.IsErrRecordNotFound(err) {
 			return nil, app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.UserID))
 		}
@@ -374,7 +375,7 @@ func (s *userService) ResetPassword(ctx context.Context, req *models.UserResetPa
 
 	if !api.IsAdmin(ctx) {
 		if req.Password == "" {
-			log.Println("origin password is required")
+			logging.Infof(ctx, "origin password is required")
 			return nil, app.NewError(http.StatusBadRequest, "origin password is required")
 		}
 		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
@@ -387,19 +388,19 @@ func (s *userService) ResetPassword(ctx context.Context, req *models.UserResetPa
 		return nil, app.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to generate password hash: %v", err))
 	}
 
-	if err := db.Transaction(func(tx *gorm.DB) error {
+	if err := db.Transaction(ctx, func(tx *gorm.DB) error {
 		user.Password = string(newPasswordHashBytes)
 		if err := tx.Model(user).Where("id = ?", req.UserID).Update("password", user.Password).Error; err != nil {
-			log.Printf("failed to reset user %s password: %v\n", req.UserID, err)
+			logging.Errorf(ctx, "failed to reset user %s password: %v", req.UserID, err)
 			return err
 		}
 
 		if err := tx.Delete(&entities.UserToken{}, "user_id = ? AND token_type = ?", user.ID, app.TokenTypeRefreshToken).Error; err != nil {
-			log.Printf("failed to delete user %s refresh token: %v\n", user.ID, err)
+			logging.Errorf(ctx, "failed to delete user %s refresh token: %v", user.ID, err)
 			return err
 		}
 
-		log.Printf("user %s password reset successfully", user.ID)
+		logging.Infof(ctx, "user %s password reset successfully", user.ID)
 		return nil
 	}); err != nil {
 		return nil, app.ErrDatabaseOperationFailed
@@ -421,8 +422,8 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*m
 	}
 
 	userToken := &entities.UserToken{}
-	if err := db.Instance().First(userToken, "token = ? AND token_type = ?", refreshToken, app.TokenTypeRefreshToken).Error; err != nil {
-		log.Printf("failed to find refresh access token %s: %v\n", refreshToken, err)
+	if err := db.WithContext(ctx).First(userToken, "token = ? AND token_type = ?", refreshToken, app.TokenTypeRefreshToken).Error; err != nil {
+		logging.Errorf(ctx, "failed to find refresh access token %s: %v", refreshToken, err)
 		return result, app.NewError(http.StatusUnauthorized, fmt.Sprintf("invalid refresh token %s", refreshToken))
 	}
 
@@ -442,7 +443,7 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*m
 		TokenType: app.TokenTypeAccessToken,
 	})
 	if err != nil {
-		log.Printf("failed to generate access token for user %s: %v\n", mc.UserID, err)
+		logging.Errorf(ctx, "failed to generate access token for user %s: %v", mc.UserID, err)
 		return result, app.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to generate access token for user %s", mc.UserID))
 	}
 
@@ -460,8 +461,8 @@ func (s *userService) Delete(ctx context.Context, req *models.DeleteUserRequest)
 	}
 
 	user := new(entities.User)
-	if err := db.Instance().First(user, "id = ?", req.UserID).Error; err != nil {
-		log.Printf("failed to get user %s: %v\n", req.UserID, err)
+	if err := db.WithContext(ctx).First(user, "id = ?", req.UserID).Error; err != nil {
+		logging.Errorf(ctx, "failed to get user %s: %v", req.UserID, err)
 		if db.IsErrRecordNotFound(err) {
 			return app.NewError(http.StatusNotFound, fmt.Sprintf("user %s does not exist", req.UserID))
 		}
@@ -474,14 +475,14 @@ func (s *userService) Delete(ctx context.Context, req *models.DeleteUserRequest)
 		}
 	}
 
-	if err := db.Transaction(func(tx *gorm.DB) error {
+	if err := db.Transaction(ctx, func(tx *gorm.DB) error {
 		if err := tx.Delete(&entities.UserToken{}, "user_id = ?", user.ID).Error; err != nil {
-			log.Printf("failed to delete user %s tokens: %v\n", user.ID, err)
+			logging.Errorf(ctx, "failed to delete user %s tokens: %v", user.ID, err)
 			return err
 		}
 
 		if err := tx.Delete(&entities.User{}, "id = ?", user.ID).Error; err != nil {
-			log.Printf("failed to delete user %s: %v\n", user.ID, err)
+			logging.Errorf(ctx, "failed to delete user %s: %v", user.ID, err)
 			return err
 		}
 
@@ -496,8 +497,8 @@ func (s *userService) Delete(ctx context.Context, req *models.DeleteUserRequest)
 func (s *userService) GetAdminResources(ctx context.Context) (*models.GetAdminResourcesResponse, app.Error) {
 
 	clusterRefs := []*models.ClusterRef{}
-	if err := db.Instance().Model(&entities.Cluster{}).Find(&clusterRefs).Error; err != nil {
-		log.Printf("failed to list cluster refs: %v", err)
+	if err := db.WithContext(ctx).Model(&entities.Cluster{}).Find(&clusterRefs).Error; err != nil {
+		logging.Errorf(ctx, "failed to list cluster refs: %v", err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
@@ -542,7 +543,7 @@ func (s *userService) GetUserResources(ctx context.Context) (*models.GetUserReso
 	var envs []*models.EnvRef
 	var apps []*models.AppRef
 
-	projectQuery := db.Instance().Model(&entities.Project{})
+	projectQuery := db.WithContext(ctx).Model(&entities.Project{})
 	if !isAdmin {
 		projectQuery = projectQuery.
 			Select("projects.id, projects.slug, projects.display_name").
@@ -550,11 +551,11 @@ func (s *userService) GetUserResources(ctx context.Context) (*models.GetUserReso
 			Where("project_members.user_id = ?", userID).Find(&projects)
 	}
 	if err := projectQuery.Find(&projects).Error; err != nil {
-		log.Printf("failed to list projects for user %s: %v", userID, err)
+		logging.Errorf(ctx, "failed to list projects for user %s: %v", userID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
-	envQuery := db.Instance().Model(&entities.Env{})
+	envQuery := db.WithContext(ctx).Model(&entities.Env{})
 	if !isAdmin {
 		envQuery = envQuery.
 			Select("envs.id, envs.slug, envs.display_name, envs.project_id").
@@ -563,11 +564,11 @@ func (s *userService) GetUserResources(ctx context.Context) (*models.GetUserReso
 			Where("project_members.user_id = ?", userID).Find(&envs)
 	}
 	if err := envQuery.Find(&envs).Error; err != nil {
-		log.Printf("failed to list environments for user %s: %v", userID, err)
+		logging.Errorf(ctx, "failed to list environments for user %s: %v", userID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
-	appQuery := db.Instance().Model(&entities.App{})
+	appQuery := db.WithContext(ctx).Model(&entities.App{})
 	if !isAdmin {
 		appQuery = appQuery.
 			Select("apps.id, apps.slug, apps.display_name, apps.env_id, apps.project_id").
@@ -575,7 +576,7 @@ func (s *userService) GetUserResources(ctx context.Context) (*models.GetUserReso
 			Where("project_members.user_id = ?", userID).Find(&apps)
 	}
 	if err := appQuery.Find(&apps).Error; err != nil {
-		log.Printf("failed to list apps for user %s: %v", userID, err)
+		logging.Errorf(ctx, "failed to list apps for user %s: %v", userID, err)
 		return nil, app.ErrDatabaseOperationFailed
 	}
 
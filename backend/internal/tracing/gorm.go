@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormParentKey = "tracing:parent"
+
+// GormPlugin traces the statements of a gorm DB as children of the span in
+// the context of the statement, see db.WithContext.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			if tx.Statement.Context == nil {
+				return
+			}
+			tx.InstanceSet(gormParentKey, tx.Statement.Context)
+			ctx, _ := Start(tx.Statement.Context, "db."+operation,
+				attribute.String("db.system", tx.Dialector.Name()),
+				attribute.String("db.operation", operation),
+			)
+			tx.Statement.Context = ctx
+		}
+	}
+	after := func(tx *gorm.DB) {
+		parent, ok := tx.InstanceGet(gormParentKey)
+		if !ok {
+			return
+		}
+		span := trace.SpanFromContext(tx.Statement.Context)
+		// Later statements of the same session are siblings, not children.
+		tx.Statement.Context = parent.(context.Context)
+		if !span.IsRecording() {
+			span.End()
+			return
+		}
+		span.SetAttributes(
+			attribute.String("db.sql.table", tx.Statement.Table),
+			attribute.String("db.statement", tx.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+		)
+		End(span, tx.Error)
+	}
+
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", after),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", after),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", after),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", after),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
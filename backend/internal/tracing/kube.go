@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/transport"
+)
+
+// KubeTransport wraps the transport of the clients of a cluster, client-go
+// and controller-runtime alike, to trace the requests made within a traced
+// request. Informer list and watch requests are not traced.
+func KubeTransport(clusterID string) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &kubeRoundTripper{clusterID: clusterID, next: rt}
+	}
+}
+
+type kubeRoundTripper struct {
+	clusterID string
+	next      http.RoundTripper
+}
+
+func (rt *kubeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return rt.next.RoundTrip(req)
+	}
+
+	target := parseKubePath(req.URL.Path)
+	verb := kubeVerb(req.Method, target.name != "")
+	if req.URL.Query().Get("watch") == "true" {
+		verb = "watch"
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("k8s.cluster.id", rt.clusterID),
+		attribute.String("k8s.verb", verb),
+		attribute.String("k8s.resource", target.resource),
+	}
+	if target.namespace != "" {
+		attrs = append(attrs, attribute.String("k8s.namespace", target.namespace))
+	}
+	if target.name != "" {
+		attrs = append(attrs, attribute.String("k8s.name", target.name))
+	}
+	if target.subresource != "" {
+		attrs = append(attrs, attribute.String("k8s.subresource", target.subresource))
+	}
+
+	ctx, span := Start(req.Context(), "k8s."+verb+" "+target.resource, attrs...)
+	resp, err := rt.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		End(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	span.End()
+	return resp, nil
+}
+
+type kubeTarget struct {
+	namespace   string
+	resource    string
+	name        string
+	subresource string
+}
+
+// parseKubePath parses API paths such as /api/v1/namespaces/default/pods/web-0/log
+// or /apis/apps/v1/deployments.
+func parseKubePath(path string) kubeTarget {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return kubeTarget{resource: path}
+	}
+
+	var target kubeTarget
+	// A namespace itself is the resource namespaces/<name>.
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		target.namespace = parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) > 0 {
+		target.resource = parts[0]
+	}
+	if len(parts) > 1 {
+		target.name = parts[1]
+	}
+	if len(parts) > 2 {
+		target.subresource = parts[2]
+	}
+	return target
+}
+
+func kubeVerb(method string, named bool) string {
+	switch method {
+	case http.MethodGet:
+		if named {
+			return "get"
+		}
+		return "list"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		if named {
+			return "delete"
+		}
+		return "deletecollection"
+	default:
+		return strings.ToLower(method)
+	}
+}
@@ -0,0 +1,75 @@
+// Package tracing sets up OpenTelemetry tracing of the API server. Tracing is
+// disabled unless an OTLP endpoint is configured, spans are then created but
+// not recorded.
+package tracing
+
+import (
+	"context"
+
+	"github.com/ketches/ketches/internal/app"
+	"github.com/ketches/ketches/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the service spans of the API server are reported as.
+const ServiceName = "ketches-api"
+
+const instrumentationName = "github.com/ketches/ketches"
+
+// Setup installs the global tracer provider exporting spans over OTLP/HTTP
+// to APP_TRACING_ENDPOINT, sampling APP_TRACING_SAMPLE_RATIO of the traces.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	endpoint := app.GetEnv("APP_TRACING_ENDPOINT", "")
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := app.GetFloat64Env("APP_TRACING_SAMPLE_RATIO", 1)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	logging.Infof(ctx, "tracing is exported to %s, sampling %.2f of traces", endpoint, ratio)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the service layer as a child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EndApp records the app error on the span and ends it.
+func EndApp(span trace.Span, err app.Error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Message())
+	}
+	span.End()
+}
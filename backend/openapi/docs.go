@@ -292,7 +292,7 @@ const docTemplate = `{
         },
         "/api/v1/apps/{appID}/action": {
             "post": {
-                "description": "Perform an action on an app (e.g., deploy, restart)",
+                "description": "Perform an action on an app (e.g., deploy, restart), or preview its changes with dryRun",
                 "consumes": [
                     "application/json"
                 ],
@@ -311,6 +311,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the changes against live cluster state without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
                     {
                         "description": "Action to perform on the app",
                         "name": "action",
@@ -593,9 +599,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/env-vars": {
+        "/api/v1/apps/{appID}/containers": {
             "get": {
-                "description": "List environment variables for an app",
+                "description": "List sidecar and init containers for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,9 +609,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppEnvVar"
+                    "AppContainer"
                 ],
-                "summary": "List App Env Vars",
+                "summary": "List App Containers",
                 "parameters": [
                     {
                         "type": "string",
@@ -629,7 +635,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.AppEnvVarModel"
+                                                "$ref": "#/definitions/models.AppContainerModel"
                                             }
                                         }
                                     }
@@ -640,7 +646,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new environment variable for an app",
+                "description": "Create a sidecar or init container for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -648,9 +654,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppEnvVar"
+                    "AppContainer"
                 ],
-                "summary": "Create App Env Var",
+                "summary": "Create App Container",
                 "parameters": [
                     {
                         "type": "string",
@@ -660,12 +666,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Env Var",
-                        "name": "envVar",
+                        "description": "Container",
+                        "name": "container",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AppEnvVarModel"
+                            "$ref": "#/definitions/models.CreateAppContainerRequest"
                         }
                     }
                 ],
@@ -681,7 +687,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppEnvVarModel"
+                                            "$ref": "#/definitions/models.AppContainerModel"
                                         }
                                     }
                                 }
@@ -691,9 +697,106 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/gateways": {
+        "/api/v1/apps/{appID}/containers/{containerID}": {
+            "put": {
+                "description": "Update a sidecar or init container for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppContainer"
+                ],
+                "summary": "Update App Container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "containerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Container",
+                        "name": "container",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAppContainerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppContainerModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a sidecar or init container for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppContainer"
+                ],
+                "summary": "Delete App Container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "containerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/dependencies": {
             "get": {
-                "description": "List gateways for an app",
+                "description": "List the apps an app depends on, with the env vars injected for them",
                 "consumes": [
                     "application/json"
                 ],
@@ -701,9 +804,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppGateway"
+                    "AppDependency"
                 ],
-                "summary": "List App Gateways",
+                "summary": "List App Dependencies",
                 "parameters": [
                     {
                         "type": "string",
@@ -727,7 +830,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.AppGatewayModel"
+                                                "$ref": "#/definitions/models.AppDependencyModel"
                                             }
                                         }
                                     }
@@ -738,7 +841,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new gateway for an app",
+                "description": "Make an app depend on another app of the project, its service address is injected into the app as env vars",
                 "consumes": [
                     "application/json"
                 ],
@@ -746,9 +849,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppGateway"
+                    "AppDependency"
                 ],
-                "summary": "Create App Gateway",
+                "summary": "Create App Dependency",
                 "parameters": [
                     {
                         "type": "string",
@@ -758,12 +861,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Gateway",
-                        "name": "gateway",
+                        "description": "Dependency",
+                        "name": "dependency",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateAppGatewayRequest"
+                            "$ref": "#/definitions/models.CreateAppDependencyRequest"
                         }
                     }
                 ],
@@ -779,7 +882,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppGatewayModel"
+                                            "$ref": "#/definitions/models.AppDependencyModel"
                                         }
                                     }
                                 }
@@ -787,9 +890,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/apps/{appID}/dependencies/{dependencyID}": {
             "delete": {
-                "description": "Delete a gateway for an app",
+                "description": "Delete a dependency of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -797,9 +902,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppGateway"
+                    "AppDependency"
                 ],
-                "summary": "Delete App Gateway",
+                "summary": "Delete App Dependency",
                 "parameters": [
                     {
                         "type": "string",
@@ -809,13 +914,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Gateway IDs",
-                        "name": "gatewayIDs",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.DeleteAppGatewaysRequest"
-                        }
+                        "type": "string",
+                        "description": "Dependency ID",
+                        "name": "dependencyID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -828,9 +931,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/gateways/{gatewayID}": {
-            "put": {
-                "description": "Update a gateway for an app",
+        "/api/v1/apps/{appID}/env-vars": {
+            "get": {
+                "description": "List environment variables for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -838,9 +941,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppGateway"
+                    "AppEnvVar"
                 ],
-                "summary": "Update App Gateway",
+                "summary": "List App Env Vars",
                 "parameters": [
                     {
                         "type": "string",
@@ -848,22 +951,6 @@ const docTemplate = `{
                         "name": "appID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gateway ID",
-                        "name": "gatewayID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Gateway",
-                        "name": "gateway",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateAppGatewayRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -878,7 +965,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppGatewayModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppEnvVarModel"
+                                            }
                                         }
                                     }
                                 }
@@ -886,11 +976,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/apps/{appID}/gateways/{gatewayID}/toggle": {
-            "patch": {
-                "description": "Toggle exposed status of a gateway for an app",
+            },
+            "post": {
+                "description": "Create a new environment variable for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -898,9 +986,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppGateway"
+                    "AppEnvVar"
                 ],
-                "summary": "Toggle App Gateway Exposed",
+                "summary": "Create App Env Var",
                 "parameters": [
                     {
                         "type": "string",
@@ -910,35 +998,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Gateway ID",
-                        "name": "gatewayID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Exposed Status",
-                        "name": "exposed",
+                        "description": "Env Var",
+                        "name": "envVar",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ToggleAppGatewayExposedRequest"
+                            "$ref": "#/definitions/models.AppEnvVarModel"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppEnvVarModel"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/apps/{appID}/image": {
-            "put": {
-                "description": "Update the image of an app",
+        "/api/v1/apps/{appID}/events": {
+            "get": {
+                "description": "List the Kubernetes events of an app's workload, ReplicaSets, pods, PVCs and routes, the latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -948,7 +1041,7 @@ const docTemplate = `{
                 "tags": [
                     "App"
                 ],
-                "summary": "Update App Image",
+                "summary": "List App Events",
                 "parameters": [
                     {
                         "type": "string",
@@ -958,13 +1051,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "New app image",
-                        "name": "image",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateAppImageRequest"
-                        }
+                        "type": "string",
+                        "name": "appID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events of this instance",
+                        "name": "instanceName",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Normal",
+                            "Warning"
+                        ],
+                        "type": "string",
+                        "description": "Only events of this type",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -979,7 +1084,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppEventModel"
+                                            }
                                         }
                                     }
                                 }
@@ -989,9 +1097,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/instances": {
+        "/api/v1/apps/{appID}/gateways": {
             "get": {
-                "description": "List instances of an app",
+                "description": "List gateways for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -999,9 +1107,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppGateway"
                 ],
-                "summary": "List App Instances",
+                "summary": "List App Gateways",
                 "parameters": [
                     {
                         "type": "string",
@@ -1025,7 +1133,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.AppInstanceModel"
+                                                "$ref": "#/definitions/models.AppGatewayModel"
                                             }
                                         }
                                     }
@@ -1034,11 +1142,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/apps/{appID}/instances/terminate": {
+            },
             "post": {
-                "description": "Terminate a specific instance of an app",
+                "description": "Create a new gateway for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1046,9 +1152,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppGateway"
                 ],
-                "summary": "Terminate App Instance",
+                "summary": "Create App Gateway",
                 "parameters": [
                     {
                         "type": "string",
@@ -1058,28 +1164,38 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Terminate app instance request",
-                        "name": "request",
+                        "description": "Gateway",
+                        "name": "gateway",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TerminateAppInstanceRequest"
+                            "$ref": "#/definitions/models.CreateAppGatewayRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppGatewayModel"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/exec": {
-            "get": {
-                "description": "Exec into a terminal of a specific container in an app instance",
+            },
+            "delete": {
+                "description": "Delete a gateway for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1087,9 +1203,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppGateway"
                 ],
-                "summary": "Exec App Container Terminal",
+                "summary": "Delete App Gateway",
                 "parameters": [
                     {
                         "type": "string",
@@ -1098,27 +1214,61 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Gateway IDs",
+                        "name": "gatewayIDs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteAppGatewaysRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/gateways/{gatewayID}": {
+            "put": {
+                "description": "Update a gateway for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppGateway"
+                ],
+                "summary": "Update App Gateway",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Instance name",
-                        "name": "instanceName",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Container name",
-                        "name": "containerName",
+                        "description": "Gateway ID",
+                        "name": "gatewayID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Request to execute command in container",
-                        "name": "request",
+                        "description": "Gateway",
+                        "name": "gateway",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ExecAppContainerTerminalRequest"
+                            "$ref": "#/definitions/models.UpdateAppGatewayRequest"
                         }
                     }
                 ],
@@ -1126,15 +1276,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppGatewayModel"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/logs": {
-            "get": {
-                "description": "View logs of a specific container in an app instance",
+        "/api/v1/apps/{appID}/gateways/{gatewayID}/toggle": {
+            "patch": {
+                "description": "Toggle exposed status of a gateway for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1142,9 +1304,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppGateway"
                 ],
-                "summary": "View App Container Logs",
+                "summary": "Toggle App Gateway Exposed",
                 "parameters": [
                     {
                         "type": "string",
@@ -1155,80 +1317,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Instance name",
-                        "name": "instanceName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Container name",
-                        "name": "containerName",
+                        "description": "Gateway ID",
+                        "name": "gatewayID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "name": "appID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Optional container name to filter logs",
-                        "name": "containerName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Whether to stream logs",
-                        "name": "follow",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "instanceName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of log lines to return",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Whether to fetch logs from the previous instance of the container",
-                        "name": "previous",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Whether to include timestamps in logs",
-                        "name": "showTimestamps",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Fetch logs since this many seconds ago",
-                        "name": "sinceSeconds",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Fetch logs since this time (RFC3339 format)",
-                        "name": "sinceTime",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of log lines to return from the end",
-                        "name": "tailLines",
-                        "in": "query"
+                        "description": "Exposed Status",
+                        "name": "exposed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ToggleAppGatewayExposedRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "$ref": "#/definitions/api.Response"
                         }
@@ -1236,9 +1342,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/probes": {
-            "get": {
-                "description": "List probes for an app",
+        "/api/v1/apps/{appID}/image": {
+            "put": {
+                "description": "Update the image of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1246,9 +1352,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppProbe"
+                    "App"
                 ],
-                "summary": "List App Probes",
+                "summary": "Update App Image",
                 "parameters": [
                     {
                         "type": "string",
@@ -1256,6 +1362,15 @@ const docTemplate = `{
                         "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New app image",
+                        "name": "image",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAppImageRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1270,10 +1385,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.AppProbeModel"
-                                            }
+                                            "$ref": "#/definitions/models.AppModel"
                                         }
                                     }
                                 }
@@ -1281,9 +1393,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new probe for an app",
+            }
+        },
+        "/api/v1/apps/{appID}/instances": {
+            "get": {
+                "description": "List instances of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1291,9 +1405,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppProbe"
+                    "App"
                 ],
-                "summary": "Create App Probe",
+                "summary": "List App Instances",
                 "parameters": [
                     {
                         "type": "string",
@@ -1301,20 +1415,11 @@ const docTemplate = `{
                         "name": "appID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Probe",
-                        "name": "probe",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateAppProbeRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1324,7 +1429,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppProbeModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppInstanceModel"
+                                            }
                                         }
                                     }
                                 }
@@ -1334,9 +1442,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/probes/{probeID}": {
-            "put": {
-                "description": "Update a probe for an app",
+        "/api/v1/apps/{appID}/instances/terminate": {
+            "post": {
+                "description": "Terminate a specific instance of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1344,9 +1452,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppProbe"
+                    "App"
                 ],
-                "summary": "Update App Probe",
+                "summary": "Terminate App Instance",
                 "parameters": [
                     {
                         "type": "string",
@@ -1356,45 +1464,28 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Probe ID",
-                        "name": "probeID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Probe",
-                        "name": "probe",
+                        "description": "Terminate app instance request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateAppProbeRequest"
+                            "$ref": "#/definitions/models.TerminateAppInstanceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.AppProbeModel"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a probe for an app",
+            }
+        },
+        "/api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/exec": {
+            "get": {
+                "description": "Exec into a terminal of a specific container in an app instance over a websocket. Clients send JSON text frames of type \"stdin\", \"resize\" or \"ping\", output is sent back as binary frames.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,9 +1493,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppProbe"
+                    "App"
                 ],
-                "summary": "Delete App Probe",
+                "summary": "Exec App Container Terminal",
                 "parameters": [
                     {
                         "type": "string",
@@ -1415,15 +1506,55 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Probe ID",
-                        "name": "probeID",
+                        "description": "Instance name",
+                        "name": "instanceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container name",
+                        "name": "containerName",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "appID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Initial terminal width",
+                        "name": "cols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shell or command to run, e.g. \"bash\", defaults to the first shell found",
+                        "name": "command",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "instanceName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Initial terminal height",
+                        "name": "rows",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/api.Response"
                         }
@@ -1431,19 +1562,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/probes/{probeID}/toggle": {
-            "put": {
-                "description": "Enable or disable a probe for an app",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/files": {
+            "get": {
+                "description": "Download a file or directory from a container of an app instance as a tar archive",
                 "produces": [
-                    "application/json"
+                    "application/x-tar"
                 ],
                 "tags": [
-                    "AppProbe"
+                    "AppContainerFile"
                 ],
-                "summary": "Toggle App Probe",
+                "summary": "Download App Container Files",
                 "parameters": [
                     {
                         "type": "string",
@@ -1454,56 +1582,47 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Probe ID",
-                        "name": "probeID",
+                        "description": "Instance name",
+                        "name": "instanceName",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Enabled",
-                        "name": "enabled",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ToggleAppProbeRequest"
-                        }
+                        "type": "string",
+                        "description": "Container name",
+                        "name": "containerName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the file or directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.AppProbeModel"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/apps/{appID}/ref": {
-            "get": {
-                "description": "Get app ref by app ID",
+            },
+            "post": {
+                "description": "Extract a tar archive into a directory of a container of an app instance",
                 "consumes": [
-                    "application/json"
+                    "application/x-tar"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppContainerFile"
                 ],
-                "summary": "Get App Ref",
+                "summary": "Upload App Container Files",
                 "parameters": [
                     {
                         "type": "string",
@@ -1511,33 +1630,42 @@ const docTemplate = `{
                         "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instance name",
+                        "name": "instanceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container name",
+                        "name": "containerName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the directory the archive is extracted to",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.AppRef"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/apps/{appID}/resource": {
-            "put": {
-                "description": "Set the resource of an app",
+        "/api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/files/list": {
+            "get": {
+                "description": "List the entries of a directory in a container of an app instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1545,9 +1673,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppContainerFile"
                 ],
-                "summary": "Set App Resource",
+                "summary": "List App Container Files",
                 "parameters": [
                     {
                         "type": "string",
@@ -1557,13 +1685,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Set app resource",
-                        "name": "resource",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SetAppResourceRequest"
-                        }
+                        "type": "string",
+                        "description": "Instance name",
+                        "name": "instanceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container name",
+                        "name": "containerName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1578,7 +1718,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppContainerFileModel"
+                                            }
                                         }
                                     }
                                 }
@@ -1588,9 +1731,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/running/info": {
+        "/api/v1/apps/{appID}/instances/{instanceName}/containers/{containerName}/logs": {
             "get": {
-                "description": "Get the running information app, including status and instances",
+                "description": "View logs of a specific container in an app instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1600,7 +1743,7 @@ const docTemplate = `{
                 "tags": [
                     "App"
                 ],
-                "summary": "Get App Running Info",
+                "summary": "View App Container Logs",
                 "parameters": [
                     {
                         "type": "string",
@@ -1609,6 +1752,20 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Instance name",
+                        "name": "instanceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container name",
+                        "name": "containerName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "name": "appID",
@@ -1637,6 +1794,12 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "integer",
+                        "description": "Maximum bytes of logs to return",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
                     {
                         "type": "boolean",
                         "description": "Whether to fetch logs from the previous instance of the container",
@@ -1678,9 +1841,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/scheduling-rule": {
-            "get": {
-                "description": "Get the scheduling rule of an app",
+        "/api/v1/apps/{appID}/instances/{instanceName}/debug": {
+            "post": {
+                "description": "Inject an ephemeral debug container into a running app instance, the workload is not restarted",
                 "consumes": [
                     "application/json"
                 ],
@@ -1690,7 +1853,7 @@ const docTemplate = `{
                 "tags": [
                     "App"
                 ],
-                "summary": "Get App Scheduling Rule",
+                "summary": "Debug App Instance",
                 "parameters": [
                     {
                         "type": "string",
@@ -1698,6 +1861,21 @@ const docTemplate = `{
                         "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instance name",
+                        "name": "instanceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Debug app instance request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.DebugAppInstanceRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1712,7 +1890,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppSchedulingRuleModel"
+                                            "$ref": "#/definitions/models.DebugAppInstanceResponse"
                                         }
                                     }
                                 }
@@ -1720,19 +1898,15 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Set the scheduling rule of an app",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/apps/{appID}/instances/{instanceName}/port-forward": {
+            "get": {
+                "description": "Tunnel a websocket to a port of an app instance, binary frames carry the TCP stream of one connection",
                 "tags": [
                     "App"
                 ],
-                "summary": "Set App Scheduling Rule",
+                "summary": "Port Forward App Instance",
                 "parameters": [
                     {
                         "type": "string",
@@ -1742,13 +1916,220 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Set app scheduling rule",
-                        "name": "schedulingRule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SetAppSchedulingRuleRequest"
-                        }
+                        "type": "string",
+                        "description": "Instance name",
+                        "name": "instanceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Container port",
+                        "name": "port",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/logs": {
+            "get": {
+                "description": "Stream the logs of all instances of an app as server-sent events, each line prefixed with its instance",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "Stream App Logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "appID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container of each instance, defaults to the main container",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only lines containing this text",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to stream logs, including instances started later",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum bytes of logs to return of each instance",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the filter is a regular expression",
+                        "name": "regex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include timestamps in logs",
+                        "name": "showTimestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fetch logs since this many seconds ago",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch logs since this time (RFC3339 format)",
+                        "name": "sinceTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of log lines to return from the end of each instance",
+                        "name": "tailLines",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/logs/download": {
+            "get": {
+                "description": "Download the logs of all instances of an app as a gzip file",
+                "produces": [
+                    "application/gzip"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "Download App Logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "appID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container of each instance, defaults to the main container",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only lines containing this text",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to stream logs, including instances started later",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum bytes of logs to return of each instance",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the filter is a regular expression",
+                        "name": "regex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include timestamps in logs",
+                        "name": "showTimestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fetch logs since this many seconds ago",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch logs since this time (RFC3339 format)",
+                        "name": "sinceTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of log lines to return from the end of each instance",
+                        "name": "tailLines",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/network-policy": {
+            "get": {
+                "description": "Get the ingress network policy of an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppNetworkPolicy"
+                ],
+                "summary": "Get App Network Policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1763,7 +2144,58 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppSchedulingRuleModel"
+                                            "$ref": "#/definitions/models.AppNetworkPolicyModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the ingress network policy of an app, only the gateway and the listed apps of the same env can reach it afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppNetworkPolicy"
+                ],
+                "summary": "Set App Network Policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Network policy",
+                        "name": "networkPolicy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetAppNetworkPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppNetworkPolicyModel"
                                         }
                                     }
                                 }
@@ -1773,7 +2205,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete the scheduling rule of an app",
+                "description": "Delete the ingress network policy of an app, the env network policy applies afterwards",
                 "consumes": [
                     "application/json"
                 ],
@@ -1781,9 +2213,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppNetworkPolicy"
                 ],
-                "summary": "Delete App Scheduling Rule",
+                "summary": "Delete App Network Policy",
                 "parameters": [
                     {
                         "type": "string",
@@ -1803,9 +2235,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/volumes": {
+        "/api/v1/apps/{appID}/ports": {
             "get": {
-                "description": "List storage volumes for an app",
+                "description": "List the ports of an app, they are exposed by its container and service",
                 "consumes": [
                     "application/json"
                 ],
@@ -1813,9 +2245,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppVolume"
+                    "AppPort"
                 ],
-                "summary": "List App Volumes",
+                "summary": "List App Ports",
                 "parameters": [
                     {
                         "type": "string",
@@ -1839,7 +2271,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.AppVolumeModel"
+                                                "$ref": "#/definitions/models.AppPortModel"
                                             }
                                         }
                                     }
@@ -1850,7 +2282,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new storage volume for an app",
+                "description": "Create a port of an app, gateways can route to it by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1858,9 +2290,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppVolume"
+                    "AppPort"
                 ],
-                "summary": "Create App Volume",
+                "summary": "Create App Port",
                 "parameters": [
                     {
                         "type": "string",
@@ -1870,12 +2302,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Volume",
-                        "name": "volume",
+                        "description": "Port",
+                        "name": "port",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateAppVolumeRequest"
+                            "$ref": "#/definitions/models.CreateAppPortRequest"
                         }
                     }
                 ],
@@ -1891,7 +2323,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppVolumeModel"
+                                            "$ref": "#/definitions/models.AppPortModel"
                                         }
                                     }
                                 }
@@ -1901,9 +2333,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/apps/{appID}/volumes/{volumeID}": {
+        "/api/v1/apps/{appID}/ports/{portID}": {
             "put": {
-                "description": "Update a storage volume for an app",
+                "description": "Update a port of an app, its name can't be changed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1911,9 +2343,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AppVolume"
+                    "AppPort"
                 ],
-                "summary": "Update App Volume",
+                "summary": "Update App Port",
                 "parameters": [
                     {
                         "type": "string",
@@ -1923,12 +2355,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Volume",
-                        "name": "volume",
+                        "type": "string",
+                        "description": "Port ID",
+                        "name": "portID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Port",
+                        "name": "port",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateAppVolumeRequest"
+                            "$ref": "#/definitions/models.UpdateAppPortRequest"
                         }
                     }
                 ],
@@ -1944,7 +2383,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppVolumeModel"
+                                            "$ref": "#/definitions/models.AppPortModel"
                                         }
                                     }
                                 }
@@ -1952,11 +2391,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/clusters": {
-            "get": {
-                "description": "List clusters",
+            },
+            "delete": {
+                "description": "Delete a port of an app, it must not be routed to by gateways",
                 "consumes": [
                     "application/json"
                 ],
@@ -1964,34 +2401,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppPort"
                 ],
-                "summary": "List Clusters",
+                "summary": "Delete App Port",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "name": "pageNo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "name": "query",
-                        "in": "query"
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
+                        "description": "Port ID",
+                        "name": "portID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/probes": {
+            "get": {
+                "description": "List probes for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppProbe"
+                ],
+                "summary": "List App Probes",
+                "parameters": [
                     {
                         "type": "string",
-                        "name": "sortOrder",
-                        "in": "query"
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2006,7 +2464,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListClustersResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppProbeModel"
+                                            }
                                         }
                                     }
                                 }
@@ -2016,7 +2477,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new cluster",
+                "description": "Create a new probe for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2024,17 +2485,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppProbe"
                 ],
-                "summary": "Create Cluster",
+                "summary": "Create App Probe",
                 "parameters": [
                     {
-                        "description": "Cluster information",
-                        "name": "request",
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Probe",
+                        "name": "probe",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateClusterRequest"
+                            "$ref": "#/definitions/models.CreateAppProbeRequest"
                         }
                     }
                 ],
@@ -2050,7 +2518,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ClusterModel"
+                                            "$ref": "#/definitions/models.AppProbeModel"
                                         }
                                     }
                                 }
@@ -2060,9 +2528,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/ping": {
-            "post": {
-                "description": "Ping a cluster's KubeConfig to check if it is connectable",
+        "/api/v1/apps/{appID}/probes/{probeID}": {
+            "put": {
+                "description": "Update a probe for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2070,17 +2538,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppProbe"
                 ],
-                "summary": "Ping Cluster KubeConfig",
+                "summary": "Update App Probe",
                 "parameters": [
                     {
-                        "description": "KubeConfig to ping",
-                        "name": "request",
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Probe ID",
+                        "name": "probeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Probe",
+                        "name": "probe",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PingClusterKubeConfigRequest"
+                            "$ref": "#/definitions/models.UpdateAppProbeRequest"
                         }
                     }
                 ],
@@ -2096,7 +2578,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "boolean"
+                                            "$ref": "#/definitions/models.AppProbeModel"
                                         }
                                     }
                                 }
@@ -2104,11 +2586,48 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Delete a probe for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppProbe"
+                ],
+                "summary": "Delete App Probe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Probe ID",
+                        "name": "probeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/clusters/refs": {
-            "get": {
-                "description": "Get all clusters for refs",
+        "/api/v1/apps/{appID}/probes/{probeID}/toggle": {
+            "put": {
+                "description": "Enable or disable a probe for an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2116,9 +2635,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppProbe"
+                ],
+                "summary": "Toggle App Probe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Probe ID",
+                        "name": "probeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enabled",
+                        "name": "enabled",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ToggleAppProbeRequest"
+                        }
+                    }
                 ],
-                "summary": "All Cluster Refs",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2131,10 +2675,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ClusterRef"
-                                            }
+                                            "$ref": "#/definitions/models.AppProbeModel"
                                         }
                                     }
                                 }
@@ -2144,9 +2685,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}": {
+        "/api/v1/apps/{appID}/ref": {
             "get": {
-                "description": "Get cluster by cluster ID",
+                "description": "Get app ref by app ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2154,14 +2695,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "App"
                 ],
-                "summary": "Get Cluster",
+                "summary": "Get App Ref",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     }
@@ -2178,7 +2719,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ClusterModel"
+                                            "$ref": "#/definitions/models.AppRef"
                                         }
                                     }
                                 }
@@ -2186,9 +2727,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing cluster",
+            }
+        },
+        "/api/v1/apps/{appID}/release": {
+            "get": {
+                "description": "Get the latest canary or blue-green release of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2196,25 +2739,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppRelease"
                 ],
-                "summary": "Update Cluster",
+                "summary": "Get App Release",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated cluster information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateClusterRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -2229,7 +2763,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ClusterModel"
+                                            "$ref": "#/definitions/models.AppReleaseModel"
                                         }
                                     }
                                 }
@@ -2237,9 +2771,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a cluster by cluster ID",
+            }
+        },
+        "/api/v1/apps/{appID}/release/action": {
+            "post": {
+                "description": "Pause, resume, promote or abort the active release of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2247,28 +2783,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppRelease"
                 ],
-                "summary": "Delete Cluster",
+                "summary": "App Release Action",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Release action",
+                        "name": "action",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AppReleaseActionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    }
-                }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/disable": {
-            "put": {
-                "description": "Disable a cluster by cluster ID",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppReleaseModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/apps/{appID}/resource": {
+            "put": {
+                "description": "Set the resource of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2276,31 +2836,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "App"
                 ],
-                "summary": "Disable Cluster",
+                "summary": "Set App Resource",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Set app resource",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetAppResourceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppModel"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/enable": {
-            "put": {
-                "description": "Enable a cluster by cluster ID",
+        "/api/v1/apps/{appID}/rollout-strategy": {
+            "get": {
+                "description": "Get the rollout strategy of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2308,14 +2889,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppRollout"
                 ],
-                "summary": "Enable Cluster",
+                "summary": "Get App Rollout Strategy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     }
@@ -2324,15 +2905,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppRolloutStrategyModel"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/extensions": {
-            "get": {
-                "description": "List extensions available in a cluster",
+            },
+            "put": {
+                "description": "Set the rollout strategy of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2340,16 +2931,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppRollout"
                 ],
-                "summary": "List Cluster Extensions",
+                "summary": "Set App Rollout Strategy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Rollout strategy",
+                        "name": "rolloutStrategy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetAppRolloutStrategyRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2364,7 +2964,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListClusterExtensionsResponse"
+                                            "$ref": "#/definitions/models.AppRolloutStrategyModel"
                                         }
                                     }
                                 }
@@ -2372,11 +2972,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/extensions/enable": {
-            "post": {
-                "description": "Enable the cluster extension feature for the specified cluster",
+            },
+            "delete": {
+                "description": "Delete the rollout strategy of an app, the Kubernetes defaults are used afterwards",
                 "consumes": [
                     "application/json"
                 ],
@@ -2384,21 +2982,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppRollout"
                 ],
-                "summary": "Enable Cluster Extension",
+                "summary": "Delete App Rollout Strategy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "$ref": "#/definitions/api.Response"
                         }
@@ -2406,9 +3004,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/extensions/feature-enabled": {
+        "/api/v1/apps/{appID}/rollout/progress": {
             "get": {
-                "description": "Check if the cluster extension feature is enabled for the specified cluster",
+                "description": "Get the rollout progress of an app, with instance counts per edition",
                 "consumes": [
                     "application/json"
                 ],
@@ -2416,14 +3014,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppRollout"
                 ],
-                "summary": "Check Cluster Extension Feature Enabled",
+                "summary": "Get App Rollout Progress",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     }
@@ -2440,7 +3038,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "boolean"
+                                            "$ref": "#/definitions/models.AppRolloutProgressModel"
                                         }
                                     }
                                 }
@@ -2450,9 +3048,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/extensions/install": {
-            "post": {
-                "description": "Install an extension in the specified cluster",
+        "/api/v1/apps/{appID}/running/info": {
+            "get": {
+                "description": "Get the running information app, including status and instances",
                 "consumes": [
                     "application/json"
                 ],
@@ -2460,64 +3058,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "App"
                 ],
-                "summary": "Install Cluster Extension",
+                "summary": "Get App Running Info",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Extension installation information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.InstallClusterExtensionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/extensions/{extensionName}": {
-            "delete": {
-                "description": "Uninstall an extension from the specified cluster",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Cluster"
-                ],
-                "summary": "Uninstall Cluster Extension",
-                "parameters": [
+                        "type": "string",
+                        "name": "appID",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
-                        "in": "path",
-                        "required": true
+                        "description": "Optional container name to filter logs",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to stream logs",
+                        "name": "follow",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Extension Name",
-                        "name": "extensionName",
-                        "in": "path",
-                        "required": true
+                        "name": "instanceName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of log lines to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum bytes of logs to return",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to fetch logs from the previous instance of the container",
+                        "name": "previous",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include timestamps in logs",
+                        "name": "showTimestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fetch logs since this many seconds ago",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch logs since this time (RFC3339 format)",
+                        "name": "sinceTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of log lines to return from the end",
+                        "name": "tailLines",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2530,9 +3144,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/extensions/{extensionName}/installed-values": {
+        "/api/v1/apps/{appID}/scheduling-rule": {
             "get": {
-                "description": "Get the current values.yaml for an installed cluster extension",
+                "description": "Get the scheduling rule of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2540,21 +3154,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "App"
                 ],
-                "summary": "Get installed extension current values",
+                "summary": "Get App Scheduling Rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Extension Name",
-                        "name": "extensionName",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     }
@@ -2571,37 +3178,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.AppSchedulingRuleModel"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
                     }
                 }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/extensions/{extensionName}/update": {
+            },
             "put": {
-                "description": "Update an installed cluster extension",
+                "description": "Set the scheduling rule of an app",
                 "consumes": [
                     "application/json"
                 ],
@@ -2609,31 +3196,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "App"
                 ],
-                "summary": "Update cluster extension",
+                "summary": "Set App Scheduling Rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Extension Name",
-                        "name": "extensionName",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Extension update information",
-                        "name": "request",
+                        "description": "Set app scheduling rule",
+                        "name": "schedulingRule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateClusterExtensionRequest"
+                            "$ref": "#/definitions/models.SetAppSchedulingRuleRequest"
                         }
                     }
                 ],
@@ -2641,23 +3221,47 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AppSchedulingRuleModel"
+                                        }
+                                    }
+                                }
+                            ]
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the scheduling rule of an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "Delete App Scheduling Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "$ref": "#/definitions/api.Response"
                         }
@@ -2665,9 +3269,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/extensions/{extensionName}/values/{version}": {
+        "/api/v1/apps/{appID}/terminal-sessions": {
             "get": {
-                "description": "Get the default values.yaml for a specific cluster extension version",
+                "description": "List the web terminal sessions opened on the instances of an app, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -2675,30 +3279,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppTerminalSession"
                 ],
-                "summary": "Get cluster extension default values",
+                "summary": "List App Terminal Sessions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Extension Name",
-                        "name": "extensionName",
-                        "in": "path",
+                        "name": "appID",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Extension Version",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "name": "pageNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2713,46 +3319,38 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.ListAppTerminalSessionsResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/nodes": {
+        "/api/v1/apps/{appID}/terminal-sessions/{sessionID}/recording": {
             "get": {
-                "description": "Get all nodes of the specified cluster",
+                "description": "Download the asciicast recording of a web terminal session for playback",
+                "produces": [
+                    "application/octet-stream"
+                ],
                 "tags": [
-                    "Cluster"
+                    "AppTerminalSession"
                 ],
-                "summary": "List nodes of a cluster",
+                "summary": "Download App Terminal Session Recording",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Terminal session ID",
+                        "name": "sessionID",
                         "in": "path",
                         "required": true
                     }
@@ -2761,39 +3359,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ClusterNodeModel"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/nodes/labels": {
+        "/api/v1/apps/{appID}/volumes": {
             "get": {
-                "description": "Get all node labels from the specified cluster",
+                "description": "List storage volumes for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Cluster"
+                    "AppVolume"
                 ],
-                "summary": "List Cluster Node Labels",
+                "summary": "List App Volumes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     }
@@ -2812,7 +3401,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "type": "string"
+                                                "$ref": "#/definitions/models.AppVolumeModel"
                                             }
                                         }
                                     }
@@ -2821,27 +3410,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/nodes/refs": {
-            "get": {
-                "description": "Get all node references of the specified cluster",
+            },
+            "post": {
+                "description": "Create a new storage volume for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Cluster"
+                    "AppVolume"
                 ],
-                "summary": "List references of cluster nodes",
+                "summary": "Create App Volume",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Volume",
+                        "name": "volume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAppVolumeRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -2851,10 +3453,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ClusterNodeRef"
-                                            }
+                                            "$ref": "#/definitions/models.AppVolumeModel"
                                         }
                                     }
                                 }
@@ -2864,21 +3463,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/nodes/taints": {
-            "get": {
-                "description": "Get all node taints from the specified cluster",
+        "/api/v1/apps/{appID}/volumes/{volumeID}": {
+            "put": {
+                "description": "Update a storage volume for an app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Cluster"
+                    "AppVolume"
                 ],
-                "summary": "List Cluster Node Taints",
+                "summary": "Update App Volume",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
-                    }
+                    },
+                    {
+                        "description": "Volume",
+                        "name": "volume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAppVolumeRequest"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -2892,10 +3506,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ClusterNodeTaintModel"
-                                            }
+                                            "$ref": "#/definitions/models.AppVolumeModel"
                                         }
                                     }
                                 }
@@ -2905,9 +3516,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/clusters/{clusterID}/nodes/{nodeName}": {
+        "/api/v1/apps/{appID}/volumes/{volumeID}/snapshot-policy": {
             "get": {
-                "description": "Get details of a specific node in the cluster",
+                "description": "Get the scheduled snapshot policy of an app volume",
                 "consumes": [
                     "application/json"
                 ],
@@ -2915,21 +3526,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppVolumeSnapshot"
                 ],
-                "summary": "Get Cluster Node",
+                "summary": "Get App Volume Snapshot Policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Node Name",
-                        "name": "nodeName",
+                        "description": "Volume ID",
+                        "name": "volumeID",
                         "in": "path",
                         "required": true
                     }
@@ -2946,7 +3557,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ClusterNodeModel"
+                                            "$ref": "#/definitions/models.AppVolumeSnapshotPolicyModel"
                                         }
                                     }
                                 }
@@ -2954,11 +3565,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/clusters/{clusterID}/ref": {
-            "get": {
-                "description": "Get cluster ref by cluster ID",
+            },
+            "put": {
+                "description": "Set the scheduled snapshot policy of an app volume",
                 "consumes": [
                     "application/json"
                 ],
@@ -2966,16 +3575,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster"
+                    "AppVolumeSnapshot"
                 ],
-                "summary": "Get Cluster Ref",
+                "summary": "Set App Volume Snapshot Policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster ID",
-                        "name": "clusterID",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Snapshot policy",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetAppVolumeSnapshotPolicyRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2990,7 +3615,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ClusterRef"
+                                            "$ref": "#/definitions/models.AppVolumeSnapshotPolicyModel"
                                         }
                                     }
                                 }
@@ -2998,11 +3623,48 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Delete the scheduled snapshot policy of an app volume, existing snapshots are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AppVolumeSnapshot"
+                ],
+                "summary": "Delete App Volume Snapshot Policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/envs/{envID}": {
+        "/api/v1/apps/{appID}/volumes/{volumeID}/snapshots": {
             "get": {
-                "description": "Get env by env ID",
+                "description": "List the snapshots of an app volume, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -3010,14 +3672,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
+                    "AppVolumeSnapshot"
                 ],
-                "summary": "Get Env",
+                "summary": "List App Volume Snapshots",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeID",
                         "in": "path",
                         "required": true
                     }
@@ -3034,7 +3703,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.EnvModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppVolumeSnapshotModel"
+                                            }
                                         }
                                     }
                                 }
@@ -3043,8 +3715,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing env",
+            "post": {
+                "description": "Take a snapshot of an app volume",
                 "consumes": [
                     "application/json"
                 ],
@@ -3052,30 +3724,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
+                    "AppVolumeSnapshot"
                 ],
-                "summary": "Update Env",
+                "summary": "Create App Volume Snapshot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Env Request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Snapshot",
+                        "name": "snapshot",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateEnvRequest"
+                            "$ref": "#/definitions/models.CreateAppVolumeSnapshotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -3085,7 +3764,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.EnvModel"
+                                            "$ref": "#/definitions/models.AppVolumeSnapshotModel"
                                         }
                                     }
                                 }
@@ -3093,9 +3772,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/apps/{appID}/volumes/{volumeID}/snapshots/{snapshotName}": {
             "delete": {
-                "description": "Delete an env by env ID",
+                "description": "Delete a snapshot of an app volume",
                 "consumes": [
                     "application/json"
                 ],
@@ -3103,14 +3784,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
+                    "AppVolumeSnapshot"
                 ],
-                "summary": "Delete Env",
+                "summary": "Delete App Volume Snapshot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot name",
+                        "name": "snapshotName",
                         "in": "path",
                         "required": true
                     }
@@ -3125,9 +3820,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/envs/{envID}/apps": {
-            "get": {
-                "description": "List apps under a specific env",
+        "/api/v1/apps/{appID}/volumes/{volumeID}/snapshots/{snapshotName}/restore": {
+            "post": {
+                "description": "Restore a snapshot into a new claim, or in place of the snapshotted claim while the app is stopped",
                 "consumes": [
                     "application/json"
                 ],
@@ -3135,22 +3830,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "AppVolumeSnapshot"
                 ],
-                "summary": "List Apps Under Env",
+                "summary": "Restore App Volume Snapshot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
+                        "description": "App ID",
+                        "name": "appID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "name": "envID",
-                        "in": "query"
+                        "description": "Volume ID",
+                        "name": "volumeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot name",
+                        "name": "snapshotName",
+                        "in": "path",
+                        "required": true
                     },
+                    {
+                        "description": "Restore options",
+                        "name": "restore",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RestoreAppVolumeSnapshotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clusters": {
+            "get": {
+                "description": "List clusters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cluster"
+                ],
+                "summary": "List Clusters",
+                "parameters": [
                     {
                         "type": "integer",
                         "name": "pageNo",
@@ -3189,7 +3927,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListAppsResponse"
+                                            "$ref": "#/definitions/models.ListClustersResponse"
                                         }
                                     }
                                 }
@@ -3199,7 +3937,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new app under a specific env",
+                "description": "Create a new cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3207,24 +3945,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "Cluster"
                 ],
-                "summary": "Create App Under Env",
+                "summary": "Create Cluster",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "App data",
-                        "name": "app",
+                        "description": "Cluster information",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateAppRequest"
+                            "$ref": "#/definitions/models.CreateClusterRequest"
                         }
                     }
                 ],
@@ -3240,7 +3971,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AppModel"
+                                            "$ref": "#/definitions/models.ClusterModel"
                                         }
                                     }
                                 }
@@ -3250,9 +3981,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/envs/{envID}/apps/refs": {
-            "get": {
-                "description": "Get all apps for refs under a specific env",
+        "/api/v1/clusters/ping": {
+            "post": {
+                "description": "Ping a cluster's KubeConfig to check if it is connectable",
                 "consumes": [
                     "application/json"
                 ],
@@ -3260,22 +3991,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "App"
+                    "Cluster"
                 ],
-                "summary": "All App Refs Under Env",
+                "summary": "Ping Cluster KubeConfig",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "envID",
-                        "in": "query",
-                        "required": true
+                        "description": "KubeConfig to ping",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PingClusterKubeConfigRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3290,10 +4017,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.AppRef"
-                                            }
+                                            "type": "boolean"
                                         }
                                     }
                                 }
@@ -3303,9 +4027,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/envs/{envID}/ref": {
+        "/api/v1/clusters/refs": {
             "get": {
-                "description": "Get env ref by env ID",
+                "description": "Get all clusters for refs",
                 "consumes": [
                     "application/json"
                 ],
@@ -3313,18 +4037,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
-                ],
-                "summary": "Get Env Ref",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Env ID",
-                        "name": "envID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Cluster"
                 ],
+                "summary": "All Cluster Refs",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3337,7 +4052,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.EnvRef"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ClusterRef"
+                                            }
                                         }
                                     }
                                 }
@@ -3347,9 +4065,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/projects": {
+        "/api/v1/clusters/{clusterID}": {
             "get": {
-                "description": "List projects",
+                "description": "Get cluster by cluster ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3357,34 +4075,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "List Projects",
+                "summary": "Get Cluster",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "name": "pageNo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "sortOrder",
-                        "in": "query"
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3399,7 +4099,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListProjectResponse"
+                                            "$ref": "#/definitions/models.ClusterModel"
                                         }
                                     }
                                 }
@@ -3408,8 +4108,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a new project",
+            "put": {
+                "description": "Update an existing cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3417,23 +4117,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Create Project",
+                "summary": "Update Cluster",
                 "parameters": [
                     {
-                        "description": "Project data",
-                        "name": "project",
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated cluster information",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateProjectRequest"
+                            "$ref": "#/definitions/models.UpdateClusterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -3443,7 +4150,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ProjectModel"
+                                            "$ref": "#/definitions/models.ClusterModel"
                                         }
                                     }
                                 }
@@ -3451,11 +4158,38 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Delete a cluster by cluster ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cluster"
+                ],
+                "summary": "Delete Cluster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/api/v1/projects/refs": {
-            "get": {
-                "description": "Get all projects for refs",
+        "/api/v1/clusters/{clusterID}/disable": {
+            "put": {
+                "description": "Disable a cluster by cluster ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3463,37 +4197,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
+                ],
+                "summary": "Disable Cluster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "All Project Refs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ProjectRef"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/projects/{projectID}": {
-            "get": {
-                "description": "Get project by project ID",
+        "/api/v1/clusters/{clusterID}/enable": {
+            "put": {
+                "description": "Enable a cluster by cluster ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3501,14 +4229,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Get Project",
+                "summary": "Enable Cluster",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     }
@@ -3517,25 +4245,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.ProjectModel"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing project",
+            }
+        },
+        "/api/v1/clusters/{clusterID}/extensions": {
+            "get": {
+                "description": "List extensions available in a cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3543,25 +4261,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Update Project",
+                "summary": "List Cluster Extensions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Project data",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateProjectRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -3576,7 +4285,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ProjectModel"
+                                            "$ref": "#/definitions/models.ListClusterExtensionsResponse"
                                         }
                                     }
                                 }
@@ -3584,9 +4293,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a project by project ID",
+            }
+        },
+        "/api/v1/clusters/{clusterID}/extensions/enable": {
+            "post": {
+                "description": "Enable the cluster extension feature for the specified cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3594,28 +4305,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Delete Project",
+                "summary": "Enable Cluster Extension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/projects/{projectID}/envs": {
+        "/api/v1/clusters/{clusterID}/extensions/feature-enabled": {
             "get": {
-                "description": "List envs under a specific project",
+                "description": "Check if the cluster extension feature is enabled for the specified cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3623,46 +4337,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
+                    "Cluster"
                 ],
-                "summary": "List Envs Under Project",
+                "summary": "Check Cluster Extension Feature Enabled",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageNo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "projectID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "sortOrder",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3677,7 +4361,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListEnvsResponse"
+                                            "type": "boolean"
                                         }
                                     }
                                 }
@@ -3685,9 +4369,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/clusters/{clusterID}/extensions/install": {
             "post": {
-                "description": "Create a new env under a specific project",
+                "description": "Install an extension in the specified cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3695,52 +4381,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
+                    "Cluster"
                 ],
-                "summary": "Create Env Under Project",
+                "summary": "Install Cluster Extension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Create Env Request",
+                        "description": "Extension installation information",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateEnvRequest"
+                            "$ref": "#/definitions/models.InstallClusterExtensionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.EnvModel"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/projects/{projectID}/envs/refs": {
-            "get": {
-                "description": "Get all envs for refs under a specific project",
+        "/api/v1/clusters/{clusterID}/extensions/{extensionName}": {
+            "delete": {
+                "description": "Uninstall an extension from the specified cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -3748,21 +4422,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Env"
+                    "Cluster"
                 ],
-                "summary": "All Env Refs Under Project",
+                "summary": "Uninstall Cluster Extension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "name": "projectID",
-                        "in": "query",
+                        "description": "Extension Name",
+                        "name": "extensionName",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -3770,30 +4445,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.EnvRef"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/projects/{projectID}/members": {
+        "/api/v1/clusters/{clusterID}/extensions/{extensionName}/installed-values": {
             "get": {
-                "description": "List members of a project",
+                "description": "Get the current values.yaml for an installed cluster extension",
                 "consumes": [
                     "application/json"
                 ],
@@ -3801,41 +4461,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "List Project Members",
+                "summary": "Get installed extension current values",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "name": "pageNo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "name": "sortOrder",
-                        "in": "query"
+                        "description": "Extension Name",
+                        "name": "extensionName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3850,17 +4492,37 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListProjectMembersResponse"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Add members to a project",
+            }
+        },
+        "/api/v1/clusters/{clusterID}/extensions/{extensionName}/update": {
+            "put": {
+                "description": "Update an installed cluster extension",
                 "consumes": [
                     "application/json"
                 ],
@@ -3868,53 +4530,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Add Project Members",
+                "summary": "Update cluster extension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Project members data",
-                        "name": "members",
+                        "type": "string",
+                        "description": "Extension Name",
+                        "name": "extensionName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Extension update information",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddProjectMembersRequest"
+                            "$ref": "#/definitions/models.UpdateClusterExtensionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ProjectMemberModel"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove a member from a project",
+            }
+        },
+        "/api/v1/clusters/{clusterID}/extensions/{extensionName}/values/{version}": {
+            "get": {
+                "description": "Get the default values.yaml for a specific cluster extension version",
                 "consumes": [
                     "application/json"
                 ],
@@ -3922,43 +4596,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Remove Project Member",
+                "summary": "Get cluster extension default values",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    }
-                }
-            }
-        },
-        "/api/v1/projects/{projectID}/members/addable": {
-            "get": {
-                "description": "List users that can be added to a project",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Project"
-                ],
-                "summary": "List Addable Project Members",
-                "parameters": [
+                    },
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Extension Name",
+                        "name": "extensionName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Extension Version",
+                        "name": "version",
                         "in": "path",
                         "required": true
                     }
@@ -3975,55 +4634,48 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ProjectMemberModel"
-                                            }
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/projects/{projectID}/members/{userID}": {
-            "put": {
-                "description": "Update a project member's role",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/api/v1/clusters/{clusterID}/ingress-classes": {
+            "get": {
+                "description": "Get the ingress classes of the specified cluster, used by the ingress routing backend",
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Update Project Member",
+                "summary": "List ingress classes of a cluster",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Project member data",
-                        "name": "member",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateProjectMemberRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -4038,7 +4690,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ProjectMemberModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ClusterIngressClassModel"
+                                            }
                                         }
                                     }
                                 }
@@ -4048,24 +4703,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/projects/{projectID}/ref": {
+        "/api/v1/clusters/{clusterID}/nodes": {
             "get": {
-                "description": "Get project ref by project ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Get all nodes of the specified cluster",
                 "tags": [
-                    "Project"
+                    "Cluster"
                 ],
-                "summary": "Get Project Ref",
+                "summary": "List nodes of a cluster",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
                         "in": "path",
                         "required": true
                     }
@@ -4082,7 +4731,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ProjectRef"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ClusterNodeModel"
+                                            }
                                         }
                                     }
                                 }
@@ -4092,19 +4744,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/projects/{projectID}/statistics": {
+        "/api/v1/clusters/{clusterID}/nodes/labels": {
             "get": {
-                "description": "Get project statistics including total environments, apps, and members.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Get all node labels from the specified cluster",
                 "tags": [
-                    "Project"
+                    "Cluster"
+                ],
+                "summary": "List Cluster Node Labels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Project Statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4117,7 +4772,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ProjectStatisticsModel"
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
                                         }
                                     }
                                 }
@@ -4127,19 +4785,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/statistics": {
+        "/api/v1/clusters/{clusterID}/nodes/refs": {
             "get": {
-                "description": "Get platform statistics including total clusters, projects, users, environments, apps, and",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Get all node references of the specified cluster",
                 "tags": [
-                    "Platform"
+                    "Cluster"
+                ],
+                "summary": "List references of cluster nodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Platform Statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4152,7 +4813,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.PlatformStatisticsModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ClusterNodeRef"
+                                            }
                                         }
                                     }
                                 }
@@ -4162,44 +4826,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users": {
+        "/api/v1/clusters/{clusterID}/nodes/taints": {
             "get": {
-                "description": "List users",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Get all node taints from the specified cluster",
                 "tags": [
-                    "User"
+                    "Cluster"
                 ],
-                "summary": "List Users",
+                "summary": "List Cluster Node Taints",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "name": "pageNo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "name": "sortOrder",
-                        "in": "query"
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4214,7 +4854,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ListUsersResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ClusterNodeTaintModel"
+                                            }
                                         }
                                     }
                                 }
@@ -4224,9 +4867,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/refresh-token": {
-            "post": {
-                "description": "Refresh user access token using refresh token",
+        "/api/v1/clusters/{clusterID}/nodes/{nodeName}": {
+            "get": {
+                "description": "Get details of a specific node in the cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -4234,9 +4877,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Cluster"
+                ],
+                "summary": "Get Cluster Node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node Name",
+                        "name": "nodeName",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Refresh User Token",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4249,7 +4908,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.ClusterNodeModel"
                                         }
                                     }
                                 }
@@ -4259,9 +4918,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/resources": {
+        "/api/v1/clusters/{clusterID}/ref": {
             "get": {
-                "description": "Get all ProjectRef, EnvRef, AppRef user has permission to query",
+                "description": "Get cluster ref by cluster ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4269,9 +4928,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Cluster"
+                ],
+                "summary": "Get Cluster Ref",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "clusterID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all resources user can access",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4284,7 +4952,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.GetUserResourcesResponse"
+                                            "$ref": "#/definitions/models.ClusterRef"
                                         }
                                     }
                                 }
@@ -4294,9 +4962,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/sign-in": {
-            "post": {
-                "description": "Sign in an existing user",
+        "/api/v1/envs/{envID}": {
+            "get": {
+                "description": "Get env by env ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4304,23 +4972,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Env"
                 ],
-                "summary": "Sign In User",
+                "summary": "Get Env",
                 "parameters": [
                     {
-                        "description": "User sign in request",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserSignInRequest"
-                        }
+                        "type": "string",
+                        "description": "Env ID",
+                        "name": "envID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -4330,7 +4996,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.EnvModel"
                                         }
                                     }
                                 }
@@ -4338,11 +5004,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/sign-up": {
-            "post": {
-                "description": "Sign up a new user",
+            },
+            "put": {
+                "description": "Update an existing env",
                 "consumes": [
                     "application/json"
                 ],
@@ -4350,23 +5014,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Env"
                 ],
-                "summary": "Sign Up User",
+                "summary": "Update Env",
                 "parameters": [
                     {
-                        "description": "User sign up request",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Env ID",
+                        "name": "envID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Env Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserSignUpRequest"
+                            "$ref": "#/definitions/models.UpdateEnvRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -4376,7 +5047,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.EnvModel"
                                         }
                                     }
                                 }
@@ -4384,11 +5055,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/{userID}": {
-            "get": {
-                "description": "Get user by user ID",
+            },
+            "delete": {
+                "description": "Delete an env by env ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4396,41 +5065,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Env"
                 ],
-                "summary": "Get User",
+                "summary": "Delete Env",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.UserModel"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update user information",
+            }
+        },
+        "/api/v1/envs/{envID}/action": {
+            "post": {
+                "description": "Start or stop all apps of an env in dependency order, apps a layer depends on are started first and stopped last",
                 "consumes": [
                     "application/json"
                 ],
@@ -4438,24 +5097,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Env"
                 ],
-                "summary": "Update User",
+                "summary": "Env Action",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User update request",
-                        "name": "user",
+                        "description": "Env Action Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserUpdateRequest"
+                            "$ref": "#/definitions/models.EnvActionRequest"
                         }
                     }
                 ],
@@ -4471,7 +5130,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.EnvActionResponse"
                                         }
                                     }
                                 }
@@ -4479,9 +5138,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete user by user ID",
+            }
+        },
+        "/api/v1/envs/{envID}/apps": {
+            "get": {
+                "description": "List apps under a specific env",
                 "consumes": [
                     "application/json"
                 ],
@@ -4489,30 +5150,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "App"
                 ],
-                "summary": "Delete User",
+                "summary": "List Apps Under Env",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Delete user request",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.DeleteUserRequest"
-                        }
+                        "type": "string",
+                        "name": "envID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortOrder",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -4522,7 +5204,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.ListAppsResponse"
                                         }
                                     }
                                 }
@@ -4530,11 +5212,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/{userID}/change-role": {
-            "put": {
-                "description": "Change user role",
+            },
+            "post": {
+                "description": "Create a new app under a specific env",
                 "consumes": [
                     "application/json"
                 ],
@@ -4542,30 +5222,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "App"
                 ],
-                "summary": "Change User Role",
+                "summary": "Create App Under Env",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User change role request",
-                        "name": "user",
+                        "description": "App data",
+                        "name": "app",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserChangeRoleRequest"
+                            "$ref": "#/definitions/models.CreateAppRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -4575,7 +5255,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.AppModel"
                                         }
                                     }
                                 }
@@ -4585,9 +5265,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{userID}/rename": {
-            "put": {
-                "description": "Rename user with password verification",
+        "/api/v1/envs/{envID}/apps/refs": {
+            "get": {
+                "description": "Get all apps for refs under a specific env",
                 "consumes": [
                     "application/json"
                 ],
@@ -4595,25 +5275,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "App"
                 ],
-                "summary": "Rename User",
+                "summary": "All App Refs Under Env",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User rename request",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserRenameRequest"
-                        }
+                        "type": "string",
+                        "name": "envID",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4628,7 +5305,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AppRef"
+                                            }
                                         }
                                     }
                                 }
@@ -4638,9 +5318,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{userID}/reset-password": {
-            "put": {
-                "description": "Reset user password",
+        "/api/v1/envs/{envID}/dependency-graph": {
+            "get": {
+                "description": "Get the dependency graph of the apps of an env, with the layer each app is started in",
                 "consumes": [
                     "application/json"
                 ],
@@ -4648,25 +5328,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "AppDependency"
                 ],
-                "summary": "Reset User Password",
+                "summary": "Get Env Dependency Graph",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "User reset password request",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserResetPasswordRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -4681,7 +5352,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.AppDependencyGraphModel"
                                         }
                                     }
                                 }
@@ -4691,9 +5362,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{userID}/sign-out": {
-            "post": {
-                "description": "Sign out user by clearing cookies",
+        "/api/v1/envs/{envID}/ref": {
+            "get": {
+                "description": "Get env ref by env ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4701,25 +5372,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Env"
                 ],
-                "summary": "Sign Out User",
+                "summary": "Get Env Ref",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Env ID",
+                        "name": "envID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "User sign out request",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserSignOutRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -4734,7 +5396,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserModel"
+                                            "$ref": "#/definitions/models.EnvRef"
                                         }
                                     }
                                 }
@@ -4744,166 +5406,2343 @@ const docTemplate = `{
                 }
             }
         },
-        "/healthz": {
-            "get": {
-                "description": "Get api server health status",
-                "summary": "Healthz",
-                "responses": {}
-            }
-        },
-        "/version": {
+        "/api/v1/projects": {
             "get": {
-                "description": "Get api server version",
-                "summary": "Version",
+                "description": "List projects",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "List Projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "pageNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListProjectResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Create Project",
+                "parameters": [
+                    {
+                        "description": "Project data",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/refs": {
+            "get": {
+                "description": "Get all projects for refs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "All Project Refs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProjectRef"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}": {
+            "get": {
+                "description": "Get project by project ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Get Project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Update Project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Project data",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a project by project ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Delete Project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/envs": {
+            "get": {
+                "description": "List envs under a specific project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Env"
+                ],
+                "summary": "List Envs Under Project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "projectID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListEnvsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new env under a specific project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Env"
+                ],
+                "summary": "Create Env Under Project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Env Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateEnvRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EnvModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/envs/refs": {
+            "get": {
+                "description": "Get all envs for refs under a specific project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Env"
+                ],
+                "summary": "All Env Refs Under Project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "projectID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.EnvRef"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/members": {
+            "get": {
+                "description": "List members of a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "List Project Members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListProjectMembersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add members to a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Add Project Members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Project members data",
+                        "name": "members",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddProjectMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProjectMemberModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a member from a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Remove Project Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/members/addable": {
+            "get": {
+                "description": "List users that can be added to a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "List Addable Project Members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProjectMemberModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/members/{userID}": {
+            "put": {
+                "description": "Update a project member's role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Update Project Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Project member data",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProjectMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectMemberModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/ref": {
+            "get": {
+                "description": "Get project ref by project ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Get Project Ref",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectRef"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/registry-credentials": {
+            "get": {
+                "description": "List the private registry credentials of a project, tokens are not returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectRegistryCredential"
+                ],
+                "summary": "List Project Registry Credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProjectRegistryCredentialModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a private registry credential, synced as a pull secret into every env of the project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectRegistryCredential"
+                ],
+                "summary": "Create Project Registry Credential",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Registry credential",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProjectRegistryCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectRegistryCredentialModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/registry-credentials/test": {
+            "post": {
+                "description": "Log in to the registry with a saved or unsaved credential using the registry v2 auth handshake",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectRegistryCredential"
+                ],
+                "summary": "Test Project Registry Credential",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Registry credential",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TestProjectRegistryCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TestProjectRegistryCredentialResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/registry-credentials/{credentialID}": {
+            "put": {
+                "description": "Update the username or token of a private registry credential",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectRegistryCredential"
+                ],
+                "summary": "Update Project Registry Credential",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Credential ID",
+                        "name": "credentialID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Registry credential",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProjectRegistryCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectRegistryCredentialModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a private registry credential and its pull secrets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectRegistryCredential"
+                ],
+                "summary": "Delete Project Registry Credential",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Credential ID",
+                        "name": "credentialID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectID}/statistics": {
+            "get": {
+                "description": "Get project statistics including total environments, apps, and members.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Get Project Statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProjectStatisticsModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/statistics": {
+            "get": {
+                "description": "Get platform statistics including total clusters, projects, users, environments, apps, and",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Get Platform Statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PlatformStatisticsModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "List users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "pageNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListUsersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/refresh-token": {
+            "post": {
+                "description": "Refresh user access token using refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh User Token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/resources": {
+            "get": {
+                "description": "Get all ProjectRef, EnvRef, AppRef user has permission to query",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get all resources user can access",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GetUserResourcesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/sign-in": {
+            "post": {
+                "description": "Sign in an existing user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign In User",
+                "parameters": [
+                    {
+                        "description": "User sign in request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSignInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/sign-up": {
+            "post": {
+                "description": "Sign up a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign Up User",
+                "parameters": [
+                    {
+                        "description": "User sign up request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSignUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userID}": {
+            "get": {
+                "description": "Get user by user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update user information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete user by user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delete user request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userID}/change-role": {
+            "put": {
+                "description": "Change user role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change User Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User change role request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserChangeRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userID}/rename": {
+            "put": {
+                "description": "Rename user with password verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Rename User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User rename request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRenameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userID}/reset-password": {
+            "put": {
+                "description": "Reset user password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset User Password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User reset password request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userID}/sign-out": {
+            "post": {
+                "description": "Sign out user by clearing cookies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign Out User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User sign out request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSignOutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Get api server health status",
+                "summary": "Healthz",
+                "responses": {}
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Get api server version",
+                "summary": "Version",
                 "responses": {}
             }
-        }
-    },
-    "definitions": {
-        "api.Response": {
+        }
+    },
+    "definitions": {
+        "api.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "details": {},
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AppAction": {
+            "type": "string",
+            "enum": [
+                "deploy",
+                "start",
+                "stop",
+                "rollback",
+                "update",
+                "redeploy",
+                "debug",
+                "debugOff",
+                "delete"
+            ],
+            "x-enum-comments": {
+                "AppActionDebug": "Redeploys the main container sleeping, running instances use ephemeral debug containers instead"
+            },
+            "x-enum-descriptions": [
+                "Redeploys the main container sleeping, running instances use ephemeral debug containers instead"
+            ],
+            "x-enum-varnames": [
+                "AppActionDeploy",
+                "AppActionStart",
+                "AppActionStop",
+                "AppActionRollback",
+                "AppActionUpdate",
+                "AppActionRedeploy",
+                "AppActionDebug",
+                "AppActionDebugOff",
+                "AppActionDelete"
+            ]
+        },
+        "app.AppType": {
+            "type": "string",
+            "enum": [
+                "Deployment",
+                "StatefulSet"
+            ],
+            "x-enum-varnames": [
+                "AppTypeDeployment",
+                "AppTypeStatefulSet"
+            ]
+        },
+        "app.EnvAction": {
+            "type": "string",
+            "enum": [
+                "start",
+                "stop"
+            ],
+            "x-enum-comments": {
+                "EnvActionStart": "Start the apps of the env, dependencies first",
+                "EnvActionStop": "Stop the apps of the env, dependents first"
+            },
+            "x-enum-descriptions": [
+                "Start the apps of the env, dependencies first",
+                "Stop the apps of the env, dependents first"
+            ],
+            "x-enum-varnames": [
+                "EnvActionStart",
+                "EnvActionStop"
+            ]
+        },
+        "models.AddProjectMembersRequest": {
+            "type": "object",
+            "required": [
+                "projectMembers"
+            ],
+            "properties": {
+                "projectMembers": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectMemberRole"
+                    }
+                }
+            }
+        },
+        "models.AppActionRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/app.AppAction"
+                },
+                "force": {
+                    "description": "Take over fields managed by other controllers, admin only",
+                    "type": "boolean"
+                },
+                "pruneVolumes": {
+                    "description": "Delete PVCs of volumes removed from the app",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AppConfigFileModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "configFileID": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "fileMode": {
+                    "type": "string"
+                },
+                "mountPath": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppContainerEnvVarModel": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppContainerFileModel": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "description": "Octal permission bits, e.g. \"644\"",
+                    "type": "string"
+                },
+                "modifiedAt": {
+                    "description": "ISO 8601 format",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "e.g., \"file\", \"directory\", \"symlink\", \"other\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppContainerModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "containerCommand": {
+                    "type": "string"
+                },
+                "containerID": {
+                    "type": "string"
+                },
+                "containerImage": {
+                    "type": "string"
+                },
+                "containerType": {
+                    "description": "sidecar, init",
+                    "type": "string"
+                },
+                "envVars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppContainerEnvVarModel"
+                    }
+                },
+                "limitCPU": {
+                    "description": "in milliCPU",
+                    "type": "integer"
+                },
+                "limitMemory": {
+                    "description": "in MiB",
+                    "type": "integer"
+                },
+                "requestCPU": {
+                    "description": "in milliCPU",
+                    "type": "integer"
+                },
+                "requestMemory": {
+                    "description": "in MiB",
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sortOrder": {
+                    "type": "integer"
+                },
+                "volumeMounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppContainerVolumeMountModel"
+                    }
+                }
+            }
+        },
+        "models.AppContainerVolumeMountModel": {
+            "type": "object",
+            "required": [
+                "mountPath",
+                "volumeSlug"
+            ],
+            "properties": {
+                "mountPath": {
+                    "type": "string"
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
+                "subPath": {
+                    "type": "string"
+                },
+                "volumeSlug": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppDependencyGraphEdge": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "dependsOnAppID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppDependencyGraphModel": {
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppDependencyGraphEdge"
+                    }
+                },
+                "envID": {
+                    "type": "string"
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppDependencyGraphNode"
+                    }
+                }
+            }
+        },
+        "models.AppDependencyGraphNode": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "envID": {
+                    "type": "string"
+                },
+                "envSlug": {
+                    "type": "string"
+                },
+                "external": {
+                    "description": "App of another env depended on by apps of the env",
+                    "type": "boolean"
+                },
+                "layer": {
+                    "description": "Start order of the app in the env, apps of a layer only depend on apps of lower layers",
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppDependencyModel": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
+                "appID": {
+                    "type": "string"
+                },
+                "dependencyID": {
+                    "type": "string"
+                },
+                "dependsOnAppID": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "envID": {
+                    "type": "string"
+                },
+                "envPrefix": {
+                    "type": "string"
+                },
+                "envSlug": {
+                    "type": "string"
+                },
+                "envVars": {
+                    "description": "Keys of the env vars injected into the app",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "host": {
+                    "description": "Service DNS name of the app depended on",
+                    "type": "string"
+                },
+                "ports": {
+                    "description": "Service ports of the app depended on",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "slug": {
+                    "description": "Slug of the app depended on",
                     "type": "string"
                 }
             }
         },
-        "app.AppAction": {
-            "type": "string",
-            "enum": [
-                "deploy",
-                "start",
-                "stop",
-                "rollback",
-                "update",
-                "redeploy",
-                "debug",
-                "debugOff",
-                "delete"
-            ],
-            "x-enum-varnames": [
-                "AppActionDeploy",
-                "AppActionStart",
-                "AppActionStop",
-                "AppActionRollback",
-                "AppActionUpdate",
-                "AppActionRedeploy",
-                "AppActionDebug",
-                "AppActionDebugOff",
-                "AppActionDelete"
-            ]
+        "models.AppEnvVarModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "envVarID": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
         },
-        "app.AppType": {
-            "type": "string",
-            "enum": [
-                "Deployment",
-                "StatefulSet"
-            ],
-            "x-enum-varnames": [
-                "AppTypeDeployment",
-                "AppTypeStatefulSet"
-            ]
+        "models.AppEventModel": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "firstSeen": {
+                    "description": "ISO 8601 format",
+                    "type": "string"
+                },
+                "lastSeen": {
+                    "description": "ISO 8601 format",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "objectKind": {
+                    "description": "Kind of the object the event is reported on, e.g. \"Pod\"",
+                    "type": "string"
+                },
+                "objectName": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "e.g., \"Normal\", \"Warning\"",
+                    "type": "string"
+                }
+            }
         },
-        "models.AddProjectMembersRequest": {
+        "models.AppGatewayBackendModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "description": "App of the same env, the app itself if empty",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "Service port, required for other apps",
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "weight": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 0
+                }
+            }
+        },
+        "models.AppGatewayHeaderModel": {
             "type": "object",
             "required": [
-                "projectMembers"
+                "name"
             ],
             "properties": {
-                "projectMembers": {
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppGatewayHeaderModifierModel": {
+            "type": "object",
+            "properties": {
+                "add": {
                     "type": "array",
-                    "uniqueItems": true,
                     "items": {
-                        "$ref": "#/definitions/models.ProjectMemberRole"
+                        "$ref": "#/definitions/models.AppGatewayHeaderModel"
+                    }
+                },
+                "remove": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "set": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayHeaderModel"
                     }
                 }
             }
         },
-        "models.AppActionRequest": {
+        "models.AppGatewayMatchModel": {
             "type": "object",
-            "required": [
-                "action"
-            ],
             "properties": {
-                "action": {
-                    "$ref": "#/definitions/app.AppAction"
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayValueMatchModel"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "HEAD",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "CONNECT",
+                        "OPTIONS",
+                        "TRACE",
+                        "PATCH"
+                    ]
+                },
+                "path": {
+                    "type": "string"
+                },
+                "pathType": {
+                    "description": "Defaults to PathPrefix",
+                    "type": "string",
+                    "enum": [
+                        "Exact",
+                        "PathPrefix",
+                        "RegularExpression"
+                    ]
+                },
+                "queryParams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayValueMatchModel"
+                    }
                 }
             }
         },
-        "models.AppConfigFileModel": {
+        "models.AppGatewayMirrorModel": {
             "type": "object",
             "properties": {
                 "appID": {
+                    "description": "App of the same env, the app itself if empty",
                     "type": "string"
                 },
-                "configFileID": {
+                "percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "port": {
+                    "description": "Service port, required for other apps",
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                }
+            }
+        },
+        "models.AppGatewayModel": {
+            "type": "object",
+            "required": [
+                "gatewayPort"
+            ],
+            "properties": {
+                "appID": {
                     "type": "string"
                 },
-                "content": {
+                "certID": {
                     "type": "string"
                 },
-                "fileMode": {
+                "domain": {
                     "type": "string"
                 },
-                "mountPath": {
+                "exposed": {
+                    "type": "boolean"
+                },
+                "gatewayID": {
                     "type": "string"
                 },
-                "slug": {
+                "gatewayPort": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "path": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "portName": {
+                    "description": "Name of the app port routed to",
                     "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "HTTP route rules, http and https gateways only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayRuleModel"
+                    }
+                }
+            }
+        },
+        "models.AppGatewayRedirectModel": {
+            "type": "object",
+            "properties": {
+                "hostname": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "pathType": {
+                    "type": "string",
+                    "enum": [
+                        "ReplaceFullPath",
+                        "ReplacePrefixMatch"
+                    ]
+                },
+                "port": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "scheme": {
+                    "type": "string",
+                    "enum": [
+                        "http",
+                        "https"
+                    ]
+                },
+                "statusCode": {
+                    "description": "Defaults to 302",
+                    "type": "integer",
+                    "enum": [
+                        301,
+                        302
+                    ]
                 }
             }
         },
-        "models.AppEnvVarModel": {
+        "models.AppGatewayRewriteModel": {
             "type": "object",
             "properties": {
-                "appID": {
+                "hostname": {
                     "type": "string"
                 },
-                "envVarID": {
-                    "type": "string"
-                },
-                "key": {
+                "path": {
                     "type": "string"
                 },
-                "value": {
-                    "type": "string"
+                "pathType": {
+                    "type": "string",
+                    "enum": [
+                        "ReplaceFullPath",
+                        "ReplacePrefixMatch"
+                    ]
                 }
             }
         },
-        "models.AppGatewayModel": {
+        "models.AppGatewayRuleModel": {
             "type": "object",
-            "required": [
-                "gatewayPort"
-            ],
             "properties": {
-                "appID": {
-                    "type": "string"
+                "backends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayBackendModel"
+                    }
                 },
-                "certID": {
-                    "type": "string"
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayMatchModel"
+                    }
                 },
-                "domain": {
-                    "type": "string"
+                "mirror": {
+                    "$ref": "#/definitions/models.AppGatewayMirrorModel"
                 },
-                "exposed": {
-                    "type": "boolean"
+                "redirect": {
+                    "$ref": "#/definitions/models.AppGatewayRedirectModel"
                 },
-                "gatewayID": {
-                    "type": "string"
+                "requestHeaders": {
+                    "$ref": "#/definitions/models.AppGatewayHeaderModifierModel"
                 },
-                "gatewayPort": {
-                    "type": "integer",
-                    "maximum": 65535,
-                    "minimum": 1
+                "responseHeaders": {
+                    "$ref": "#/definitions/models.AppGatewayHeaderModifierModel"
                 },
-                "path": {
+                "rewrite": {
+                    "$ref": "#/definitions/models.AppGatewayRewriteModel"
+                }
+            }
+        },
+        "models.AppGatewayValueMatchModel": {
+            "type": "object",
+            "required": [
+                "name",
+                "value"
+            ],
+            "properties": {
+                "name": {
                     "type": "string"
                 },
-                "port": {
-                    "type": "integer"
+                "type": {
+                    "description": "Defaults to Exact",
+                    "type": "string",
+                    "enum": [
+                        "Exact",
+                        "RegularExpression"
+                    ]
                 },
-                "protocol": {
+                "value": {
                     "type": "string"
                 }
             }
@@ -4914,11 +7753,23 @@ const docTemplate = `{
                 "containerName": {
                     "type": "string"
                 },
+                "containerType": {
+                    "description": "e.g., \"main\", \"sidecar\", \"init\", \"debug\"",
+                    "type": "string"
+                },
                 "image": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
+                },
+                "usage": {
+                    "description": "Actual usage, omitted without metrics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ResourceUsageModel"
+                        }
+                    ]
                 }
             }
         },
@@ -4977,6 +7828,14 @@ const docTemplate = `{
                 },
                 "status": {
                     "type": "string"
+                },
+                "usage": {
+                    "description": "Actual usage of all containers, omitted without metrics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ResourceUsageModel"
+                        }
+                    ]
                 }
             }
         },
@@ -5087,6 +7946,63 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AppNetworkPeer": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppNetworkPolicyModel": {
+            "type": "object",
+            "properties": {
+                "allowFromApps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppNetworkPeer"
+                    }
+                },
+                "allowGateway": {
+                    "type": "boolean"
+                },
+                "appID": {
+                    "type": "string"
+                },
+                "policyID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppPortModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "appProtocol": {
+                    "type": "string"
+                },
+                "containerPort": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "portID": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "servicePort": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.AppProbeModel": {
             "type": "object",
             "required": [
@@ -5162,6 +8078,183 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AppReleaseActionRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "pause",
+                        "resume",
+                        "promote",
+                        "abort"
+                    ]
+                }
+            }
+        },
+        "models.AppReleaseModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "canaryEdition": {
+                    "type": "string"
+                },
+                "canarySteps": {
+                    "description": "Traffic percentages of canary steps",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "healthyPeriodSeconds": {
+                    "type": "integer"
+                },
+                "healthySince": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "promotable": {
+                    "description": "Whether the canary edition has been healthy for the healthy period",
+                    "type": "boolean"
+                },
+                "releaseID": {
+                    "type": "string"
+                },
+                "releaseType": {
+                    "description": "e.g., \"canary\", \"blueGreen\"",
+                    "type": "string"
+                },
+                "stableEdition": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "e.g., \"progressing\", \"paused\", \"switched\", \"promoting\", \"promoted\", \"aborted\"",
+                    "type": "string"
+                },
+                "step": {
+                    "description": "Index of the current canary step, -1 before the first",
+                    "type": "integer"
+                },
+                "stepStartedAt": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Percentage of gateway traffic routed to the canary edition",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AppRolloutEditionModel": {
+            "type": "object",
+            "properties": {
+                "edition": {
+                    "type": "string"
+                },
+                "readyReplicas": {
+                    "description": "Number of ready instances running this edition",
+                    "type": "integer"
+                },
+                "replicas": {
+                    "description": "Number of instances running this edition",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AppRolloutProgressModel": {
+            "type": "object",
+            "properties": {
+                "actualEdition": {
+                    "type": "string"
+                },
+                "appID": {
+                    "type": "string"
+                },
+                "availableReplicas": {
+                    "type": "integer"
+                },
+                "desiredEdition": {
+                    "type": "string"
+                },
+                "desiredReplicas": {
+                    "type": "integer"
+                },
+                "editions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppRolloutEditionModel"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "readyReplicas": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "e.g., \"progressing\", \"complete\", \"failed\"",
+                    "type": "string"
+                },
+                "updatedReplicas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AppRolloutStrategyModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "canarySteps": {
+                    "description": "Traffic percentages of canary steps, e.g., [10, 50]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "healthyPeriodSeconds": {
+                    "type": "integer"
+                },
+                "maxSurge": {
+                    "description": "e.g., \"25%\", \"1\"",
+                    "type": "string"
+                },
+                "maxUnavailable": {
+                    "description": "e.g., \"25%\", \"0\"",
+                    "type": "string"
+                },
+                "minReadySeconds": {
+                    "type": "integer"
+                },
+                "partition": {
+                    "type": "integer"
+                },
+                "progressDeadlineSeconds": {
+                    "type": "integer"
+                },
+                "releaseType": {
+                    "description": "e.g., \"canary\", \"blueGreen\"",
+                    "type": "string"
+                },
+                "strategyID": {
+                    "type": "string"
+                },
+                "strategyType": {
+                    "description": "e.g., \"RollingUpdate\", \"Recreate\", \"OnDelete\"",
+                    "type": "string"
+                }
+            }
+        },
         "models.AppSchedulingRuleModel": {
             "type": "object",
             "properties": {
@@ -5203,13 +8296,46 @@ const docTemplate = `{
                 "effect": {
                     "type": "string"
                 },
-                "key": {
+                "key": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppTerminalSessionModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "command": {
+                    "type": "string"
+                },
+                "containerName": {
+                    "type": "string"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "instanceName": {
+                    "type": "string"
+                },
+                "recorded": {
+                    "description": "Whether an asciicast recording can be downloaded",
+                    "type": "boolean"
+                },
+                "sessionID": {
                     "type": "string"
                 },
-                "operator": {
+                "startedAt": {
                     "type": "string"
                 },
-                "value": {
+                "userID": {
                     "type": "string"
                 }
             }
@@ -5229,9 +8355,30 @@ const docTemplate = `{
                 "capacity": {
                     "type": "integer"
                 },
+                "claimName": {
+                    "type": "string"
+                },
+                "emptyDirMedium": {
+                    "type": "string"
+                },
+                "hostPath": {
+                    "type": "string"
+                },
+                "hostPathType": {
+                    "type": "string"
+                },
                 "mountPath": {
                     "type": "string"
                 },
+                "nfsPath": {
+                    "type": "string"
+                },
+                "nfsServer": {
+                    "type": "string"
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
                 "slug": {
                     "type": "string"
                 },
@@ -5249,6 +8396,69 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "volumeType": {
+                    "description": "e.g., \"emptyDir\", \"pvc\", \"hostPath\", \"nfs\", \"existingClaim\", \"shared\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppVolumeSnapshotModel": {
+            "type": "object",
+            "properties": {
+                "claimName": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readyToUse": {
+                    "type": "boolean"
+                },
+                "restoreSize": {
+                    "type": "string"
+                },
+                "scheduled": {
+                    "description": "Taken by the snapshot policy, pruned by its retention",
+                    "type": "boolean"
+                },
+                "snapshotClass": {
+                    "type": "string"
+                },
+                "volumeID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppVolumeSnapshotPolicyModel": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "intervalMinutes": {
+                    "type": "integer"
+                },
+                "lastSnapshotAt": {
+                    "type": "string"
+                },
+                "policyID": {
+                    "type": "string"
+                },
+                "retention": {
+                    "type": "integer"
+                },
+                "snapshotClass": {
+                    "type": "string"
+                },
+                "volumeID": {
                     "type": "string"
                 }
             }
@@ -5294,6 +8504,20 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ClusterIngressClassModel": {
+            "type": "object",
+            "properties": {
+                "controller": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "models.ClusterModel": {
             "type": "object",
             "properties": {
@@ -5315,6 +8539,10 @@ const docTemplate = `{
                 "gatewayIP": {
                     "type": "string"
                 },
+                "ingressClassName": {
+                    "description": "Empty for the cluster default",
+                    "type": "string"
+                },
                 "kubeConfig": {
                     "type": "string"
                 },
@@ -5324,6 +8552,10 @@ const docTemplate = `{
                 "readyNodeCount": {
                     "type": "integer"
                 },
+                "routingBackend": {
+                    "description": "e.g., \"gatewayAPI\", \"ingress\"",
+                    "type": "string"
+                },
                 "serverVersion": {
                     "type": "string"
                 },
@@ -5458,10 +8690,82 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CreateAppContainerRequest": {
+            "type": "object",
+            "required": [
+                "containerImage",
+                "containerType",
+                "slug"
+            ],
+            "properties": {
+                "containerCommand": {
+                    "type": "string"
+                },
+                "containerImage": {
+                    "type": "string"
+                },
+                "containerType": {
+                    "type": "string",
+                    "enum": [
+                        "sidecar",
+                        "init"
+                    ]
+                },
+                "envVars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppContainerEnvVarModel"
+                    }
+                },
+                "limitCPU": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "limitMemory": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "requestCPU": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "requestMemory": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sortOrder": {
+                    "type": "integer"
+                },
+                "volumeMounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppContainerVolumeMountModel"
+                    }
+                }
+            }
+        },
+        "models.CreateAppDependencyRequest": {
+            "type": "object",
+            "required": [
+                "dependsOnAppID"
+            ],
+            "properties": {
+                "dependsOnAppID": {
+                    "type": "string"
+                },
+                "envPrefix": {
+                    "description": "Defaults to the upper snake case slug of the app depended on",
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
         "models.CreateAppGatewayRequest": {
             "type": "object",
             "required": [
-                "port",
                 "protocol"
             ],
             "properties": {
@@ -5485,6 +8789,10 @@ const docTemplate = `{
                     "maximum": 65535,
                     "minimum": 1
                 },
+                "portName": {
+                    "description": "Name of the app port to route to, takes precedence over port",
+                    "type": "string"
+                },
                 "protocol": {
                     "type": "string",
                     "enum": [
@@ -5493,6 +8801,50 @@ const docTemplate = `{
                         "tcp",
                         "udp"
                     ]
+                },
+                "rules": {
+                    "description": "HTTP route rules, http and https gateways only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayRuleModel"
+                    }
+                }
+            }
+        },
+        "models.CreateAppPortRequest": {
+            "type": "object",
+            "required": [
+                "containerPort",
+                "name"
+            ],
+            "properties": {
+                "appProtocol": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "containerPort": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "protocol": {
+                    "description": "Defaults to TCP",
+                    "type": "string",
+                    "enum": [
+                        "TCP",
+                        "UDP",
+                        "SCTP"
+                    ]
+                },
+                "servicePort": {
+                    "description": "Defaults to the container port",
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
                 }
             }
         },
@@ -5629,11 +8981,8 @@ const docTemplate = `{
         "models.CreateAppVolumeRequest": {
             "type": "object",
             "required": [
-                "accessModes",
-                "capacity",
                 "mountPath",
                 "slug",
-                "volumeMode",
                 "volumeType"
             ],
             "properties": {
@@ -5644,11 +8993,38 @@ const docTemplate = `{
                     }
                 },
                 "capacity": {
-                    "type": "integer"
+                    "description": "Claim size of pvc and shared volumes, size limit of emptyDir volumes, in MiB",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "claimName": {
+                    "description": "Name of the pre-existing claim, or of the shared project volume",
+                    "type": "string"
+                },
+                "emptyDirMedium": {
+                    "type": "string",
+                    "enum": [
+                        "Memory"
+                    ]
+                },
+                "hostPath": {
+                    "type": "string"
+                },
+                "hostPathType": {
+                    "type": "string"
                 },
                 "mountPath": {
                     "type": "string"
                 },
+                "nfsPath": {
+                    "type": "string"
+                },
+                "nfsServer": {
+                    "type": "string"
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
                 "slug": {
                     "type": "string"
                 },
@@ -5666,6 +9042,27 @@ const docTemplate = `{
                     ]
                 },
                 "volumeType": {
+                    "type": "string",
+                    "enum": [
+                        "emptyDir",
+                        "pvc",
+                        "hostPath",
+                        "nfs",
+                        "existingClaim",
+                        "shared"
+                    ]
+                }
+            }
+        },
+        "models.CreateAppVolumeSnapshotRequest": {
+            "type": "object",
+            "properties": {
+                "replica": {
+                    "description": "Replica ordinal of StatefulSet apps, each replica has its own claim",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "snapshotClass": {
                     "type": "string"
                 }
             }
@@ -5687,9 +9084,20 @@ const docTemplate = `{
                 "gatewayIP": {
                     "type": "string"
                 },
+                "ingressClassName": {
+                    "type": "string"
+                },
                 "kubeConfig": {
                     "type": "string"
                 },
+                "routingBackend": {
+                    "description": "Defaults to gatewayAPI",
+                    "type": "string",
+                    "enum": [
+                        "gatewayAPI",
+                        "ingress"
+                    ]
+                },
                 "slug": {
                     "type": "string"
                 }
@@ -5712,6 +9120,15 @@ const docTemplate = `{
                 "displayName": {
                     "type": "string"
                 },
+                "networkPolicyMode": {
+                    "description": "Defaults to open",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "isolate",
+                        "deny-all-ingress"
+                    ]
+                },
                 "projectID": {
                     "type": "string"
                 },
@@ -5720,6 +9137,26 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CreateProjectRegistryCredentialRequest": {
+            "type": "object",
+            "required": [
+                "host",
+                "token",
+                "username"
+            ],
+            "properties": {
+                "host": {
+                    "description": "e.g., 'ghcr.io', 'registry.example.com:5000'",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "models.CreateProjectRequest": {
             "type": "object",
             "required": [
@@ -5738,6 +9175,37 @@ const docTemplate = `{
                 }
             }
         },
+        "models.DebugAppInstanceRequest": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "description": "Debug image, defaults to APP_DEBUG_IMAGE",
+                    "type": "string"
+                },
+                "targetContainer": {
+                    "description": "Container whose process namespace is shared, defaults to the main container",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DebugAppInstanceResponse": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "description": "Connect the exec terminal to this container",
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "instanceName": {
+                    "type": "string"
+                },
+                "targetContainer": {
+                    "type": "string"
+                }
+            }
+        },
         "models.DeleteAppConfigFilesRequest": {
             "type": "object",
             "required": [
@@ -5797,14 +9265,54 @@ const docTemplate = `{
                 }
             }
         },
-        "models.DeleteUserRequest": {
+        "models.DeleteUserRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EnvActionRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "enum": [
+                        "start",
+                        "stop"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/app.EnvAction"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.EnvActionResponse": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
+                "action": {
+                    "type": "string"
+                },
+                "envID": {
                     "type": "string"
+                },
+                "layers": {
+                    "description": "Apps in the order they are started or stopped, layer by layer",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.AppRef"
+                        }
+                    }
                 }
             }
         },
@@ -5826,6 +9334,10 @@ const docTemplate = `{
                 "envID": {
                     "type": "string"
                 },
+                "networkPolicyMode": {
+                    "description": "open, isolate or deny-all-ingress",
+                    "type": "string"
+                },
                 "projectID": {
                     "type": "string"
                 },
@@ -5851,17 +9363,20 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ExecAppContainerTerminalRequest": {
+        "models.EnvResourceUsageModel": {
             "type": "object",
             "properties": {
-                "appID": {
+                "displayName": {
                     "type": "string"
                 },
-                "containerName": {
+                "envID": {
                     "type": "string"
                 },
-                "instanceName": {
+                "slug": {
                     "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/models.ResourceUsageModel"
                 }
             }
         },
@@ -5935,6 +9450,20 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ListAppTerminalSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppTerminalSessionModel"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.ListAppsResponse": {
             "type": "object",
             "properties": {
@@ -6138,9 +9667,37 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ProjectRegistryCredentialModel": {
+            "type": "object",
+            "properties": {
+                "credentialID": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "projectID": {
+                    "type": "string"
+                },
+                "secretName": {
+                    "description": "Pull secret synced into the env namespaces of the project",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "models.ProjectStatisticsModel": {
             "type": "object",
             "properties": {
+                "envUsages": {
+                    "description": "Actual usage of each env on clusters with metrics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EnvResourceUsageModel"
+                    }
+                },
                 "totalAppGateways": {
                     "type": "integer"
                 },
@@ -6152,6 +9709,48 @@ const docTemplate = `{
                 },
                 "totalMembers": {
                     "type": "integer"
+                },
+                "usage": {
+                    "description": "Actual usage of all envs, omitted without metrics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ResourceUsageModel"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.ResourceUsageModel": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "description": "e.g., \"250m\"",
+                    "type": "string"
+                },
+                "cpuMilli": {
+                    "description": "CPU usage in millicores",
+                    "type": "integer"
+                },
+                "memory": {
+                    "description": "e.g., \"128Mi\"",
+                    "type": "string"
+                },
+                "memoryBytes": {
+                    "description": "Memory usage in bytes",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RestoreAppVolumeSnapshotRequest": {
+            "type": "object",
+            "properties": {
+                "claimName": {
+                    "description": "Name of the new claim, required unless inPlace",
+                    "type": "string"
+                },
+                "inPlace": {
+                    "description": "Replace the claim the snapshot was taken from, the app must be stopped",
+                    "type": "boolean"
                 }
             }
         },
@@ -6163,6 +9762,24 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SetAppNetworkPolicyRequest": {
+            "type": "object",
+            "required": [
+                "allowFromApps"
+            ],
+            "properties": {
+                "allowFromApps": {
+                    "description": "IDs of apps in the same env",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowGateway": {
+                    "type": "boolean"
+                }
+            }
+        },
         "models.SetAppResourceRequest": {
             "type": "object",
             "required": [
@@ -6193,6 +9810,58 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SetAppRolloutStrategyRequest": {
+            "type": "object",
+            "required": [
+                "strategyType"
+            ],
+            "properties": {
+                "canarySteps": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "healthyPeriodSeconds": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxSurge": {
+                    "type": "string"
+                },
+                "maxUnavailable": {
+                    "type": "string"
+                },
+                "minReadySeconds": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "partition": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "progressDeadlineSeconds": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "releaseType": {
+                    "type": "string",
+                    "enum": [
+                        "canary",
+                        "blueGreen"
+                    ]
+                },
+                "strategyType": {
+                    "type": "string",
+                    "enum": [
+                        "RollingUpdate",
+                        "Recreate",
+                        "OnDelete"
+                    ]
+                }
+            }
+        },
         "models.SetAppSchedulingRuleRequest": {
             "type": "object",
             "properties": {
@@ -6222,6 +9891,30 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SetAppVolumeSnapshotPolicyRequest": {
+            "type": "object",
+            "required": [
+                "intervalMinutes",
+                "retention"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "intervalMinutes": {
+                    "type": "integer",
+                    "minimum": 15
+                },
+                "retention": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "snapshotClass": {
+                    "type": "string"
+                }
+            }
+        },
         "models.TerminateAppInstanceRequest": {
             "type": "object",
             "required": [
@@ -6233,6 +9926,35 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TestProjectRegistryCredentialRequest": {
+            "type": "object",
+            "properties": {
+                "credentialID": {
+                    "description": "Tests a saved credential, host, username and token are ignored",
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TestProjectRegistryCredentialResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
         "models.ToggleAppGatewayExposedRequest": {
             "type": "object",
             "properties": {
@@ -6270,10 +9992,54 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateAppContainerRequest": {
+            "type": "object",
+            "required": [
+                "containerImage"
+            ],
+            "properties": {
+                "containerCommand": {
+                    "type": "string"
+                },
+                "containerImage": {
+                    "type": "string"
+                },
+                "envVars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppContainerEnvVarModel"
+                    }
+                },
+                "limitCPU": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "limitMemory": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "requestCPU": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "requestMemory": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "sortOrder": {
+                    "type": "integer"
+                },
+                "volumeMounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppContainerVolumeMountModel"
+                    }
+                }
+            }
+        },
         "models.UpdateAppGatewayRequest": {
             "type": "object",
             "required": [
-                "port",
                 "protocol"
             ],
             "properties": {
@@ -6297,6 +10063,10 @@ const docTemplate = `{
                     "maximum": 65535,
                     "minimum": 1
                 },
+                "portName": {
+                    "description": "Name of the app port to route to, takes precedence over port",
+                    "type": "string"
+                },
                 "protocol": {
                     "type": "string",
                     "enum": [
@@ -6305,6 +10075,13 @@ const docTemplate = `{
                         "tcp",
                         "udp"
                     ]
+                },
+                "rules": {
+                    "description": "HTTP route rules, http and https gateways only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppGatewayRuleModel"
+                    }
                 }
             }
         },
@@ -6325,6 +10102,36 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateAppPortRequest": {
+            "type": "object",
+            "required": [
+                "containerPort"
+            ],
+            "properties": {
+                "appProtocol": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "containerPort": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "protocol": {
+                    "type": "string",
+                    "enum": [
+                        "TCP",
+                        "UDP",
+                        "SCTP"
+                    ]
+                },
+                "servicePort": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                }
+            }
+        },
         "models.UpdateAppProbeRequest": {
             "type": "object",
             "required": [
@@ -6403,6 +10210,9 @@ const docTemplate = `{
                 "mountPath": {
                     "type": "string"
                 },
+                "readOnly": {
+                    "type": "boolean"
+                },
                 "subPath": {
                     "type": "string"
                 }
@@ -6448,8 +10258,19 @@ const docTemplate = `{
                 "displayName": {
                     "type": "string"
                 },
+                "ingressClassName": {
+                    "type": "string"
+                },
                 "kubeConfig": {
                     "type": "string"
+                },
+                "routingBackend": {
+                    "description": "Unchanged if empty",
+                    "type": "string",
+                    "enum": [
+                        "gatewayAPI",
+                        "ingress"
+                    ]
                 }
             }
         },
@@ -6467,6 +10288,15 @@ const docTemplate = `{
                 },
                 "envID": {
                     "type": "string"
+                },
+                "networkPolicyMode": {
+                    "description": "Unchanged when empty",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "isolate",
+                        "deny-all-ingress"
+                    ]
                 }
             }
         },
@@ -6481,6 +10311,21 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateProjectRegistryCredentialRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "token": {
+                    "description": "Keeps the current token if empty",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateProjectRequest": {
             "type": "object",
             "required": [
@@ -0,0 +1,121 @@
+// Package asciicast records terminal sessions in the asciicast v2 format, the
+// recordings can be played back with asciinema.
+package asciicast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event types of the asciicast v2 format.
+const (
+	EventOutput = "o"
+	EventInput  = "i"
+	EventResize = "r"
+)
+
+// ErrClosed is returned for events recorded after Close.
+var ErrClosed = errors.New("asciicast: recorder closed")
+
+// Header is the first line of an asciicast v2 recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes the events of a terminal session to w, one JSON array per
+// line. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+	now   func() time.Time
+	err   error
+}
+
+// NewRecorder writes the header to w and returns a recorder whose event times
+// are relative to now.
+func NewRecorder(w io.Writer, header Header) (*Recorder, error) {
+	return newRecorder(w, header, time.Now)
+}
+
+func newRecorder(w io.Writer, header Header, now func() time.Time) (*Recorder, error) {
+	header.Version = 2
+	start := now()
+	if header.Timestamp == 0 {
+		header.Timestamp = start.Unix()
+	}
+	if err := writeLine(w, header); err != nil {
+		return nil, err
+	}
+	return &Recorder{w: w, start: start, now: now}, nil
+}
+
+// Output records data written to the terminal.
+func (r *Recorder) Output(data []byte) error {
+	return r.event(EventOutput, string(data))
+}
+
+// Input records data typed into the terminal.
+func (r *Recorder) Input(data []byte) error {
+	return r.event(EventInput, string(data))
+}
+
+// Resize records a change of the terminal size.
+func (r *Recorder) Resize(width, height int) error {
+	return r.event(EventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Err returns the first error met while writing events, later events are
+// dropped once writing failed.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close stops the recording and flushes w if it buffers its writes, e.g. a
+// bufio.Writer. Events recorded after Close are dropped, so w may be closed
+// once Close returns.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == ErrClosed {
+		return nil
+	}
+	err := r.err
+	if f, ok := r.w.(interface{ Flush() error }); ok && err == nil {
+		err = f.Flush()
+	}
+	r.err = ErrClosed
+	return err
+}
+
+func (r *Recorder) event(kind, data string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	elapsed := r.now().Sub(r.start).Seconds()
+	r.err = writeLine(r.w, []any{elapsed, kind, data})
+	return r.err
+}
+
+func writeLine(w io.Writer, v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,125 @@
+package asciicast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	now := start
+	clock := func() time.Time { return now }
+
+	var buf bytes.Buffer
+	r, err := newRecorder(&buf, Header{Width: 80, Height: 24, Command: "sh"}, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now = start.Add(500 * time.Millisecond)
+	if err := r.Output([]byte("$ \x1b[0m")); err != nil {
+		t.Fatal(err)
+	}
+	now = start.Add(time.Second)
+	if err := r.Input([]byte("ls\r")); err != nil {
+		t.Fatal(err)
+	}
+	now = start.Add(2 * time.Second)
+	if err := r.Resize(120, 40); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(lines), buf.String())
+	}
+
+	var header Header
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 2 || header.Width != 80 || header.Height != 24 || header.Timestamp != start.Unix() || header.Command != "sh" {
+		t.Errorf("unexpected header %+v", header)
+	}
+
+	want := []struct {
+		elapsed float64
+		kind    string
+		data    string
+	}{
+		{0.5, EventOutput, "$ \x1b[0m"},
+		{1, EventInput, "ls\r"},
+		{2, EventResize, "120x40"},
+	}
+	for i, w := range want {
+		var event []any
+		if err := json.Unmarshal([]byte(lines[i+1]), &event); err != nil {
+			t.Fatal(err)
+		}
+		if len(event) != 3 || event[0] != w.elapsed || event[1] != w.kind || event[2] != w.data {
+			t.Errorf("event %d = %v, want [%v %q %q]", i, event, w.elapsed, w.kind, w.data)
+		}
+	}
+}
+
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestRecorderStopsAfterWriteError(t *testing.T) {
+	w := &failingWriter{n: 1}
+	r, err := NewRecorder(w, Header{Width: 80, Height: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Output([]byte("a")); err == nil {
+		t.Fatal("expected write error")
+	}
+	w.n = 10
+	if err := r.Output([]byte("b")); err == nil {
+		t.Fatal("expected the first error to be kept")
+	}
+	if r.Err() == nil {
+		t.Fatal("Err() = nil, want the write error")
+	}
+}
+
+func TestRecorderClose(t *testing.T) {
+	var out bytes.Buffer
+	buf := bufio.NewWriter(&out)
+	r, err := NewRecorder(buf, Header{Width: 80, Height: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Output([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("recording written before Close: %q", out.String())
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 2 {
+		t.Fatalf("recording has %d lines after Close, want 2", lines)
+	}
+	if err := r.Output([]byte("b")); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Output() after Close error = %v, want ErrClosed", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("second Close() = %v, want nil", err)
+	}
+}
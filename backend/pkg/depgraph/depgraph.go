@@ -0,0 +1,103 @@
+// Package depgraph orders nodes of a dependency graph, e.g. apps that depend
+// on other apps.
+package depgraph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CycleError is returned when the dependencies of nodes form a cycle.
+type CycleError struct {
+	Nodes []string // Nodes on the cycles, sorted
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle between %s", strings.Join(e.Nodes, ", "))
+}
+
+// Layers groups nodes so that every node comes after the nodes it depends
+// on: nodes of the first layer have no dependency, nodes of the second layer
+// only depend on the first one and so on. deps maps a node to the nodes it
+// depends on, dependencies not in nodes are ignored. Nodes of a layer are
+// sorted, so the result is stable.
+func Layers(nodes []string, deps map[string][]string) ([][]string, error) {
+	known := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		known[node] = true
+	}
+
+	// pending counts the unresolved dependencies of each node
+	pending := make(map[string]int, len(known))
+	dependents := make(map[string][]string, len(known))
+	for node := range known {
+		pending[node] = 0
+		for _, dep := range slices.Compact(slices.Sorted(slices.Values(deps[node]))) {
+			if !known[dep] || dep == node {
+				continue
+			}
+			pending[node]++
+			dependents[dep] = append(dependents[dep], node)
+		}
+	}
+
+	var layer []string
+	for node, n := range pending {
+		if n == 0 {
+			layer = append(layer, node)
+		}
+	}
+
+	var result [][]string
+	resolved := 0
+	for len(layer) > 0 {
+		slices.Sort(layer)
+		result = append(result, layer)
+		resolved += len(layer)
+
+		var next []string
+		for _, node := range layer {
+			for _, dependent := range dependents[node] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		layer = next
+	}
+
+	if resolved < len(pending) {
+		var cycle []string
+		for node, n := range pending {
+			if n > 0 {
+				cycle = append(cycle, node)
+			}
+		}
+		slices.Sort(cycle)
+		return nil, &CycleError{Nodes: cycle}
+	}
+
+	return result, nil
+}
+
+// Reaches tells whether from depends on to, directly or through other nodes.
+func Reaches(deps map[string][]string, from, to string) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, dep := range deps[node] {
+			if dep == to {
+				return true
+			}
+			if !visited[dep] {
+				visited[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package depgraph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLayers(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []string
+		deps  map[string][]string
+		want  [][]string
+	}{
+		{
+			name:  "no dependency",
+			nodes: []string{"web", "api", "db"},
+			want:  [][]string{{"api", "db", "web"}},
+		},
+		{
+			name:  "chain",
+			nodes: []string{"web", "api", "db"},
+			deps: map[string][]string{
+				"web": {"api"},
+				"api": {"db"},
+			},
+			want: [][]string{{"db"}, {"api"}, {"web"}},
+		},
+		{
+			name:  "diamond",
+			nodes: []string{"web", "api", "worker", "db"},
+			deps: map[string][]string{
+				"web":    {"api", "worker"},
+				"api":    {"db"},
+				"worker": {"db", "db"},
+			},
+			want: [][]string{{"db"}, {"api", "worker"}, {"web"}},
+		},
+		{
+			name:  "unknown and self dependencies are ignored",
+			nodes: []string{"api", "db"},
+			deps: map[string][]string{
+				"api": {"db", "cache", "api"},
+			},
+			want: [][]string{{"db"}, {"api"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Layers(tt.nodes, tt.deps)
+			if err != nil {
+				t.Fatalf("Layers() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Layers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayersCycle(t *testing.T) {
+	_, err := Layers([]string{"a", "b", "c", "d"}, map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+		"d": {"a"},
+	})
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Layers() error = %v, want CycleError", err)
+	}
+	// d is blocked by the cycle too
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(cycleErr.Nodes, want) {
+		t.Errorf("CycleError.Nodes = %v, want %v", cycleErr.Nodes, want)
+	}
+}
+
+func TestReaches(t *testing.T) {
+	deps := map[string][]string{
+		"web": {"api"},
+		"api": {"db", "cache"},
+		"db":  {"api"},
+	}
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"web", "api", true},
+		{"web", "cache", true},
+		{"db", "cache", true},
+		{"api", "web", false},
+		{"cache", "db", false},
+	}
+	for _, tt := range tests {
+		if got := Reaches(deps, tt.from, tt.to); got != tt.want {
+			t.Errorf("Reaches(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package fielddiff compares unstructured objects, such as decoded Kubernetes
+// manifests, and reports the paths of the fields that differ.
+package fielddiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fields returns the sorted paths of the fields whose values differ between
+// the two objects, e.g. "spec.replicas" or `metadata.labels["ketches.cn/id"]`.
+// Paths for which ignore returns true are skipped along with their children.
+// Lists of the same length are compared by index, other lists as a whole.
+func Fields(from, to map[string]any, ignore func(path string) bool) []string {
+	var result []string
+	diff("", from, to, ignore, &result)
+	sort.Strings(result)
+	return result
+}
+
+func diff(path string, from, to any, ignore func(path string) bool, out *[]string) {
+	if path != "" && ignore != nil && ignore(path) {
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	if fromIsMap && toIsMap {
+		keys := make(map[string]struct{}, len(fromMap)+len(toMap))
+		for k := range fromMap {
+			keys[k] = struct{}{}
+		}
+		for k := range toMap {
+			keys[k] = struct{}{}
+		}
+		for k := range keys {
+			diff(JoinPath(path, k), fromMap[k], toMap[k], ignore, out)
+		}
+		return
+	}
+
+	fromSlice, fromIsSlice := from.([]any)
+	toSlice, toIsSlice := to.([]any)
+	if fromIsSlice && toIsSlice && len(fromSlice) == len(toSlice) {
+		for i := range fromSlice {
+			diff(fmt.Sprintf("%s[%d]", path, i), fromSlice[i], toSlice[i], ignore, out)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*out = append(*out, path)
+	}
+}
+
+// JoinPath appends the key to the field path. Keys containing dots or
+// slashes, such as label keys, are quoted in brackets.
+func JoinPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
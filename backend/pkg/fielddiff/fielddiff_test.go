@@ -0,0 +1,94 @@
+package fielddiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   map[string]any
+		to     map[string]any
+		ignore func(string) bool
+		want   []string
+	}{
+		{
+			name: "equal",
+			from: map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+			to:   map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+		},
+		{
+			name: "changed, added and removed fields",
+			from: map[string]any{"spec": map[string]any{"replicas": int64(1), "paused": true}},
+			to:   map[string]any{"spec": map[string]any{"replicas": int64(2), "minReadySeconds": int64(5)}},
+			want: []string{"spec.minReadySeconds", "spec.paused", "spec.replicas"},
+		},
+		{
+			name: "label keys are quoted",
+			from: map[string]any{"metadata": map[string]any{"labels": map[string]any{"ketches.cn/edition": "1"}}},
+			to:   map[string]any{"metadata": map[string]any{"labels": map[string]any{"ketches.cn/edition": "2"}}},
+			want: []string{`metadata.labels["ketches.cn/edition"]`},
+		},
+		{
+			name: "lists of the same length are compared by index",
+			from: map[string]any{"ports": []any{map[string]any{"port": int64(80)}, map[string]any{"port": int64(443)}}},
+			to:   map[string]any{"ports": []any{map[string]any{"port": int64(80)}, map[string]any{"port": int64(8443)}}},
+			want: []string{"ports[1].port"},
+		},
+		{
+			name: "lists of different length are compared as a whole",
+			from: map[string]any{"ports": []any{int64(80)}},
+			to:   map[string]any{"ports": []any{int64(80), int64(443)}},
+			want: []string{"ports"},
+		},
+		{
+			name: "map replaced by a scalar",
+			from: map[string]any{"data": map[string]any{"a": "b"}},
+			to:   map[string]any{"data": "ab"},
+			want: []string{"data"},
+		},
+		{
+			name: "ignored fields and their children are skipped",
+			from: map[string]any{
+				"metadata": map[string]any{"resourceVersion": "1", "annotations": map[string]any{"ketches.cn/deployed-at": "a", "note": "x"}},
+				"status":   map[string]any{"replicas": int64(1)},
+			},
+			to: map[string]any{
+				"metadata": map[string]any{"resourceVersion": "2", "annotations": map[string]any{"ketches.cn/deployed-at": "b", "note": "y"}},
+				"status":   map[string]any{"replicas": int64(2)},
+			},
+			ignore: func(path string) bool {
+				return path == "status" || path == "metadata.resourceVersion" || strings.HasSuffix(path, `["ketches.cn/deployed-at"]`)
+			},
+			want: []string{"metadata.annotations.note"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fields(tt.from, tt.to, tt.ignore); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		path string
+		key  string
+		want string
+	}{
+		{path: "", key: "spec", want: "spec"},
+		{path: "spec", key: "replicas", want: "spec.replicas"},
+		{path: "metadata.labels", key: "ketches.cn/app", want: `metadata.labels["ketches.cn/app"]`},
+		{path: "data", key: "app.conf", want: `data["app.conf"]`},
+		{path: "", key: "app.conf", want: `["app.conf"]`},
+	}
+	for _, tt := range tests {
+		if got := JoinPath(tt.path, tt.key); got != tt.want {
+			t.Errorf("JoinPath(%q, %q) = %q, want %q", tt.path, tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package logstream reads container log streams line by line and serves them
+// as server-sent events.
+package logstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+)
+
+// DefaultMaxLineSize is the line size lines are split at unless another one
+// is given.
+const DefaultMaxLineSize = 1 << 20
+
+// Stream reads the lines of a log stream on its own goroutine.
+type Stream struct {
+	lines chan string
+	err   error
+}
+
+// NewStream starts reading rc line by line. Lines longer than maxLineSize are
+// split in chunks of maxLineSize bytes instead of failing the stream. rc is
+// closed once it is exhausted, fails or ctx is done, which also unblocks a
+// pending read.
+func NewStream(ctx context.Context, rc io.ReadCloser, maxLineSize int) *Stream {
+	if maxLineSize <= 0 {
+		maxLineSize = DefaultMaxLineSize
+	}
+	s := &Stream{lines: make(chan string)}
+	go s.read(ctx, rc, maxLineSize)
+	return s
+}
+
+// Lines returns the lines read, the channel is closed when the stream ends.
+func (s *Stream) Lines() <-chan string {
+	return s.lines
+}
+
+// Err returns why the stream ended: nil at the end of the stream, the context
+// error once ctx is done or the read error. It must only be called after the
+// lines channel is closed.
+func (s *Stream) Err() error {
+	return s.err
+}
+
+func (s *Stream) read(ctx context.Context, rc io.ReadCloser, maxLineSize int) {
+	defer close(s.lines)
+	stop := context.AfterFunc(ctx, func() { rc.Close() })
+	defer func() {
+		if stop() {
+			rc.Close()
+		}
+	}()
+
+	br := bufio.NewReaderSize(rc, maxLineSize)
+	for {
+		line, err := br.ReadSlice('\n')
+		if len(line) > 0 && (err == nil || errors.Is(err, bufio.ErrBufferFull) || errors.Is(err, io.EOF)) {
+			select {
+			case s.lines <- string(trimNewline(line)):
+			case <-ctx.Done():
+				s.err = ctx.Err()
+				return
+			}
+		}
+		switch {
+		case err == nil, errors.Is(err, bufio.ErrBufferFull):
+		case errors.Is(err, io.EOF):
+			return
+		case ctx.Err() != nil:
+			// Reads fail once rc is closed by ctx.
+			s.err = ctx.Err()
+			return
+		default:
+			s.err = err
+			return
+		}
+	}
+}
+
+func trimNewline(line []byte) []byte {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r"))
+}
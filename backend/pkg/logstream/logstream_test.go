@@ -0,0 +1,183 @@
+package logstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func collect(s *Stream) []string {
+	var lines []string
+	for line := range s.Lines() {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestStreamLines(t *testing.T) {
+	rc := io.NopCloser(strings.NewReader("first\nsecond\r\n\nlast"))
+	s := NewStream(context.Background(), rc, 0)
+
+	want := []string{"first", "second", "", "last"}
+	if got := collect(s); !slices.Equal(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestStreamLongLines(t *testing.T) {
+	long := strings.Repeat("a", 16) + strings.Repeat("b", 16) + "cc"
+	rc := io.NopCloser(strings.NewReader(long + "\nnext\n"))
+	s := NewStream(context.Background(), rc, 16)
+
+	want := []string{strings.Repeat("a", 16), strings.Repeat("b", 16), "cc", "next"}
+	if got := collect(s); !slices.Equal(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestStreamReadError(t *testing.T) {
+	pr, pw := io.Pipe()
+	s := NewStream(context.Background(), pr, 0)
+
+	errBroken := errors.New("connection reset")
+	go func() {
+		pw.Write([]byte("line\n"))
+		pw.CloseWithError(errBroken)
+	}()
+
+	if got := collect(s); !slices.Equal(got, []string{"line"}) {
+		t.Errorf("lines = %q, want [line]", got)
+	}
+	if err := s.Err(); !errors.Is(err, errBroken) {
+		t.Errorf("Err() = %v, want %v", err, errBroken)
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewStream(ctx, pr, 0)
+
+	cancel()
+	select {
+	case _, ok := <-s.Lines():
+		if ok {
+			t.Fatal("got a line from an idle stream")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream did not end after cancellation")
+	}
+	if err := s.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+	if _, err := pw.Write([]byte("x")); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("stream was not closed, write error = %v", err)
+	}
+}
+
+func TestServeSSEFakeClientset(t *testing.T) {
+	clientset := fake.NewClientset()
+	rc, err := clientset.CoreV1().Pods("default").GetLogs("app-0", &corev1.PodLogOptions{}).Stream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	w, err := NewSSEWriter(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ServeSSE(context.Background(), w, rc, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	want := "data: fake logs\n\nevent: end\ndata: eof\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestServeSSEHeartbeat(t *testing.T) {
+	pr, pw := io.Pipe()
+	rec := httptest.NewRecorder()
+	w, err := NewSSEWriter(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		pw.Write([]byte("late\n"))
+		pw.CloseWithError(errors.New("stream reset"))
+	}()
+	if err := ServeSSE(context.Background(), w, pr, Options{Heartbeat: 10 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, ": heartbeat\n\n") {
+		t.Errorf("body %q does not start with a heartbeat", body)
+	}
+	if !strings.HasSuffix(body, "data: late\n\nevent: end\ndata: stream reset\n\n") {
+		t.Errorf("body %q does not end with the line and the error", body)
+	}
+}
+
+func TestServeSSECanceled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	rec := httptest.NewRecorder()
+	w, err := NewSSEWriter(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ServeSSE(ctx, w, pr, Options{}) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ServeSSE() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ServeSSE did not return after cancellation")
+	}
+	if body := rec.Body.String(); body != "" {
+		t.Errorf("body = %q, want nothing sent to a gone client", body)
+	}
+}
+
+func TestSSEWriterMultilineData(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w, err := NewSSEWriter(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Event("notice", "a\nb"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rec.Body.String(), "event: notice\ndata: a\ndata: b\n\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package logstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHeartbeat is how often a heartbeat is sent on an idle stream unless
+// another interval is given.
+const DefaultHeartbeat = 15 * time.Second
+
+// EventEnd is the event sent when a log stream ends, its data is EndEOF or
+// the error the stream failed with.
+const (
+	EventEnd = "end"
+	EndEOF   = "eof"
+)
+
+// SSEWriter writes server-sent events, flushing each one.
+type SSEWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+// NewSSEWriter sets the SSE headers on w, it fails if w can not be flushed.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming unsupported")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	return &SSEWriter{w: w, flusher: flusher}, nil
+}
+
+// Data sends a message event.
+func (s *SSEWriter) Data(data string) error {
+	return s.Event("", data)
+}
+
+// Event sends a named event, each line of data is sent as its own data field.
+func (s *SSEWriter) Event(event, data string) error {
+	var b strings.Builder
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// Heartbeat sends a comment, which keeps proxies from closing an idle stream
+// and is ignored by clients.
+func (s *SSEWriter) Heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+// End sends the end event of a stream which ended with err.
+func (s *SSEWriter) End(err error) error {
+	if err != nil {
+		return s.Event(EventEnd, err.Error())
+	}
+	return s.Event(EventEnd, EndEOF)
+}
+
+func (s *SSEWriter) write(data string) error {
+	if _, err := io.WriteString(s.w, data); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// Options configure ServeSSE.
+type Options struct {
+	// MaxLineSize is the size long lines are split at, DefaultMaxLineSize by
+	// default.
+	MaxLineSize int
+	// Heartbeat is how often a heartbeat is sent on an idle stream,
+	// DefaultHeartbeat by default.
+	Heartbeat time.Duration
+}
+
+// ServeSSE sends each line of rc as a message event until rc ends or ctx is
+// done. The end of rc is reported with an end event, nothing is sent once ctx
+// is done as the client is gone. It returns the error writing to w.
+func ServeSSE(ctx context.Context, w *SSEWriter, rc io.ReadCloser, opts Options) error {
+	heartbeat := opts.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream := NewStream(ctx, rc, opts.MaxLineSize)
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case line, ok := <-stream.Lines():
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return w.End(stream.Err())
+			}
+			if err := w.Data(line); err != nil {
+				return err
+			}
+			ticker.Reset(heartbeat)
+		case <-ticker.C:
+			if err := w.Heartbeat(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			// Wait for the reader to close rc.
+			for range stream.Lines() {
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Package redact masks secrets such as passwords, tokens and kubeconfig
+// credentials in log output.
+package redact
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Mask replaces redacted values.
+const Mask = "[REDACTED]"
+
+// sensitiveKeys are the trailing words of keys whose values are secret, keys
+// are split into words at separators and camel case boundaries. Words in the
+// middle of a key don't count: "secretName" or "credentialID" are not secret.
+var sensitiveKeys = map[string]struct{}{
+	"password":      {},
+	"passwords":     {},
+	"passwd":        {},
+	"secret":        {},
+	"secrets":       {},
+	"token":         {},
+	"tokens":        {},
+	"kubeconfig":    {},
+	"credential":    {},
+	"credentials":   {},
+	"authorization": {},
+	"privatekey":    {},
+	"clientkeydata": {},
+	"envvalue":      {},
+}
+
+// Key tells whether values of the key are secret, e.g. "password",
+// "registryPassword", "kube_config" or "client-key-data".
+func Key(key string) bool {
+	words := splitKey(key)
+	for i := range words {
+		if _, ok := sensitiveKeys[strings.Join(words[i:], "")]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// splitKey splits a key into lower case words, e.g. "credentialID" into
+// credential and id, "client-key-data" into client, key and data.
+func splitKey(key string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	runes := []rune(key)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == '.' || unicode.IsSpace(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			// "registryPassword", or the last capital of "HTTPToken"
+			if !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+var (
+	// keyValuePattern matches key=value, key: value and "key":"value" pairs
+	// whose key contains a sensitive word, Key tells whether it is secret.
+	keyValuePattern = regexp.MustCompile(`(?i)("?)([\w.-]*(?:password|passwd|secret|token|kube_?config|credential|client-key-data|private_?key)[\w.-]*)("?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,;&}]+)`)
+	bearerPattern   = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[a-z0-9._~+/=-]+`)
+	pemPattern      = regexp.MustCompile(`-----BEGIN ([A-Z ]*PRIVATE KEY)-----[\s\S]*?-----END ([A-Z ]*PRIVATE KEY)-----`)
+)
+
+// String masks the secrets in s: values of sensitive keys, bearer and basic
+// credentials and PEM private keys.
+func String(s string) string {
+	s = pemPattern.ReplaceAllString(s, Mask)
+	s = bearerPattern.ReplaceAllString(s, "$1 "+Mask)
+	return keyValuePattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := keyValuePattern.FindStringSubmatch(match)
+		value := groups[4]
+		if !Key(groups[2]) || value == Mask || value == `"`+Mask+`"` {
+			return match
+		}
+		quote := ""
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+			quote = value[:1]
+		}
+		return groups[1] + groups[2] + groups[3] + quote + Mask + quote
+	})
+}
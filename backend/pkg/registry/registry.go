@@ -0,0 +1,289 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DockerHub is the host images without a registry host are pulled from.
+const DockerHub = "docker.io"
+
+// ErrUnauthorized is returned by Ping when the registry rejects the credentials.
+var ErrUnauthorized = errors.New("registry rejected the credentials")
+
+// ErrForbiddenAddress is returned by Ping when the registry or its token realm
+// resolves to an address the client of NewClient must not connect to.
+var ErrForbiddenAddress = errors.New("registry address is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, used by some clusters for
+// pod and service networks.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewClient returns a client for Ping. Unless allowPrivate is set, it only
+// connects to public addresses: loopback, private, link-local and shared
+// addresses are refused once names are resolved, so that user supplied
+// registries and token realms can't probe the network of the caller.
+// Proxies are not used, the proxy address would be checked instead.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddr(addr) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// NormalizeHost strips the scheme and path from a registry host and maps the
+// Docker Hub aliases to docker.io.
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(strings.ToLower(host))
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return DockerHub
+	}
+	return host
+}
+
+// ImageHost returns the registry host of an image reference, following the
+// same rules as docker: the first path component is a host only if it
+// contains a "." or ":" or is "localhost".
+func ImageHost(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if !found {
+		return DockerHub
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return NormalizeHost(first)
+	}
+	return DockerHub
+}
+
+// DockerConfigJSON renders the content of a kubernetes.io/dockerconfigjson
+// Secret for the credentials.
+func DockerConfigJSON(host, username, password string) ([]byte, error) {
+	key := NormalizeHost(host)
+	if key == DockerHub {
+		key = "https://index.docker.io/v1/"
+	}
+
+	type authEntry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	return json.Marshal(map[string]map[string]authEntry{
+		"auths": {
+			key: {
+				Username: username,
+				Password: password,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	})
+}
+
+// Ping performs the registry v2 auth handshake with the credentials: it
+// requests /v2/, follows the Basic or Bearer challenge of the registry and
+// checks the authenticated request succeeds. host may carry a scheme, https
+// is used otherwise.
+func Ping(ctx context.Context, client *http.Client, host, username, password string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	endpoint := strings.TrimSuffix(host, "/")
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		if NormalizeHost(endpoint) == DockerHub {
+			endpoint = "registry-1.docker.io"
+		}
+		endpoint = "https://" + endpoint
+	}
+	endpoint += "/v2/"
+
+	resp, err := get(ctx, client, endpoint, "")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if username == "" {
+			return nil
+		}
+		// Anonymous access is allowed, make sure the credentials are still valid
+		resp, err = get(ctx, client, endpoint, basicAuth(username, password))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return checkStatus(resp)
+	case http.StatusUnauthorized:
+	default:
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+
+	scheme, params := parseChallenge(resp.Header.Get("WWW-Authenticate"))
+	switch scheme {
+	case "basic":
+		resp, err := get(ctx, client, endpoint, basicAuth(username, password))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return checkStatus(resp)
+	case "bearer":
+		token, err := fetchToken(ctx, client, params, username, password)
+		if err != nil {
+			return err
+		}
+		resp, err := get(ctx, client, endpoint, "Bearer "+token)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return checkStatus(resp)
+	default:
+		return fmt.Errorf("unsupported auth challenge %q from %s", scheme, endpoint)
+	}
+}
+
+func fetchToken(ctx context.Context, client *http.Client, params map[string]string, username, password string) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", errors.New("bearer challenge without realm")
+	}
+	u, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("unsupported token realm scheme %q", u.Scheme)
+	}
+	q := u.Query()
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+	if scope := params["scope"]; scope != "" {
+		q.Set("scope", scope)
+	}
+	u.RawQuery = q.Encode()
+
+	var authorization string
+	if username != "" {
+		authorization = basicAuth(username, password)
+	}
+	resp, err := get(ctx, client, u.String(), authorization)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return "", err
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("token response without token")
+}
+
+func get(ctx context.Context, client *http.Client, url, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return client.Do(req)
+}
+
+func checkStatus(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	default:
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, resp.Request.URL)
+	}
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// parseChallenge parses a WWW-Authenticate header like
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+	for rest != "" {
+		var pair string
+		rest = strings.TrimLeft(rest, ", ")
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				pair, rest = value[1:], ""
+			} else {
+				pair, rest = value[1:end+1], value[end+2:]
+			}
+		} else {
+			pair, rest, _ = strings.Cut(value, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = pair
+	}
+	return strings.ToLower(scheme), params
+}
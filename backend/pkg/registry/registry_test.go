@@ -0,0 +1,178 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestImageHost(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"nginx", "docker.io"},
+		{"nginx:1.27", "docker.io"},
+		{"library/nginx", "docker.io"},
+		{"docker.io/library/nginx", "docker.io"},
+		{"index.docker.io/library/nginx", "docker.io"},
+		{"ghcr.io/ketches/ketches:latest", "ghcr.io"},
+		{"localhost/app", "localhost"},
+		{"localhost:5000/app", "localhost:5000"},
+		{"Registry.Example.com:443/team/app@sha256:abc", "registry.example.com:443"},
+	}
+	for _, tt := range tests {
+		if got := ImageHost(tt.image); got != tt.want {
+			t.Errorf("ImageHost(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestDockerConfigJSON(t *testing.T) {
+	data, err := DockerConfigJSON("https://index.docker.io", "user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var config struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := config.Auths["https://index.docker.io/v1/"]
+	if !ok {
+		t.Fatalf("missing docker hub auth entry in %s", data)
+	}
+	if entry.Auth != "dXNlcjpwYXNz" {
+		t.Errorf("auth = %q, want %q", entry.Auth, "dXNlcjpwYXNz")
+	}
+}
+
+func TestPingBasic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); ok && user == "user" && pass == "pass" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if err := Ping(context.Background(), srv.Client(), srv.URL, "user", "pass"); err != nil {
+		t.Errorf("Ping with valid credentials: %v", err)
+	}
+	if err := Ping(context.Background(), srv.Client(), srv.URL, "user", "wrong"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Ping with invalid credentials = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestPingBearer(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("service") != "fake-registry" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "secret-token"})
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer secret-token" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="fake-registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	if err := Ping(context.Background(), srv.Client(), srv.URL, "user", "pass"); err != nil {
+		t.Errorf("Ping with valid credentials: %v", err)
+	}
+	if err := Ping(context.Background(), srv.Client(), srv.URL, "user", "wrong"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Ping with invalid credentials = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if err := Ping(context.Background(), NewClient(time.Second, false), srv.URL, "", ""); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Ping of a loopback registry = %v, want ErrForbiddenAddress", err)
+	}
+	if err := Ping(context.Background(), NewClient(time.Second, true), srv.URL, "", ""); err != nil {
+		t.Errorf("Ping of a loopback registry with private networks allowed: %v", err)
+	}
+}
+
+func TestPingRejectsRealmScheme(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="file:///etc/passwd"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if err := Ping(context.Background(), srv.Client(), srv.URL, "user", "pass"); err == nil {
+		t.Error("Ping with a file token realm succeeded, want an error")
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:a/b:pull,push"`)
+	if scheme != "bearer" {
+		t.Errorf("scheme = %q, want bearer", scheme)
+	}
+	want := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:a/b:pull,push",
+	}
+	for k, v := range want {
+		if params[k] != v {
+			t.Errorf("params[%q] = %q, want %q", k, params[k], v)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package shellwords splits command lines into arguments the way a POSIX
+// shell does, without expanding variables or globs.
+package shellwords
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnterminated is returned for command lines ending inside a quote or
+// after an escaping backslash.
+var ErrUnterminated = errors.New("unterminated quote or escape")
+
+// Split returns the words of line. Words are separated by unquoted white
+// space, single quotes keep their content literally, and backslashes escape
+// the next character outside quotes and $, `, ", \ and newlines in double
+// quotes.
+func Split(line string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		escaped bool
+		quote   rune
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", r) {
+				word.WriteRune('\\')
+			}
+			if r != '\n' {
+				word.WriteRune(r)
+			}
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if escaped || quote != 0 {
+		return nil, ErrUnterminated
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,43 @@
+package shellwords
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+		err  error
+	}{
+		{name: "empty", line: "", want: nil},
+		{name: "blank", line: " \t\n", want: nil},
+		{name: "single word", line: "bash", want: []string{"bash"}},
+		{name: "white space", line: "  ls\t-la \n/tmp ", want: []string{"ls", "-la", "/tmp"}},
+		{name: "single quotes", line: `sh -c 'echo "a  b"; ls'`, want: []string{"sh", "-c", `echo "a  b"; ls`}},
+		{name: "double quotes", line: `echo "it's \"quoted\" \$HOME \n"`, want: []string{"echo", `it's "quoted" $HOME \n`}},
+		{name: "escaped space", line: `cat my\ file`, want: []string{"cat", "my file"}},
+		{name: "escaped newline", line: "echo a\\\nb", want: []string{"echo", "ab"}},
+		{name: "empty quotes", line: `printf '' ""`, want: []string{"printf", "", ""}},
+		{name: "adjacent quotes", line: `a'b'"c"d`, want: []string{"abcd"}},
+		{name: "backslash in single quotes", line: `echo 'a\b'`, want: []string{"echo", `a\b`}},
+		{name: "unterminated single quote", line: `echo 'a`, err: ErrUnterminated},
+		{name: "unterminated double quote", line: `echo "a`, err: ErrUnterminated},
+		{name: "trailing backslash", line: `echo a\`, err: ErrUnterminated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(tt.line)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Split(%q) error = %v, want %v", tt.line, err, tt.err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Split(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
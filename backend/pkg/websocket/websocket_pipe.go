@@ -0,0 +1,37 @@
+package websocket
+
+import (
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Pipe copies the messages of the websocket connection to rwc and the data
+// read from rwc back as binary messages, until either side is closed. Both
+// sides are closed when it returns.
+func Pipe(conn *websocket.Conn, rwc io.ReadWriteCloser) error {
+	errc := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(NewWriter(conn), rwc)
+		errc <- err
+	}()
+	go func() {
+		_, err := io.Copy(rwc, NewReader(conn))
+		errc <- err
+	}()
+
+	err := <-errc
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	conn.Close()
+	rwc.Close()
+	<-errc
+
+	if err == nil || errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) ||
+		websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		return nil
+	}
+	return err
+}
@@ -4,8 +4,11 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// Reader reads the payload of websocket messages as a byte stream, bytes not
+// fitting into p are kept for the next Read.
 type Reader struct {
-	conn *websocket.Conn
+	conn    *websocket.Conn
+	pending []byte
 }
 
 func NewReader(conn *websocket.Conn) *Reader {
@@ -13,10 +16,14 @@ func NewReader(conn *websocket.Conn) *Reader {
 }
 
 func (r *Reader) Read(p []byte) (int, error) {
-	_, message, err := r.conn.ReadMessage()
-	if err != nil {
-		return 0, err
+	for len(r.pending) == 0 {
+		_, message, err := r.conn.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = message
 	}
-	copy(p, message)
-	return len(message), nil
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
 }
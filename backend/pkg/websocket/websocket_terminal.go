@@ -0,0 +1,203 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Message types of the terminal protocol. Clients send stdin, resize and ping
+// messages as JSON text frames, binary frames are taken as raw stdin. The
+// terminal output is sent back as binary frames, pong and exit messages as
+// JSON text frames.
+const (
+	TerminalMessageStdin  = "stdin"
+	TerminalMessageResize = "resize"
+	TerminalMessagePing   = "ping"
+	TerminalMessagePong   = "pong"
+	TerminalMessageExit   = "exit"
+)
+
+type TerminalMessage struct {
+	Type string `json:"type"`
+	Data string `json:"data,omitempty"` // Input of stdin messages, reason of exit messages
+	Cols uint16 `json:"cols,omitempty"`
+	Rows uint16 `json:"rows,omitempty"`
+}
+
+// TerminalRecorder receives the traffic of a terminal session, e.g. an
+// asciicast recorder.
+type TerminalRecorder interface {
+	Output(data []byte) error
+	Input(data []byte) error
+	Resize(width, height int) error
+}
+
+// Terminal serves a TTY over a websocket connection. It is the stdin, stdout
+// and terminal size queue of a remote command stream.
+type Terminal struct {
+	conn        *websocket.Conn
+	idleTimeout time.Duration
+	recorder    TerminalRecorder
+
+	writeMu sync.Mutex
+	pending []byte
+	active  time.Time
+	sizes   chan remotecommand.TerminalSize
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewTerminal returns a terminal closed after idleTimeout without input, zero
+// disables the timeout. Pings keep the connection open but do not count as
+// input.
+func NewTerminal(conn *websocket.Conn, idleTimeout time.Duration) *Terminal {
+	return &Terminal{
+		conn:        conn,
+		idleTimeout: idleTimeout,
+		active:      time.Now(),
+		sizes:       make(chan remotecommand.TerminalSize, 1),
+		done:        make(chan struct{}),
+	}
+}
+
+// SetRecorder records the session, it must be set before streaming starts.
+func (t *Terminal) SetRecorder(recorder TerminalRecorder) {
+	t.recorder = recorder
+}
+
+// Read returns the input of the client, input larger than p is kept for the
+// next Read.
+func (t *Terminal) Read(p []byte) (int, error) {
+	for len(t.pending) == 0 {
+		if t.idleTimeout > 0 {
+			t.conn.SetReadDeadline(t.active.Add(t.idleTimeout))
+		}
+		messageType, data, err := t.conn.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				t.Close("idle timeout")
+			} else {
+				t.Close("")
+			}
+			return 0, io.EOF
+		}
+		if messageType == websocket.BinaryMessage {
+			t.input(data)
+			continue
+		}
+
+		var msg TerminalMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type == "" {
+			// Plain text frames of clients not speaking the protocol
+			t.input(data)
+			continue
+		}
+		switch msg.Type {
+		case TerminalMessageStdin:
+			t.input([]byte(msg.Data))
+		case TerminalMessageResize:
+			t.active = time.Now()
+			t.Resize(msg.Cols, msg.Rows)
+		case TerminalMessagePing:
+			t.writeMessage(TerminalMessage{Type: TerminalMessagePong})
+		}
+	}
+
+	n := copy(p, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+func (t *Terminal) input(data []byte) {
+	t.active = time.Now()
+	t.pending = append(t.pending, data...)
+	if t.recorder != nil {
+		t.recorder.Input(data)
+	}
+}
+
+// Write sends the output of the command to the client as a binary frame.
+func (t *Terminal) Write(p []byte) (int, error) {
+	if t.recorder != nil {
+		t.recorder.Output(p)
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if err := t.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Resize queues a new terminal size, sizes not yet taken by the stream are
+// replaced.
+func (t *Terminal) Resize(cols, rows uint16) {
+	if cols == 0 || rows == 0 {
+		return
+	}
+	if t.recorder != nil {
+		t.recorder.Resize(int(cols), int(rows))
+	}
+	size := remotecommand.TerminalSize{Width: cols, Height: rows}
+	for {
+		select {
+		case t.sizes <- size:
+			return
+		default:
+		}
+		select {
+		case <-t.sizes:
+		default:
+		}
+	}
+}
+
+// Next implements remotecommand.TerminalSizeQueue, it returns nil once the
+// terminal is closed.
+func (t *Terminal) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-t.sizes:
+		return &size
+	case <-t.done:
+		return nil
+	}
+}
+
+// Done is closed when the terminal is closed.
+func (t *Terminal) Done() <-chan struct{} {
+	return t.done
+}
+
+// Close tells the client why the session ends and closes the connection.
+func (t *Terminal) Close(reason string) {
+	t.closeOnce.Do(func() {
+		if reason != "" {
+			t.writeMessage(TerminalMessage{Type: TerminalMessageExit, Data: reason})
+		}
+		t.conn.Close()
+		close(t.done)
+	})
+}
+
+func (t *Terminal) writeMessage(msg TerminalMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	t.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	defer t.conn.SetWriteDeadline(time.Time{})
+	return t.conn.WriteMessage(websocket.TextMessage, data)
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// Writer sends each write as a binary message, the payload is not required to
+// be valid UTF-8.
 type Writer struct {
 	conn *websocket.Conn
 }
@@ -13,7 +15,7 @@ func NewWriter(conn *websocket.Conn) *Writer {
 }
 
 func (w *Writer) Write(p []byte) (int, error) {
-	err := w.conn.WriteMessage(websocket.TextMessage, p)
+	err := w.conn.WriteMessage(websocket.BinaryMessage, p)
 	if err != nil {
 		return 0, err
 	}
@@ -1,5 +1,5 @@
 import api from '@/api/axios'
-import type { appConfigFileModel, appEnvVarModel, appGatewayModel, appInstanceModel, appModel, appProbeModel, appRefModel, appSchedulingRuleModel, appVolumeModel, createAppEnvVarModel, createAppGatewayModel, createAppProbeModel, createAppVolumeModel, logsRequestModel, setAppCommandModel, setAppResourceModel, setAppSchedulingRuleModel, updateAppEnvVarModel, updateAppGatewayModel, updateAppImageModel, updateAppInfoModel, updateAppProbeModel, updateAppVolumeModel } from '@/types/app'
+import type { appConfigFileModel, appEnvVarModel, appGatewayModel, appInstanceModel, appModel, appPortModel, appProbeModel, appRefModel, appSchedulingRuleModel, appVolumeModel, createAppEnvVarModel, createAppGatewayModel, createAppPortModel, createAppProbeModel, createAppVolumeModel, logsRequestModel, setAppCommandModel, setAppResourceModel, setAppSchedulingRuleModel, updateAppEnvVarModel, updateAppGatewayModel, updateAppImageModel, updateAppInfoModel, updateAppPortModel, updateAppProbeModel, updateAppVolumeModel } from '@/types/app'
 import { getApiBaseUrl } from '@/utils/env'
 import { toast } from 'vue-sonner'
 
@@ -153,6 +153,25 @@ export async function deleteAppVolumes(appID: string, volumeIDs: string[]): Prom
     return
 }
 
+export async function listAppPorts(appID: string): Promise<appPortModel[]> {
+    const response = await api.get(`/apps/${appID}/ports`);
+    return response.data as appPortModel[];
+}
+
+export async function createAppPort(appID: string, model: createAppPortModel): Promise<appPortModel> {
+    const response = await api.post(`/apps/${appID}/ports`, model);
+    return response.data as appPortModel;
+}
+
+export async function updateAppPort(appID: string, portID: string, model: updateAppPortModel): Promise<appPortModel> {
+    const response = await api.put(`/apps/${appID}/ports/${portID}`, model);
+    return response.data as appPortModel;
+}
+
+export async function deleteAppPort(appID: string, portID: string) {
+    await api.delete(`/apps/${appID}/ports/${portID}`);
+}
+
 export async function listAppGateways(appID: string): Promise<appGatewayModel[]> {
     const response = await api.get(`/apps/${appID}/gateways`);
     return response.data as appGatewayModel[];
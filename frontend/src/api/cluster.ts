@@ -1,5 +1,5 @@
 import api from '@/api/axios';
-import type { clusterExtensionModel, clusterModel, clusterNodeModel, clusterNodeRefModel, clusterNodeTaintsModel, clusterRefModel, createClusterModel, installClusterExtensionModel, updateClusterExtensionModel, updateClusterModel } from '@/types/cluster';
+import type { clusterExtensionModel, clusterIngressClassModel, clusterModel, clusterNodeModel, clusterNodeRefModel, clusterNodeTaintsModel, clusterRefModel, createClusterModel, installClusterExtensionModel, updateClusterExtensionModel, updateClusterModel } from '@/types/cluster';
 import type { QueryAndPagedRequest } from '@/types/common.ts';
 
 export async function listClusters(filter: QueryAndPagedRequest): Promise<{ total: number, records: clusterModel[] }> {
@@ -54,6 +54,11 @@ export async function pingClusterKubeConfig(kubeConfig: string): Promise<boolean
     return response.data as boolean;
 }
 
+export async function listClusterIngressClasses(clusterID: string): Promise<clusterIngressClassModel[]> {
+    const response = await api.get(`/clusters/${clusterID}/ingress-classes`);
+    return response.data as clusterIngressClassModel[];
+}
+
 export async function listClusterNodes(clusterID: string): Promise<clusterNodeModel[]> {
     const response = await api.get(`/clusters/${clusterID}/nodes`);
     return response.data as clusterNodeModel[];
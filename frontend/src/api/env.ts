@@ -1,7 +1,7 @@
 import api from '@/api/axios';
 import type { appModel, appRefModel, createAppModel } from '@/types/app';
 import type { QueryAndPagedRequest } from '@/types/common';
-import type { envModel, envRefModel, updateEnvModel } from '@/types/env';
+import type { appDependencyGraphModel, envAction, envActionResponse, envModel, envRefModel, updateEnvModel } from '@/types/env';
 
 export async function getEnv(envID: string): Promise<envModel> {
     const response = await api.get(`/envs/${envID}`)
@@ -23,6 +23,16 @@ export async function deleteEnv(envID: string): Promise<boolean> {
     return true
 }
 
+export async function envAction(envID: string, action: envAction): Promise<envActionResponse> {
+    const response = await api.post(`/envs/${envID}/action`, { action })
+    return response.data as envActionResponse
+}
+
+export async function getEnvDependencyGraph(envID: string): Promise<appDependencyGraphModel> {
+    const response = await api.get(`/envs/${envID}/dependency-graph`)
+    return response.data as appDependencyGraphModel
+}
+
 export async function listApps(envID: string, filter: QueryAndPagedRequest): Promise<{ total: number, records: appModel[] }> {
     const response = await api.get(`/envs/${envID}/apps`, {
         params: filter,
@@ -135,6 +135,24 @@ export const volumeTypeRefs = {
     icon: SquaresSubtract,
     desc: "节点本地存储，实例漂移后数据可能丢失。",
   },
+  nfs: {
+    label: "NFS 存储",
+    value: "nfs",
+    icon: Server,
+    desc: "挂载 NFS 服务器上的共享目录。",
+  },
+  existingClaim: {
+    label: "已有存储卷声明",
+    value: "existingClaim",
+    icon: Disc3,
+    desc: "挂载集群中已存在的 PersistentVolumeClaim。",
+  },
+  shared: {
+    label: "共享存储",
+    value: "shared",
+    icon: FolderTree,
+    desc: "挂载项目内多个应用共享的存储卷。",
+  },
 };
 
 export const accessModeRefs = {
@@ -84,11 +84,20 @@ export interface appInstanceModel {
     nodeName: string
     nodeIP: string
     edition: string
+    usage?: resourceUsageModel
 }
 
 export interface appInstanceContainerModel {
     containerName: string
     status: string
+    usage?: resourceUsageModel
+}
+
+export interface resourceUsageModel {
+    cpu: string
+    memory: string
+    cpuMilli: number
+    memoryBytes: number
 }
 
 export interface appRunningInfoModel {
@@ -135,6 +144,13 @@ export interface appVolumeModel {
     storageClass?: string
     capacity: number
     volumeType?: string
+    readOnly?: boolean
+    emptyDirMedium?: string
+    hostPath?: string
+    hostPathType?: string
+    nfsServer?: string
+    nfsPath?: string
+    claimName?: string
     appID: string
 }
 
@@ -156,16 +172,107 @@ export interface createAppVolumeModel {
     storageClass?: string
     capacity: number
     volumeType?: string
+    readOnly?: boolean
+    emptyDirMedium?: string
+    hostPath?: string
+    hostPathType?: string
+    nfsServer?: string
+    nfsPath?: string
+    claimName?: string
 }
 
 export interface updateAppVolumeModel {
     mountPath: string
     subPath?: string
+    readOnly?: boolean
+}
+
+export interface appPortModel {
+    portID: string
+    appID: string
+    name: string
+    containerPort: number
+    protocol: 'TCP' | 'UDP' | 'SCTP'
+    servicePort: number
+    appProtocol?: string
+}
+
+export interface createAppPortModel {
+    name: string
+    containerPort: number
+    protocol?: 'TCP' | 'UDP' | 'SCTP'
+    servicePort?: number
+    appProtocol?: string
+}
+
+export interface updateAppPortModel {
+    containerPort: number
+    protocol?: 'TCP' | 'UDP' | 'SCTP'
+    servicePort?: number
+    appProtocol?: string
+}
+
+export interface appGatewayRuleModel {
+    matches?: appGatewayMatchModel[]
+    requestHeaders?: appGatewayHeaderModifierModel
+    responseHeaders?: appGatewayHeaderModifierModel
+    rewrite?: appGatewayRewriteModel
+    redirect?: appGatewayRedirectModel
+    mirror?: appGatewayMirrorModel
+    backends?: appGatewayBackendModel[]
+}
+
+export interface appGatewayMatchModel {
+    pathType?: 'Exact' | 'PathPrefix' | 'RegularExpression'
+    path?: string
+    method?: string
+    headers?: appGatewayValueMatchModel[]
+    queryParams?: appGatewayValueMatchModel[]
+}
+
+export interface appGatewayValueMatchModel {
+    type?: 'Exact' | 'RegularExpression'
+    name: string
+    value: string
+}
+
+export interface appGatewayHeaderModifierModel {
+    set?: { name: string, value: string }[]
+    add?: { name: string, value: string }[]
+    remove?: string[]
+}
+
+export interface appGatewayRewriteModel {
+    hostname?: string
+    pathType?: 'ReplaceFullPath' | 'ReplacePrefixMatch'
+    path?: string
+}
+
+export interface appGatewayRedirectModel {
+    scheme?: 'http' | 'https'
+    hostname?: string
+    port?: number
+    pathType?: 'ReplaceFullPath' | 'ReplacePrefixMatch'
+    path?: string
+    statusCode?: 301 | 302
+}
+
+export interface appGatewayMirrorModel {
+    appID?: string
+    port?: number
+    percent?: number
+}
+
+export interface appGatewayBackendModel {
+    appID?: string
+    port?: number
+    weight?: number
 }
 
 export interface appGatewayModel {
     gatewayID: string
     port: number
+    portName?: string
     protocol: string
     domain: string
     path: string
@@ -173,26 +280,31 @@ export interface appGatewayModel {
     gatewayPort: number
     exposed: boolean
     appID: string
+    rules?: appGatewayRuleModel[]
 }
 
 export interface createAppGatewayModel {
     port: number
+    portName?: string
     protocol: string
     domain: string
     path: string
     certID: string
     gatewayPort: number
     exposed: boolean
+    rules?: appGatewayRuleModel[]
 }
 
 export interface updateAppGatewayModel {
     port: number
+    portName?: string
     protocol: string
     domain: string
     path: string
     certID: string
     gatewayPort: number
     exposed: boolean
+    rules?: appGatewayRuleModel[]
 }
 
 export interface appProbeModel {
@@ -4,6 +4,8 @@ export interface clusterModel {
     displayName: string;
     description?: string;
     kubeConfig?: string;
+    routingBackend: clusterRoutingBackend;
+    ingressClassName?: string;
     readyNodeCount?: number;
     nodeCount?: number;
     serverVersion?: string;
@@ -11,6 +13,14 @@ export interface clusterModel {
     enabled: boolean;
 }
 
+export type clusterRoutingBackend = 'gatewayAPI' | 'ingress'
+
+export interface clusterIngressClassModel {
+    name: string;
+    controller: string;
+    isDefault: boolean;
+}
+
 export interface clusterRefModel {
     clusterID: string;
     slug: string;
@@ -23,12 +33,16 @@ export interface createClusterModel {
     kubeConfig: string
     gatewayIP?: string
     description?: string
+    routingBackend?: clusterRoutingBackend
+    ingressClassName?: string
 }
 
 export interface updateClusterModel {
     displayName: string,
     kubeConfig: string
     description?: string,
+    routingBackend?: clusterRoutingBackend,
+    ingressClassName?: string,
 }
 
 export interface clusterNodeModel {
@@ -1,3 +1,5 @@
+import type { appRefModel } from "./app"
+
 export interface envModel {
     envID: string
     slug: string
@@ -6,8 +8,17 @@ export interface envModel {
     projectID: string
     clusterID: string
     createdAt: string
+    networkPolicyMode?: envNetworkPolicyMode
 }
 
+export type envNetworkPolicyMode = 'open' | 'isolate' | 'deny-all-ingress'
+
+export const envNetworkPolicyModeOptions: { value: envNetworkPolicyMode, label: string, description: string }[] = [
+    { value: 'open', label: '开放', description: '不限制访问' },
+    { value: 'isolate', label: '环境隔离', description: '拒绝来自其他环境的访问' },
+    { value: 'deny-all-ingress', label: '拒绝所有入站', description: '仅允许应用网络策略放行的访问' },
+]
+
 export interface envRefModel {
     envID: string
     slug: string
@@ -20,10 +31,36 @@ export interface envCreateModel {
     displayName: string
     description?: string | ""
     clusterID: string | ""
+    networkPolicyMode?: envNetworkPolicyMode
 }
 
 export interface updateEnvModel {
     displayName: string,
     description?: string,
+    networkPolicyMode?: envNetworkPolicyMode,
+}
+
+
+export type envAction = 'start' | 'stop'
+
+export interface envActionResponse {
+    envID: string
+    action: envAction
+    layers: appRefModel[][]
 }
 
+export interface appDependencyGraphNode {
+    appID: string
+    slug: string
+    displayName: string
+    envID: string
+    envSlug: string
+    external?: boolean
+    layer: number
+}
+
+export interface appDependencyGraphModel {
+    envID: string
+    nodes: appDependencyGraphNode[]
+    edges: { appID: string, dependsOnAppID: string }[]
+}
@@ -1,3 +1,5 @@
+import type { resourceUsageModel } from "./app"
+
 export interface projectModel {
     projectID: string
     slug: string
@@ -37,5 +39,14 @@ export interface projectStatisticsModel {
     totalApps: number
     totalAppGateways: number
     totalMembers: number
+    usage?: resourceUsageModel
+    envUsages?: envResourceUsageModel[]
+}
+
+export interface envResourceUsageModel {
+    envID: string
+    slug: string
+    displayName: string
+    usage: resourceUsageModel
 }
 